@@ -2,14 +2,20 @@ package app
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/stiffis/UniCLI/internal/config"
 	"github.com/stiffis/UniCLI/internal/database"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/components"
 	"github.com/stiffis/UniCLI/internal/ui/screens"
 	"github.com/stiffis/UniCLI/internal/ui/styles"
+	"github.com/stiffis/UniCLI/internal/undo"
 )
 
 type View int
@@ -23,6 +29,8 @@ const (
 	ViewNotes
 	ViewStats
 	ViewSettings
+	ViewTrash
+	ViewArchive
 )
 
 type Model struct {
@@ -34,6 +42,10 @@ type Model struct {
 	taskScreen     tea.Model
 	calendarScreen tea.Model
 	coursesScreen  tea.Model
+	gradesScreen   tea.Model
+	notesScreen    tea.Model
+	trashScreen    tea.Model
+	archiveScreen  tea.Model
 	ready          bool
 	err            error
 
@@ -42,22 +54,266 @@ type Model struct {
 
 	sidebarMode   bool
 	sidebarCursor int
+
+	searchMode bool
+	search     *components.SearchOverlay
+
+	quickAddMode bool
+	quickAdd     *components.QuickAddOverlay
+
+	savedFilters []models.SavedFilter // Smart lists shown in the sidebar
+
+	pomodoro    *components.Pomodoro
+	pomodoroErr error // Error of the last Pomodoro log, shown in the status bar
+
+	notice   string // Result of the last undo or redo, shown in the status bar
+	noticeID int    // Identifies the notice cleared by clearNoticeMsg
+
+	boardErr error // Invalid board columns in the config, the defaults are used
+}
+
+// savedFiltersLoadedMsg carries the smart lists shown in the sidebar
+type savedFiltersLoadedMsg struct {
+	filters []models.SavedFilter
+	err     error
 }
 
+// sidebarViewCount is the number of views listed in the sidebar, smart lists
+// follow them
+const sidebarViewCount = 9
+
 // NewModel creates a new application model
 func NewModel(db *database.DB, cfg *config.Config) Model {
+	scale, scaleErr := gradingScale(cfg.Grading)
+	columns, boardErr := boardColumns(cfg.Board)
+	if boardErr != nil {
+		columns = models.DefaultBoardColumns()
+	}
+
 	return Model{
 		db:             db,
 		cfg:            cfg,
 		currentView:    ViewWelcome,
-		taskScreen:     screens.NewTaskScreen(db),
+		taskScreen:     screens.NewTaskScreen(db, columns, archiveAfter(cfg.Archive), matrixThresholds(cfg.Matrix)),
 		calendarScreen: screens.NewCalendarScreen(db),
 		coursesScreen:  screens.NewCoursesScreen(db),
+		gradesScreen:   screens.NewGradesScreen(db, scale, scaleErr),
+		notesScreen:    screens.NewNotesScreen(db),
+		trashScreen:    screens.NewTrashScreen(db, trashRetention(cfg.Trash)),
+		archiveScreen:  screens.NewArchiveScreen(db),
+		pomodoro:       components.NewPomodoro(pomodoroSettings(cfg.Pomodoro)),
+		boardErr:       boardErr,
+	}
+}
+
+// boardColumns converts the board config, the default columns are used if
+// none are configured
+func boardColumns(cfg config.BoardConfig) ([]models.BoardColumn, error) {
+	if len(cfg.Columns) == 0 {
+		return models.DefaultBoardColumns(), nil
+	}
+
+	var columns []models.BoardColumn
+	for _, c := range cfg.Columns {
+		column := models.BoardColumn{Name: c.Name, WIPLimit: c.WIPLimit}
+		for _, name := range c.Statuses {
+			status, ok := models.ParseTaskStatus(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown status %q in column %q", name, c.Name)
+			}
+			column.Statuses = append(column.Statuses, status)
+		}
+		columns = append(columns, column)
+	}
+
+	if err := models.ValidateBoardColumns(columns); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// boardErrorMsg reports invalid board columns in the config
+type boardErrorMsg struct {
+	err error
+}
+
+// reportBoardError shows why the configured board columns were not used
+func (m Model) reportBoardError() tea.Cmd {
+	if m.boardErr == nil {
+		return nil
+	}
+	err := m.boardErr
+	return func() tea.Msg { return boardErrorMsg{err: err} }
+}
+
+// pomodoroSettings converts the Pomodoro config, keeping the defaults of
+// missing or invalid lengths
+func pomodoroSettings(cfg config.PomodoroConfig) models.PomodoroSettings {
+	settings := models.DefaultPomodoroSettings()
+	if cfg.WorkMinutes > 0 {
+		settings.Work = time.Duration(cfg.WorkMinutes) * time.Minute
+	}
+	if cfg.ShortBreakMinutes > 0 {
+		settings.ShortBreak = time.Duration(cfg.ShortBreakMinutes) * time.Minute
+	}
+	if cfg.LongBreakMinutes > 0 {
+		settings.LongBreak = time.Duration(cfg.LongBreakMinutes) * time.Minute
+	}
+	if cfg.LongBreakEvery >= 0 {
+		settings.LongBreakEvery = cfg.LongBreakEvery
+	}
+	return settings
+}
+
+// trashRetention returns how long deleted items are kept, 0 for forever
+func trashRetention(cfg config.TrashConfig) time.Duration {
+	return time.Duration(max(0, cfg.RetentionDays)) * 24 * time.Hour
+}
+
+// archiveAfter returns how long completed tasks stay on the board, 0 for
+// forever
+func archiveAfter(cfg config.ArchiveConfig) time.Duration {
+	return time.Duration(max(0, cfg.AfterDays)) * 24 * time.Hour
+}
+
+// matrixThresholds converts the priority matrix config, keeping the defaults
+// of invalid values
+func matrixThresholds(cfg config.MatrixConfig) models.MatrixThresholds {
+	thresholds := models.DefaultMatrixThresholds()
+	if cfg.UrgentWithinDays >= 0 {
+		thresholds.UrgentWithinDays = cfg.UrgentWithinDays
+	}
+	if priority, ok := models.ParseTaskPriority(cfg.ImportantPriority); ok {
+		thresholds.ImportantFrom = priority
+	}
+	return thresholds
+}
+
+// trashPurgedMsg is sent when the expired items of the trash were purged
+type trashPurgedMsg struct {
+	err error
+}
+
+// purgeTrash permanently deletes the items older than the trash retention
+func (m Model) purgeTrash() tea.Cmd {
+	retention := trashRetention(m.cfg.Trash)
+	if retention == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		_, err := m.db.Trash().PurgeOlderThan(time.Now().Add(-retention))
+		return trashPurgedMsg{err: err}
+	}
+}
+
+// pomodoroLoggedMsg is sent when a completed Pomodoro was logged
+type pomodoroLoggedMsg struct {
+	err error
+}
+
+// logPomodoro logs a completed work phase against its task
+func (m Model) logPomodoro(msg components.PomodoroPhaseEndedMsg) tea.Cmd {
+	return func() tea.Msg {
+		pomodoro := models.NewPomodoro(msg.TaskID, msg.StartedAt, msg.EndedAt)
+		return pomodoroLoggedMsg{err: m.db.Pomodoros().Create(pomodoro)}
+	}
+}
+
+// notifyPhaseChange rings the terminal bell and runs the notify command, if
+// configured, when a Pomodoro phase ends
+func (m Model) notifyPhaseChange(msg components.PomodoroPhaseEndedMsg) tea.Cmd {
+	text := fmt.Sprintf("%s finished, time for a %s", msg.Ended.Label(), strings.ToLower(msg.Next.Label()))
+	if msg.Next == models.PomodoroWork {
+		text = "Break over, time to focus"
+	}
+	if msg.TaskTitle != "" && msg.Ended == models.PomodoroWork {
+		text = fmt.Sprintf("%s on \"%s\" finished, time for a %s", msg.Ended.Label(), msg.TaskTitle, strings.ToLower(msg.Next.Label()))
+	}
+
+	bell := m.cfg.Pomodoro.Bell
+	command := strings.Fields(m.cfg.Pomodoro.NotifyCommand)
+	return func() tea.Msg {
+		if bell {
+			// The renderer owns stdout, the bell goes to the same terminal
+			fmt.Fprint(os.Stderr, "\a")
+		}
+		if len(command) > 0 {
+			// Notifications are best effort, a failing command is ignored
+			_ = exec.Command(command[0], append(command[1:], "UniCLI", text)...).Run()
+		}
+		return nil
+	}
+}
+
+// gradingScale returns the configured grading scale, the letter scale is used
+// if none is configured
+func gradingScale(cfg config.GradingConfig) (models.GradingScale, error) {
+	if strings.TrimSpace(cfg.Scale) == "" {
+		return models.LetterScale, nil
+	}
+	scale, ok := models.GradingScaleByName(cfg.Scale)
+	if !ok {
+		return models.LetterScale, fmt.Errorf("unknown grading scale %q in config", cfg.Scale)
+	}
+	return scale, nil
+}
+
+// historyMsg is sent when a change was undone or redone
+type historyMsg struct {
+	action undo.Action
+	redo   bool
+	err    error
+}
+
+// clearNoticeMsg clears the status bar notice after a delay
+type clearNoticeMsg struct {
+	id int
+}
+
+// undoChange reverts the last change made from the screens
+func (m Model) undoChange() tea.Cmd {
+	return func() tea.Msg {
+		action, err := m.db.History().Undo()
+		return historyMsg{action: action, err: err}
 	}
 }
 
+// redoChange repeats the last undone change
+func (m Model) redoChange() tea.Cmd {
+	return func() tea.Msg {
+		action, err := m.db.History().Redo()
+		return historyMsg{action: action, redo: true, err: err}
+	}
+}
+
+// showNotice shows a message in the status bar for a few seconds
+func (m *Model) showNotice(text string, color lipgloss.Color) tea.Cmd {
+	m.noticeID++
+	m.notice = lipgloss.NewStyle().Foreground(color).Render(text)
+	id := m.noticeID
+	return tea.Tick(3*time.Second, func(time.Time) tea.Msg { return clearNoticeMsg{id: id} })
+}
+
 func (m Model) Init() tea.Cmd {
-	return m.taskScreen.Init()
+	return tea.Batch(m.taskScreen.Init(), m.loadSavedFilters(), m.purgeTrash(), m.reportBoardError())
+}
+
+// loadSavedFilters loads the smart lists
+func (m Model) loadSavedFilters() tea.Cmd {
+	return func() tea.Msg {
+		filters, err := m.db.SavedFilters().FindAll()
+		return savedFiltersLoadedMsg{filters: filters, err: err}
+	}
+}
+
+// deleteSavedFilter deletes a smart list
+func (m Model) deleteSavedFilter(id string) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.db.SavedFilters().Delete(id); err != nil {
+			return savedFiltersLoadedMsg{err: err}
+		}
+		return screens.SavedFiltersChangedMsg{}
+	}
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -66,18 +322,119 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.ready = true
+		if m.search != nil {
+			m.search.SetSize(m.contentWidth(), m.height-4)
+		}
+		if m.quickAdd != nil {
+			m.quickAdd.SetSize(m.contentWidth(), m.height-4)
+		}
 		if m.currentView == ViewTasks {
 			var cmd tea.Cmd
 			m.taskScreen, cmd = m.taskScreen.Update(msg)
 			return m, cmd
 		} else if m.currentView == ViewCalendar {
 			var cmd tea.Cmd
-			sidebarWidth := 20
-			if m.width < 80 {
-				sidebarWidth = 15
-			}
-			contentWidth := m.width - sidebarWidth - 4
-			m.calendarScreen, cmd = m.calendarScreen.Update(tea.WindowSizeMsg{Width: contentWidth, Height: m.height})
+			m.calendarScreen, cmd = m.calendarScreen.Update(tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height})
+			return m, cmd
+		} else if m.currentView == ViewGrades {
+			var cmd tea.Cmd
+			m.gradesScreen, cmd = m.gradesScreen.Update(tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height})
+			return m, cmd
+		} else if m.currentView == ViewNotes {
+			var cmd tea.Cmd
+			m.notesScreen, cmd = m.notesScreen.Update(tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height})
+			return m, cmd
+		} else if m.currentView == ViewTrash {
+			var cmd tea.Cmd
+			m.trashScreen, cmd = m.trashScreen.Update(tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height})
+			return m, cmd
+		} else if m.currentView == ViewArchive {
+			var cmd tea.Cmd
+			m.archiveScreen, cmd = m.archiveScreen.Update(tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height})
+			return m, cmd
+		}
+		return m, nil
+
+	case savedFiltersLoadedMsg:
+		if msg.err == nil {
+			m.savedFilters = msg.filters
+		}
+		if m.sidebarCursor >= sidebarViewCount+len(m.savedFilters) {
+			m.sidebarCursor = sidebarViewCount + len(m.savedFilters) - 1
+		}
+		return m, nil
+
+	case screens.SavedFiltersChangedMsg:
+		return m, m.loadSavedFilters()
+
+	case screens.StartPomodoroMsg:
+		m.pomodoroErr = nil
+		return m, m.pomodoro.Start(msg.TaskID, msg.Title)
+
+	case components.PomodoroTickMsg:
+		return m, m.pomodoro.Update(msg)
+
+	case components.PomodoroPhaseEndedMsg:
+		cmds := []tea.Cmd{m.notifyPhaseChange(msg)}
+		if msg.Ended == models.PomodoroWork && msg.TaskID != "" {
+			cmds = append(cmds, m.logPomodoro(msg))
+		}
+		return m, tea.Batch(cmds...)
+
+	case pomodoroLoggedMsg:
+		m.pomodoroErr = msg.err
+		if msg.err == nil && m.currentView == ViewTasks {
+			// Refresh the Pomodoro count of the task
+			var cmd tea.Cmd
+			m.taskScreen, cmd = m.taskScreen.Update(screens.PomodoroLoggedMsg{})
+			return m, cmd
+		}
+		return m, nil
+
+	case historyMsg:
+		switch {
+		case msg.err == undo.ErrNothingToUndo:
+			return m, m.showNotice("Nothing to undo", styles.Warning)
+		case msg.err == undo.ErrNothingToRedo:
+			return m, m.showNotice("Nothing to redo", styles.Warning)
+		case msg.err != nil && msg.redo:
+			return m, m.showNotice(fmt.Sprintf("Could not redo %s: %v", msg.action.Description, msg.err), styles.Danger)
+		case msg.err != nil:
+			return m, m.showNotice(fmt.Sprintf("Could not undo %s: %v", msg.action.Description, msg.err), styles.Danger)
+		}
+		text := "↶ Undid " + msg.action.Description
+		if msg.redo {
+			text = "↷ Redid " + msg.action.Description
+		}
+		return m, tea.Batch(m.showNotice(text, styles.Success), m.reloadCurrentView())
+
+	case boardErrorMsg:
+		return m, m.showNotice(fmt.Sprintf("Invalid board columns in config, using the defaults: %v", msg.err), styles.Danger)
+
+	case trashPurgedMsg:
+		if msg.err != nil {
+			return m, m.showNotice(fmt.Sprintf("Could not purge the trash: %v", msg.err), styles.Danger)
+		}
+		return m, nil
+
+	case clearNoticeMsg:
+		if msg.id == m.noticeID {
+			m.notice = ""
+		}
+		return m, nil
+
+	case components.SearchResultsMsg:
+		if m.searchMode {
+			var cmd tea.Cmd
+			m.search, cmd = m.search.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case components.QuickAddSavedMsg:
+		if m.quickAddMode {
+			var cmd tea.Cmd
+			m.quickAdd, cmd = m.quickAdd.Update(msg)
 			return m, cmd
 		}
 		return m, nil
@@ -105,11 +462,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// If searching, the search overlay gets all keys
+		if m.searchMode {
+			var cmd tea.Cmd
+			m.search, cmd = m.search.Update(msg)
+			if m.search.IsClosed() {
+				m.searchMode = false
+				if result := m.search.Selected(); result != nil {
+					return m.jumpTo(*result)
+				}
+			}
+			return m, cmd
+		}
+
+		// If adding quickly, the prompt gets all keys
+		if m.quickAddMode {
+			var cmd tea.Cmd
+			m.quickAdd, cmd = m.quickAdd.Update(msg)
+			if m.quickAdd.IsClosed() {
+				m.quickAddMode = false
+				if m.quickAdd.SavedCount() > 0 {
+					// Show the new items on the current screen
+					return m, m.reloadCurrentView()
+				}
+			}
+			return m, cmd
+		}
+
 		// If in sidebar mode, handle sidebar navigation
 		if m.sidebarMode {
 			switch msg.String() {
 			case "j", "down":
-				if m.sidebarCursor < 6 {
+				if m.sidebarCursor < sidebarViewCount+len(m.savedFilters)-1 {
 					m.sidebarCursor++
 				}
 				return m, nil
@@ -118,7 +502,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.sidebarCursor--
 				}
 				return m, nil
+			case "d":
+				// Delete the smart list under the cursor
+				if m.sidebarCursor >= sidebarViewCount {
+					return m, m.deleteSavedFilter(m.savedFilters[m.sidebarCursor-sidebarViewCount].ID)
+				}
+				return m, nil
 			case "enter":
+				// Smart lists open the task board with their filter
+				if m.sidebarCursor >= sidebarViewCount {
+					filter := m.savedFilters[m.sidebarCursor-sidebarViewCount]
+					m.currentView = ViewTasks
+					m.sidebarMode = false
+					var cmd tea.Cmd
+					m.taskScreen, cmd = m.taskScreen.Update(screens.ApplyFilterMsg{Name: filter.Name, Query: filter.Query})
+					return m, cmd
+				}
+
 				// Select the view (add 1 to skip ViewWelcome)
 				newView := View(m.sidebarCursor + 1)
 				m.currentView = newView
@@ -131,21 +531,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else if newView == ViewCalendar {
 					// Send a window size message to the calendar screen
 					// to ensure it has the correct dimensions.
-					sidebarWidth := 20
-					if m.width < 80 {
-						sidebarWidth = 15
-					}
-					contentWidth := m.width - sidebarWidth - 4
-					m.calendarScreen, _ = m.calendarScreen.Update(tea.WindowSizeMsg{Width: contentWidth, Height: m.height})
+					m.calendarScreen, _ = m.calendarScreen.Update(tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height})
 					cmd = m.calendarScreen.Init()
 				} else if newView == ViewCourses {
-					sidebarWidth := 20
-					if m.width < 80 {
-						sidebarWidth = 15
-					}
-					contentWidth := m.width - sidebarWidth - 4
-					m.coursesScreen, _ = m.coursesScreen.Update(tea.WindowSizeMsg{Width: contentWidth, Height: m.height})
+					m.coursesScreen, _ = m.coursesScreen.Update(tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height})
 					cmd = m.coursesScreen.Init()
+				} else if newView == ViewGrades {
+					m.gradesScreen, _ = m.gradesScreen.Update(tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height})
+					cmd = m.gradesScreen.Init()
+				} else if newView == ViewNotes {
+					m.notesScreen, _ = m.notesScreen.Update(tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height})
+					cmd = m.notesScreen.Init()
+				} else if newView == ViewTrash {
+					m.trashScreen, _ = m.trashScreen.Update(tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height})
+					cmd = m.trashScreen.Init()
+				} else if newView == ViewArchive {
+					m.archiveScreen, _ = m.archiveScreen.Update(tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height})
+					cmd = m.archiveScreen.Init()
 				}
 				return m, cmd
 			case "esc":
@@ -160,34 +562,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+c":
 			return m, tea.Quit
 		case ":":
-			if m.currentView == ViewCalendar {
-				if calendar, ok := m.calendarScreen.(screens.CalendarScreen); ok {
-					if calendar.IsEventFormActive() {
-						// Don't enter command mode if event form is active
-						break
-					}
-					if calendar.IsWeekViewActive() && calendar.IsWeekViewEventFormActive() {
-						// Don't enter command mode if week view event form is active
-						break
-					}
-					if calendar.IsDayViewActive() && calendar.IsDayViewEventFormActive() {
-						// Don't enter command mode if day view event form is active
-						break
-					}
-				}
-			}
-			if m.currentView == ViewCourses {
-				if courses, ok := m.coursesScreen.(screens.CoursesScreen); ok {
-					if courses.IsCourseFormActive() {
-						// Don't enter command mode if course form is active
-						break
-					}
-				}
+			if m.isFormActive() {
+				// Don't enter command mode while typing in a form
+				break
 			}
 			// Enter command mode
 			m.commandMode = true
 			m.commandInput = ""
 			return m, nil
+		case "/":
+			if m.isFormActive() {
+				break
+			}
+			// Open the global search
+			m.searchMode = true
+			m.search = components.NewSearchOverlay(m.db)
+			m.search.SetSize(m.contentWidth(), m.height-4)
+			return m, m.search.Init()
+		case "u":
+			if m.isFormActive() {
+				break
+			}
+			return m, m.undoChange()
+		case "ctrl+r":
+			if m.isFormActive() {
+				break
+			}
+			return m, m.redoChange()
 		}
 	}
 
@@ -200,6 +601,89 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.calendarScreen, cmd = m.calendarScreen.Update(msg)
 	case ViewCourses:
 		m.coursesScreen, cmd = m.coursesScreen.Update(msg)
+	case ViewGrades:
+		m.gradesScreen, cmd = m.gradesScreen.Update(msg)
+	case ViewNotes:
+		m.notesScreen, cmd = m.notesScreen.Update(msg)
+	case ViewTrash:
+		m.trashScreen, cmd = m.trashScreen.Update(msg)
+	case ViewArchive:
+		m.archiveScreen, cmd = m.archiveScreen.Update(msg)
+	}
+	return m, cmd
+}
+
+// isFormActive reports whether the current screen has a form or text input
+// focused, in which case global keys are passed through to it
+func (m Model) isFormActive() bool {
+	switch m.currentView {
+	case ViewTasks:
+		if tasks, ok := m.taskScreen.(*screens.TaskScreen); ok {
+			return tasks.IsTaskFormActive()
+		}
+	case ViewCalendar:
+		if calendar, ok := m.calendarScreen.(screens.CalendarScreen); ok {
+			if calendar.IsEventFormActive() {
+				return true
+			}
+			if calendar.IsWeekViewActive() && calendar.IsWeekViewEventFormActive() {
+				return true
+			}
+			if calendar.IsDayViewActive() && calendar.IsDayViewEventFormActive() {
+				return true
+			}
+			// The category form has name and color inputs
+			if calendar.IsCategoryManagerActive() {
+				return true
+			}
+		}
+	case ViewCourses:
+		if courses, ok := m.coursesScreen.(screens.CoursesScreen); ok {
+			return courses.IsCourseFormActive()
+		}
+	case ViewGrades:
+		if grades, ok := m.gradesScreen.(*screens.GradesScreen); ok {
+			return grades.IsGradeFormActive()
+		}
+	case ViewNotes:
+		if notes, ok := m.notesScreen.(*screens.NotesScreen); ok {
+			return notes.IsNoteFormActive()
+		}
+	case ViewArchive:
+		if archive, ok := m.archiveScreen.(*screens.ArchiveScreen); ok {
+			return archive.IsFilterActive()
+		}
+	}
+	return false
+}
+
+// jumpTo switches to the screen of a search result and reveals the item
+func (m Model) jumpTo(result models.SearchResult) (tea.Model, tea.Cmd) {
+	jump := screens.JumpToMsg{Kind: result.Kind, ID: result.ID}
+	size := tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height}
+
+	var cmd tea.Cmd
+	switch {
+	case result.Kind == models.SearchKindTask && result.Archived:
+		m.currentView = ViewArchive
+		m.archiveScreen, _ = m.archiveScreen.Update(size)
+		m.archiveScreen, cmd = m.archiveScreen.Update(jump)
+	case result.Kind == models.SearchKindTask:
+		m.currentView = ViewTasks
+		m.taskScreen, _ = m.taskScreen.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+		m.taskScreen, cmd = m.taskScreen.Update(jump)
+	case result.Kind == models.SearchKindEvent:
+		m.currentView = ViewCalendar
+		m.calendarScreen, _ = m.calendarScreen.Update(size)
+		m.calendarScreen, cmd = m.calendarScreen.Update(jump)
+	case result.Kind == models.SearchKindCourse:
+		m.currentView = ViewCourses
+		m.coursesScreen, _ = m.coursesScreen.Update(size)
+		m.coursesScreen, cmd = m.coursesScreen.Update(jump)
+	case result.Kind == models.SearchKindNote, result.Kind == models.SearchKindCourseNote:
+		m.currentView = ViewNotes
+		m.notesScreen, _ = m.notesScreen.Update(size)
+		m.notesScreen, cmd = m.notesScreen.Update(jump)
 	}
 	return m, cmd
 }
@@ -211,12 +695,32 @@ func (m Model) executeCommand() (tea.Model, tea.Cmd) {
 	m.commandMode = false
 	m.commandInput = ""
 
+	if fields := strings.Fields(cmd); len(fields) > 0 && (fields[0] == "pomo" || fields[0] == "pomodoro") {
+		return m.executePomodoroCommand(fields[1:])
+	}
+
+	// :add opens the quick-add prompt, filled with the rest of the command
+	if name, text, _ := strings.Cut(cmd, " "); name == "add" || name == "a" {
+		m.quickAddMode = true
+		m.quickAdd = components.NewQuickAddOverlay(m.db, strings.TrimSpace(text))
+		m.quickAdd.SetSize(m.contentWidth(), m.height-4)
+		return m, m.quickAdd.Init()
+	}
+
 	switch cmd {
 	case "q", "quit":
 		return m, tea.Quit
 	case "h", "help":
 		m.currentView = ViewSettings // Placeholder for now
 		return m, nil
+	case "trash":
+		m.currentView = ViewTrash
+		m.trashScreen, _ = m.trashScreen.Update(tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height})
+		return m, m.trashScreen.Init()
+	case "archive":
+		m.currentView = ViewArchive
+		m.archiveScreen, _ = m.archiveScreen.Update(tea.WindowSizeMsg{Width: m.contentWidth(), Height: m.height})
+		return m, m.archiveScreen.Init()
 	case "s", "sidebar":
 		// Enter sidebar navigation mode
 		m.sidebarMode = true
@@ -231,6 +735,46 @@ func (m Model) executeCommand() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// reloadCurrentView reloads the data of the current screen
+func (m Model) reloadCurrentView() tea.Cmd {
+	switch m.currentView {
+	case ViewTasks:
+		return m.taskScreen.Init()
+	case ViewCalendar:
+		return m.calendarScreen.Init()
+	case ViewCourses:
+		return m.coursesScreen.Init()
+	case ViewGrades:
+		return m.gradesScreen.Init()
+	case ViewNotes:
+		return m.notesScreen.Init()
+	case ViewTrash:
+		return m.trashScreen.Init()
+	case ViewArchive:
+		return m.archiveScreen.Init()
+	}
+	return nil
+}
+
+// executePomodoroCommand handles :pomo [stop|pause|skip]. Without arguments a
+// Pomodoro not attached to any task is started.
+func (m Model) executePomodoroCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		m.pomodoroErr = nil
+		return m, m.pomodoro.Start("", "")
+	}
+
+	switch args[0] {
+	case "stop":
+		m.pomodoro.Stop()
+	case "pause", "resume":
+		return m, m.pomodoro.TogglePause()
+	case "skip":
+		return m, m.pomodoro.Skip()
+	}
+	return m, nil
+}
+
 func (m Model) View() string {
 	if !m.ready {
 		return "Initializing..."
@@ -304,13 +848,25 @@ func (m Model) View() string {
 	case ViewCourses:
 		content = m.coursesScreen.View()
 	case ViewGrades:
-		content = "Grades View (Coming Soon)"
+		content = m.gradesScreen.View()
 	case ViewNotes:
-		content = "Notes View (Coming Soon)"
+		content = m.notesScreen.View()
 	case ViewStats:
 		content = "Statistics View (Coming Soon)"
 	case ViewSettings:
 		content = "Settings View (Coming Soon)"
+	case ViewTrash:
+		content = m.trashScreen.View()
+	case ViewArchive:
+		content = m.archiveScreen.View()
+	}
+
+	// The search overlay replaces the current screen while open
+	if m.searchMode {
+		content = m.search.View()
+	}
+	if m.quickAddMode {
+		content = m.quickAdd.View()
 	}
 
 	contentPanelStyle := styles.Panel.
@@ -358,6 +914,8 @@ func (m Model) renderSidebar(height int, width int) string {
 		{ViewNotes, "5", "󰷈", "Notes"},
 		{ViewStats, "6", "󰄨", "Stats"},
 		{ViewSettings, "7", "", "Settings"},
+		{ViewTrash, "8", "󰩺", "Trash"},
+		{ViewArchive, "9", "󰀼", "Archive"},
 	}
 
 	var items []string
@@ -382,6 +940,30 @@ func (m Model) renderSidebar(height int, width int) string {
 		items = append(items, item)
 	}
 
+	// Smart lists (saved task filters)
+	if len(m.savedFilters) > 0 {
+		items = append(items, "", lipgloss.NewStyle().
+			Foreground(styles.Muted).
+			Bold(true).
+			Padding(0, 1).
+			Render("Smart Lists"))
+	}
+	for i, filter := range m.savedFilters {
+		style := lipgloss.NewStyle().Padding(0, 1)
+		if m.sidebarMode && sidebarViewCount+i == m.sidebarCursor {
+			style = style.
+				Background(styles.Secondary).
+				Foreground(styles.Background).
+				Bold(true)
+		}
+
+		name := filter.Name
+		if runes := []rune(name); len(runes) > width-6 {
+			name = string(runes[:max(1, width-7)]) + "…"
+		}
+		items = append(items, style.Render(" "+name))
+	}
+
 	sidebarContent := lipgloss.JoinVertical(lipgloss.Left, items...)
 
 	// Choose border color based on sidebar mode
@@ -400,8 +982,13 @@ func (m Model) renderSidebar(height int, width int) string {
 
 // renderStatusBar renders the bottom status bar
 func (m Model) renderStatusBar() string {
-	// Terminal size indicator (right side)
-	terminalSize := styles.Dimmed.Render(fmt.Sprintf("%dx%d", m.width, m.height))
+	// Terminal size indicator (right side), after the Pomodoro countdown
+	rightContent := styles.Dimmed.Render(fmt.Sprintf("%dx%d", m.width, m.height))
+	if m.pomodoroErr != nil {
+		rightContent = lipgloss.NewStyle().Foreground(styles.Danger).Render("⚠ "+m.pomodoroErr.Error()) + "  " + rightContent
+	} else if pomodoro := m.pomodoro.View(); pomodoro != "" {
+		rightContent = pomodoro + "  " + rightContent
+	}
 
 	// If in command mode, show command input
 	if m.commandMode {
@@ -416,7 +1003,7 @@ func (m Model) renderStatusBar() string {
 			Render(" ")
 
 		leftContent := commandPrompt + cursor
-		spacing := m.width - lipgloss.Width(leftContent) - lipgloss.Width(terminalSize) - 2
+		spacing := m.width - lipgloss.Width(leftContent) - lipgloss.Width(rightContent) - 2
 		if spacing < 0 {
 			spacing = 0
 		}
@@ -425,7 +1012,7 @@ func (m Model) renderStatusBar() string {
 			lipgloss.Top,
 			leftContent,
 			strings.Repeat(" ", spacing),
-			terminalSize,
+			rightContent,
 		)
 
 		return styles.StatusBar.
@@ -435,8 +1022,12 @@ func (m Model) renderStatusBar() string {
 
 	// If in sidebar mode, show navigation help
 	if m.sidebarMode {
-		leftContent := styles.Dimmed.Render("SIDEBAR: j/k to navigate  |  Enter to select  |  Esc to exit")
-		spacing := m.width - lipgloss.Width(leftContent) - lipgloss.Width(terminalSize) - 2
+		help := "SIDEBAR: j/k to navigate  |  Enter to select  |  Esc to exit"
+		if m.sidebarCursor >= sidebarViewCount {
+			help = "SIDEBAR: j/k to navigate  |  Enter to open smart list  |  d to delete  |  Esc to exit"
+		}
+		leftContent := styles.Dimmed.Render(help)
+		spacing := m.width - lipgloss.Width(leftContent) - lipgloss.Width(rightContent) - 2
 		if spacing < 0 {
 			spacing = 0
 		}
@@ -445,7 +1036,7 @@ func (m Model) renderStatusBar() string {
 			lipgloss.Top,
 			leftContent,
 			strings.Repeat(" ", spacing),
-			terminalSize,
+			rightContent,
 		)
 
 		return styles.StatusBar.
@@ -453,9 +1044,12 @@ func (m Model) renderStatusBar() string {
 			Render(statusLine)
 	}
 
-	// Normal mode status bar
-	leftContent := styles.Dimmed.Render("[:s] Sidebar  |  [:h] Help  |  [:q] Quit")
-	spacing := m.width - lipgloss.Width(leftContent) - lipgloss.Width(terminalSize) - 2
+	// Normal mode status bar, the hints make way for an undo notice
+	leftContent := styles.Dimmed.Render("[:s] Sidebar  |  [/] Search  |  [:add] Quick add  |  [u] Undo  |  [:h] Help  |  [:q] Quit")
+	if m.notice != "" {
+		leftContent = m.notice
+	}
+	spacing := m.width - lipgloss.Width(leftContent) - lipgloss.Width(rightContent) - 2
 	if spacing < 0 {
 		spacing = 0
 	}
@@ -464,7 +1058,7 @@ func (m Model) renderStatusBar() string {
 		lipgloss.Top,
 		leftContent,
 		strings.Repeat(" ", spacing),
-		terminalSize,
+		rightContent,
 	)
 
 	return styles.StatusBar.
@@ -472,6 +1066,16 @@ func (m Model) renderStatusBar() string {
 		Render(statusLine)
 }
 
+// contentWidth returns the width available to the current screen, accounting
+// for the sidebar and the borders of both panels
+func (m Model) contentWidth() int {
+	sidebarWidth := 20
+	if m.width < 80 {
+		sidebarWidth = 15
+	}
+	return m.width - sidebarWidth - 4
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -1,24 +1,77 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 )
 
 type Config struct {
-	DatabasePath string
-	DataDir      string
-	Theme        Theme
+	DatabasePath string         `json:"database_path"`
+	DataDir      string         `json:"-"`
+	Theme        Theme          `json:"theme"`
+	Grading      GradingConfig  `json:"grading"`
+	Pomodoro     PomodoroConfig `json:"pomodoro"`
+	Trash        TrashConfig    `json:"trash"`
+	Archive      ArchiveConfig  `json:"archive"`
+	Board        BoardConfig    `json:"board"`
+	Matrix       MatrixConfig   `json:"matrix"`
+}
+
+// GradingConfig configures how course averages are turned into a GPA
+type GradingConfig struct {
+	Scale string `json:"scale"` // "letter", "vigesimal" or "percentage"
+}
+
+// PomodoroConfig configures the Pomodoro timer
+type PomodoroConfig struct {
+	WorkMinutes       int    `json:"work_minutes"`
+	ShortBreakMinutes int    `json:"short_break_minutes"`
+	LongBreakMinutes  int    `json:"long_break_minutes"`
+	LongBreakEvery    int    `json:"long_break_every"` // Work sessions before a long break
+	Bell              bool   `json:"bell"`             // Ring the terminal bell when a phase ends
+	NotifyCommand     string `json:"notify_command"`   // e.g. "notify-send", called with a title and a message
+}
+
+// TrashConfig configures how long deleted items are kept
+type TrashConfig struct {
+	RetentionDays int `json:"retention_days"` // 0 keeps deleted items until the trash is emptied
+}
+
+// ArchiveConfig configures when completed tasks leave the board
+type ArchiveConfig struct {
+	AfterDays int `json:"after_days"` // 0 turns automatic archiving off
+}
+
+// BoardConfig configures the columns of the task board, empty for the default
+// To Do, In Progress and Done
+type BoardConfig struct {
+	Columns []ColumnConfig `json:"columns"`
+}
+
+// ColumnConfig is a column of the task board
+type ColumnConfig struct {
+	Name     string   `json:"name"`
+	Statuses []string `json:"statuses"`  // pending, in_progress, completed or cancelled; moved tasks get the first
+	WIPLimit int      `json:"wip_limit"` // 0 for no limit
+}
+
+// MatrixConfig configures which tasks the priority matrix treats as urgent
+// and important
+type MatrixConfig struct {
+	UrgentWithinDays  int    `json:"urgent_within_days"` // Tasks due within this many days are urgent, 0 for today
+	ImportantPriority string `json:"important_priority"` // low, medium, high or urgent; tasks with at least this priority are important
 }
 
 type Theme struct {
-	Primary   string
-	Secondary string
-	Success   string
-	Warning   string
-	Danger    string
-	Info      string
-	Muted     string
+	Primary   string `json:"primary"`
+	Secondary string `json:"secondary"`
+	Success   string `json:"success"`
+	Warning   string `json:"warning"`
+	Danger    string `json:"danger"`
+	Info      string `json:"info"`
+	Muted     string `json:"muted"`
 }
 
 func DefaultTheme() Theme {
@@ -49,7 +102,48 @@ func Load() (*Config, error) {
 		DatabasePath: filepath.Join(dataDir, "unicli.db"),
 		DataDir:      dataDir,
 		Theme:        DefaultTheme(),
+		Grading: GradingConfig{
+			Scale: "letter",
+		},
+		Pomodoro: PomodoroConfig{
+			WorkMinutes:       25,
+			ShortBreakMinutes: 5,
+			LongBreakMinutes:  15,
+			LongBreakEvery:    4,
+			Bell:              true,
+		},
+		Trash: TrashConfig{
+			RetentionDays: 30,
+		},
+		Archive: ArchiveConfig{
+			AfterDays: 14,
+		},
+		Matrix: MatrixConfig{
+			UrgentWithinDays:  2,
+			ImportantPriority: "high",
+		},
+	}
+
+	if err := cfg.loadFile(filepath.Join(dataDir, "config.json")); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
 }
+
+// loadFile overrides the defaults with the values of the optional config file
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
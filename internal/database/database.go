@@ -5,9 +5,13 @@ import (
 	"fmt"
 
 	"github.com/stiffis/UniCLI/internal/database/repositories"
+	"github.com/stiffis/UniCLI/internal/undo"
 	_ "modernc.org/sqlite"
 )
 
+// historyLimit is the number of changes that can be undone
+const historyLimit = 50
+
 // DB wraps the database connection
 type DB struct {
 	conn         *sql.DB
@@ -15,18 +19,29 @@ type DB struct {
 	eventRepo    *repositories.EventRepository
 	categoryRepo *repositories.CategoryRepository
 	courseRepo   *repositories.CourseRepository
+	gradeRepo    *repositories.GradeRepository
+	noteRepo     *repositories.NoteRepository
+	searchRepo   *repositories.SearchRepository
+	filterRepo   *repositories.SavedFilterRepository
+	timeRepo     *repositories.TimeEntryRepository
+	pomodoroRepo *repositories.PomodoroRepository
+	trashRepo    *repositories.TrashRepository
+	templateRepo *repositories.TaskTemplateRepository
+	sortRepo     *repositories.BoardSortRepository
+	history      *undo.Stack
 }
 
 // New creates a new database connection
 func New(path string) (*DB, error) {
-	conn, err := sql.Open("sqlite", path)
+	// Enable foreign keys on every connection of the pool, deleting a row
+	// relies on them to cascade to the rows referencing it
+	conn, err := sql.Open("sqlite", path+"?_pragma=foreign_keys(1)")
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	// Enable foreign keys
-	if _, err := conn.Exec("PRAGMA foreign_keys = ON"); err != nil {
-		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
+	if err := conn.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
 	db := &DB{conn: conn}
@@ -35,6 +50,16 @@ func New(path string) (*DB, error) {
 	db.eventRepo = repositories.NewEventRepository(conn)
 	db.categoryRepo = repositories.NewCategoryRepository(conn)
 	db.courseRepo = repositories.NewCourseRepository(conn)
+	db.gradeRepo = repositories.NewGradeRepository(conn)
+	db.noteRepo = repositories.NewNoteRepository(conn)
+	db.searchRepo = repositories.NewSearchRepository(conn)
+	db.filterRepo = repositories.NewSavedFilterRepository(conn)
+	db.timeRepo = repositories.NewTimeEntryRepository(conn)
+	db.pomodoroRepo = repositories.NewPomodoroRepository(conn)
+	db.trashRepo = repositories.NewTrashRepository(conn)
+	db.templateRepo = repositories.NewTaskTemplateRepository(conn)
+	db.sortRepo = repositories.NewBoardSortRepository(conn)
+	db.history = undo.NewStack(historyLimit)
 
 	return db, nil
 }
@@ -68,6 +93,56 @@ func (db *DB) Courses() *repositories.CourseRepository {
 	return db.courseRepo
 }
 
+// Grades returns the grade repository
+func (db *DB) Grades() *repositories.GradeRepository {
+	return db.gradeRepo
+}
+
+// Notes returns the standalone note repository
+func (db *DB) Notes() *repositories.NoteRepository {
+	return db.noteRepo
+}
+
+// Search returns the full-text search repository
+func (db *DB) Search() *repositories.SearchRepository {
+	return db.searchRepo
+}
+
+// SavedFilters returns the saved task filter repository
+func (db *DB) SavedFilters() *repositories.SavedFilterRepository {
+	return db.filterRepo
+}
+
+// TimeEntries returns the time tracking repository
+func (db *DB) TimeEntries() *repositories.TimeEntryRepository {
+	return db.timeRepo
+}
+
+// Pomodoros returns the pomodoro repository
+func (db *DB) Pomodoros() *repositories.PomodoroRepository {
+	return db.pomodoroRepo
+}
+
+// Trash returns the repository of deleted items
+func (db *DB) Trash() *repositories.TrashRepository {
+	return db.trashRepo
+}
+
+// TaskTemplates returns the task template repository
+func (db *DB) TaskTemplates() *repositories.TaskTemplateRepository {
+	return db.templateRepo
+}
+
+// BoardSorts returns the repository of the sort modes of the board columns
+func (db *DB) BoardSorts() *repositories.BoardSortRepository {
+	return db.sortRepo
+}
+
+// History returns the undo history of changes made from the screens
+func (db *DB) History() *undo.Stack {
+	return db.history
+}
+
 // Migrate runs database migrations
 func (db *DB) Migrate() error {
 	schema := `
@@ -79,9 +154,15 @@ func (db *DB) Migrate() error {
 		priority TEXT NOT NULL DEFAULT 'medium',
 		category TEXT,
 		due_date DATETIME,
+		recurrence_rule TEXT,
+		course_id TEXT REFERENCES courses(id) ON DELETE SET NULL,
+		estimated_minutes INTEGER NOT NULL DEFAULT 0,
 		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-		completed_at DATETIME
+		completed_at DATETIME,
+		archived_at DATETIME,
+		deleted_at DATETIME,
+		position INTEGER NOT NULL DEFAULT 0
 	);
 
 	CREATE TABLE IF NOT EXISTS tags (
@@ -102,10 +183,38 @@ func (db *DB) Migrate() error {
 		task_id TEXT NOT NULL,
 		title TEXT NOT NULL,
 		is_completed BOOLEAN NOT NULL DEFAULT 0,
+		parent_id INTEGER REFERENCES subtasks(id) ON DELETE CASCADE,
+		position INTEGER NOT NULL DEFAULT 0,
 		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
 	);
 
+	CREATE TABLE IF NOT EXISTS task_dependencies (
+		task_id TEXT NOT NULL,
+		depends_on_id TEXT NOT NULL,
+		FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+		FOREIGN KEY (depends_on_id) REFERENCES tasks(id) ON DELETE CASCADE,
+		PRIMARY KEY (task_id, depends_on_id)
+	);
+
+	CREATE TABLE IF NOT EXISTS time_entries (
+		id TEXT PRIMARY KEY,
+		task_id TEXT NOT NULL,
+		started_at DATETIME NOT NULL,
+		ended_at DATETIME,
+		duration_seconds INTEGER NOT NULL DEFAULT 0,
+		FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS pomodoros (
+		id TEXT PRIMARY KEY,
+		task_id TEXT NOT NULL,
+		started_at DATETIME NOT NULL,
+		ended_at DATETIME NOT NULL,
+		duration_minutes INTEGER NOT NULL,
+		FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+	);
+
 	CREATE TABLE IF NOT EXISTS courses (
 		id TEXT PRIMARY KEY,
 		name TEXT NOT NULL,
@@ -117,7 +226,8 @@ func (db *DB) Migrate() error {
 		color TEXT,
 		description TEXT,
 		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		deleted_at DATETIME
 	);
 
 	CREATE TABLE IF NOT EXISTS course_schedules (
@@ -139,6 +249,7 @@ func (db *DB) Migrate() error {
 		tags TEXT,
 		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		deleted_at DATETIME,
 		FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE
 	);
 
@@ -159,6 +270,7 @@ func (db *DB) Migrate() error {
 		score REAL NOT NULL,
 		max_score REAL NOT NULL,
 		weight REAL DEFAULT 1.0,
+		graded BOOLEAN NOT NULL DEFAULT 1,
 		date DATE,
 		type TEXT,
 		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -169,6 +281,7 @@ func (db *DB) Migrate() error {
 		id TEXT PRIMARY KEY,
 		title TEXT NOT NULL,
 		description TEXT,
+		location TEXT,
 		start_datetime DATETIME NOT NULL,
 		end_datetime DATETIME,
 		type TEXT,
@@ -176,6 +289,7 @@ func (db *DB) Migrate() error {
 		recurrence_rule TEXT,
 		recurrence_end_date DATETIME,
 		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		deleted_at DATETIME,
 		FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE SET NULL
 	);
 
@@ -189,17 +303,46 @@ func (db *DB) Migrate() error {
 		id TEXT PRIMARY KEY,
 		title TEXT NOT NULL,
 		content TEXT,
+		tags TEXT,
 		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		deleted_at DATETIME
+	);
+
+	CREATE TABLE IF NOT EXISTS saved_filters (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL UNIQUE,
+		query TEXT NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS task_templates (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL UNIQUE,
+		title_pattern TEXT NOT NULL,
+		priority TEXT NOT NULL DEFAULT 'medium',
+		tags TEXT NOT NULL DEFAULT '[]',
+		due_in TEXT NOT NULL DEFAULT '',
+		subtasks TEXT NOT NULL DEFAULT '[]',
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS board_sort_modes (
+		column_name TEXT PRIMARY KEY,
+		mode TEXT NOT NULL
 	);
 
 	CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
 	CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks(due_date);
 	CREATE INDEX IF NOT EXISTS idx_subtasks_task_id ON subtasks(task_id);
+	CREATE INDEX IF NOT EXISTS idx_task_dependencies_depends_on ON task_dependencies(depends_on_id);
+	CREATE INDEX IF NOT EXISTS idx_time_entries_task_id ON time_entries(task_id);
+	CREATE INDEX IF NOT EXISTS idx_pomodoros_task_id ON pomodoros(task_id);
 	CREATE INDEX IF NOT EXISTS idx_events_start ON events(start_datetime);
 	CREATE INDEX IF NOT EXISTS idx_course_schedules_course_id ON course_schedules(course_id);
 	CREATE INDEX IF NOT EXISTS idx_course_notes_course_id ON course_notes(course_id);
 	CREATE INDEX IF NOT EXISTS idx_course_attendance_course_id ON course_attendance(course_id);
+	CREATE INDEX IF NOT EXISTS idx_grades_course_id ON grades(course_id);
 	`
 
 	if _, err := db.conn.Exec(schema); err != nil {
@@ -215,10 +358,247 @@ func (db *DB) Migrate() error {
 	if err := db.addColumnIfNotExists("events", "category_id", "TEXT"); err != nil {
 		return err
 	}
+	if err := db.addColumnIfNotExists("events", "location", "TEXT"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("grades", "graded", "BOOLEAN NOT NULL DEFAULT 1"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("notes", "tags", "TEXT"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("tasks", "recurrence_rule", "TEXT"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("tasks", "course_id", "TEXT REFERENCES courses(id) ON DELETE SET NULL"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("tasks", "estimated_minutes", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("tasks", "archived_at", "DATETIME"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("tasks", "position", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := db.backfillTaskPositions(); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("subtasks", "parent_id", "INTEGER REFERENCES subtasks(id) ON DELETE CASCADE"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("subtasks", "position", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	// Deleted items are kept in the trash until they are purged
+	for _, table := range []string{"tasks", "events", "courses", "course_notes", "notes"} {
+		if err := db.addColumnIfNotExists(table, "deleted_at", "DATETIME"); err != nil {
+			return err
+		}
+	}
+	if _, err := db.conn.Exec("CREATE INDEX IF NOT EXISTS idx_tasks_course_id ON tasks(course_id)"); err != nil {
+		return fmt.Errorf("failed to create task course index: %w", err)
+	}
+	if _, err := db.conn.Exec("CREATE INDEX IF NOT EXISTS idx_subtasks_parent_id ON subtasks(parent_id)"); err != nil {
+		return fmt.Errorf("failed to create subtask parent index: %w", err)
+	}
+
+	return db.migrateSearch()
+}
+
+// searchIndexes maps each full-text index to the query that fills it from its
+// source table
+var searchIndexes = []struct {
+	table    string
+	populate string
+}{
+	{"tasks_fts", `
+		INSERT INTO tasks_fts (id, title, description, category, tags)
+		SELECT id, title, COALESCE(description, ''), COALESCE(category, ''),
+			COALESCE((SELECT group_concat(tg.name, ' ') FROM task_tags tt
+				JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = tasks.id), '')
+		FROM tasks`},
+	{"events_fts", `
+		INSERT INTO events_fts (id, title, description)
+		SELECT id, title, COALESCE(description, '') FROM events`},
+	{"courses_fts", `
+		INSERT INTO courses_fts (id, name, code, professor, description)
+		SELECT id, name, COALESCE(code, ''), COALESCE(professor, ''), COALESCE(description, '')
+		FROM courses`},
+	{"course_notes_fts", `
+		INSERT INTO course_notes_fts (id, title, content, tags)
+		SELECT id, title, COALESCE(content, ''), COALESCE(tags, '') FROM course_notes`},
+	{"notes_fts", `
+		INSERT INTO notes_fts (id, title, content, tags)
+		SELECT id, title, COALESCE(content, ''), COALESCE(tags, '') FROM notes`},
+}
+
+// migrateSearch creates the FTS5 search indexes and the triggers that keep them
+// in sync with their source tables. New indexes are filled from existing rows.
+func (db *DB) migrateSearch() error {
+	missing := make(map[string]bool)
+	for _, index := range searchIndexes {
+		exists, err := db.tableExists(index.table)
+		if err != nil {
+			return err
+		}
+		missing[index.table] = !exists
+	}
+
+	schema := `
+	CREATE VIRTUAL TABLE IF NOT EXISTS tasks_fts USING fts5(id UNINDEXED, title, description, category, tags);
+	CREATE VIRTUAL TABLE IF NOT EXISTS events_fts USING fts5(id UNINDEXED, title, description);
+	CREATE VIRTUAL TABLE IF NOT EXISTS courses_fts USING fts5(id UNINDEXED, name, code, professor, description);
+	CREATE VIRTUAL TABLE IF NOT EXISTS course_notes_fts USING fts5(id UNINDEXED, title, content, tags);
+	CREATE VIRTUAL TABLE IF NOT EXISTS notes_fts USING fts5(id UNINDEXED, title, content, tags);
+
+	CREATE TRIGGER IF NOT EXISTS tasks_fts_insert AFTER INSERT ON tasks BEGIN
+		INSERT INTO tasks_fts (id, title, description, category, tags)
+		VALUES (NEW.id, NEW.title, COALESCE(NEW.description, ''), COALESCE(NEW.category, ''), '');
+	END;
+	CREATE TRIGGER IF NOT EXISTS tasks_fts_update AFTER UPDATE OF title, description, category ON tasks BEGIN
+		UPDATE tasks_fts SET title = NEW.title, description = COALESCE(NEW.description, ''),
+			category = COALESCE(NEW.category, '')
+		WHERE id = OLD.id;
+	END;
+	CREATE TRIGGER IF NOT EXISTS tasks_fts_delete AFTER DELETE ON tasks BEGIN
+		DELETE FROM tasks_fts WHERE id = OLD.id;
+	END;
+	CREATE TRIGGER IF NOT EXISTS task_tags_fts_insert AFTER INSERT ON task_tags BEGIN
+		UPDATE tasks_fts SET tags = COALESCE((SELECT group_concat(tg.name, ' ') FROM task_tags tt
+			JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = NEW.task_id), '')
+		WHERE id = NEW.task_id;
+	END;
+	CREATE TRIGGER IF NOT EXISTS task_tags_fts_delete AFTER DELETE ON task_tags BEGIN
+		UPDATE tasks_fts SET tags = COALESCE((SELECT group_concat(tg.name, ' ') FROM task_tags tt
+			JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = OLD.task_id), '')
+		WHERE id = OLD.task_id;
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS events_fts_insert AFTER INSERT ON events BEGIN
+		INSERT INTO events_fts (id, title, description)
+		VALUES (NEW.id, NEW.title, COALESCE(NEW.description, ''));
+	END;
+	CREATE TRIGGER IF NOT EXISTS events_fts_update AFTER UPDATE OF title, description ON events BEGIN
+		UPDATE events_fts SET title = NEW.title, description = COALESCE(NEW.description, '')
+		WHERE id = OLD.id;
+	END;
+	CREATE TRIGGER IF NOT EXISTS events_fts_delete AFTER DELETE ON events BEGIN
+		DELETE FROM events_fts WHERE id = OLD.id;
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS courses_fts_insert AFTER INSERT ON courses BEGIN
+		INSERT INTO courses_fts (id, name, code, professor, description)
+		VALUES (NEW.id, NEW.name, COALESCE(NEW.code, ''), COALESCE(NEW.professor, ''), COALESCE(NEW.description, ''));
+	END;
+	CREATE TRIGGER IF NOT EXISTS courses_fts_update AFTER UPDATE OF name, code, professor, description ON courses BEGIN
+		UPDATE courses_fts SET name = NEW.name, code = COALESCE(NEW.code, ''),
+			professor = COALESCE(NEW.professor, ''), description = COALESCE(NEW.description, '')
+		WHERE id = OLD.id;
+	END;
+	CREATE TRIGGER IF NOT EXISTS courses_fts_delete AFTER DELETE ON courses BEGIN
+		DELETE FROM courses_fts WHERE id = OLD.id;
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS course_notes_fts_insert AFTER INSERT ON course_notes BEGIN
+		INSERT INTO course_notes_fts (id, title, content, tags)
+		VALUES (NEW.id, NEW.title, COALESCE(NEW.content, ''), COALESCE(NEW.tags, ''));
+	END;
+	CREATE TRIGGER IF NOT EXISTS course_notes_fts_update AFTER UPDATE OF title, content, tags ON course_notes BEGIN
+		UPDATE course_notes_fts SET title = NEW.title, content = COALESCE(NEW.content, ''),
+			tags = COALESCE(NEW.tags, '')
+		WHERE id = OLD.id;
+	END;
+	CREATE TRIGGER IF NOT EXISTS course_notes_fts_delete AFTER DELETE ON course_notes BEGIN
+		DELETE FROM course_notes_fts WHERE id = OLD.id;
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS notes_fts_insert AFTER INSERT ON notes BEGIN
+		INSERT INTO notes_fts (id, title, content, tags)
+		VALUES (NEW.id, NEW.title, COALESCE(NEW.content, ''), COALESCE(NEW.tags, ''));
+	END;
+	CREATE TRIGGER IF NOT EXISTS notes_fts_update AFTER UPDATE OF title, content, tags ON notes BEGIN
+		UPDATE notes_fts SET title = NEW.title, content = COALESCE(NEW.content, ''),
+			tags = COALESCE(NEW.tags, '')
+		WHERE id = OLD.id;
+	END;
+	CREATE TRIGGER IF NOT EXISTS notes_fts_delete AFTER DELETE ON notes BEGIN
+		DELETE FROM notes_fts WHERE id = OLD.id;
+	END;
+	`
+
+	if _, err := db.conn.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create search indexes: %w", err)
+	}
+
+	// Index the rows that existed before the index was created
+	for _, index := range searchIndexes {
+		if !missing[index.table] {
+			continue
+		}
+		if _, err := db.conn.Exec(index.populate); err != nil {
+			return fmt.Errorf("failed to build search index %s: %w", index.table, err)
+		}
+	}
 
 	return nil
 }
 
+// tableExists reports whether a table (or virtual table) exists
+func (db *DB) tableExists(name string) (bool, error) {
+	var count int
+	err := db.conn.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check table %s: %w", name, err)
+	}
+	return count > 0, nil
+}
+
+// backfillTaskPositions gives every task its own position when some share
+// one, as tasks created before manual sorting all start at 0. The order of
+// the positions is kept and ties are ordered by creation.
+func (db *DB) backfillTaskPositions() error {
+	var duplicates int
+	if err := db.conn.QueryRow("SELECT COUNT(*) - COUNT(DISTINCT position) FROM tasks").Scan(&duplicates); err != nil {
+		return fmt.Errorf("failed to check task positions: %w", err)
+	}
+	if duplicates == 0 {
+		return nil
+	}
+
+	rows, err := db.conn.Query("SELECT id FROM tasks ORDER BY position, created_at, id")
+	if err != nil {
+		return fmt.Errorf("failed to query task positions: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan task position: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating task positions: %w", err)
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for position, id := range ids {
+		if _, err := tx.Exec("UPDATE tasks SET position = ? WHERE id = ?", position, id); err != nil {
+			return fmt.Errorf("failed to backfill task positions: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
 func (db *DB) addColumnIfNotExists(tableName, columnName, columnType string) error {
 	rows, err := db.conn.Query(fmt.Sprintf("PRAGMA table_info(%s)", tableName))
 	if err != nil {
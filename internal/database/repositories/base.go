@@ -4,6 +4,12 @@ import (
 	"database/sql"
 )
 
+// executor runs queries on the database connection or inside a transaction
+type executor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
 // BaseRepository provides common database operations
 type BaseRepository struct {
 	db *sql.DB
@@ -23,3 +29,9 @@ func (r *BaseRepository) DB() *sql.DB {
 func (r *BaseRepository) BeginTx() (*sql.Tx, error) {
 	return r.db.Begin()
 }
+
+// nullIfEmpty stores an empty optional reference as NULL so it satisfies its
+// foreign key
+func nullIfEmpty(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/stiffis/UniCLI/internal/models"
+)
+
+// BoardSortRepository stores the sort mode chosen for each board column
+type BoardSortRepository struct {
+	*BaseRepository
+}
+
+// NewBoardSortRepository creates a new board sort repository
+func NewBoardSortRepository(db *sql.DB) *BoardSortRepository {
+	return &BoardSortRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindAll returns the sort mode of each column by column name. Columns that
+// were never sorted and unknown modes are left out.
+func (r *BoardSortRepository) FindAll() (map[string]models.TaskSortMode, error) {
+	rows, err := r.DB().Query(`SELECT column_name, mode FROM board_sort_modes`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query board sort modes: %w", err)
+	}
+	defer rows.Close()
+
+	modes := make(map[string]models.TaskSortMode)
+	for rows.Next() {
+		var column, name string
+		if err := rows.Scan(&column, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan board sort mode: %w", err)
+		}
+		if mode, ok := models.ParseTaskSortMode(name); ok {
+			modes[column] = mode
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating board sort modes: %w", err)
+	}
+
+	return modes, nil
+}
+
+// Save sets the sort mode of a column
+func (r *BoardSortRepository) Save(column string, mode models.TaskSortMode) error {
+	query := `
+		INSERT INTO board_sort_modes (column_name, mode) VALUES (?, ?)
+		ON CONFLICT(column_name) DO UPDATE SET mode = excluded.mode
+	`
+
+	if _, err := r.DB().Exec(query, column, mode); err != nil {
+		return fmt.Errorf("failed to save board sort mode: %w", err)
+	}
+
+	return nil
+}
@@ -90,12 +90,24 @@ func (r *CourseRepository) Update(course *models.Course) error {
 	return nil
 }
 
+// Delete moves a course to the trash, see TrashRepository. Its grades, notes
+// and attendance are kept until the course is purged.
 func (r *CourseRepository) Delete(id string) error {
-	query := "DELETE FROM courses WHERE id = ?"
-	_, err := r.db.Exec(query, id)
+	query := "UPDATE courses SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL"
+	result, err := r.db.Exec(query, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("failed to delete course: %w", err)
 	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("course not found: %s", id)
+	}
+
 	return nil
 }
 
@@ -104,7 +116,7 @@ func (r *CourseRepository) GetByID(id string) (*models.Course, error) {
 	query := `
 		SELECT id, name, code, professor, location, semester, credits, color, description, created_at, updated_at
 		FROM courses
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`
 	course := &models.Course{}
 	err := r.db.QueryRow(query, id).Scan(
@@ -141,6 +153,7 @@ func (r *CourseRepository) GetAll() ([]models.Course, error) {
 	query := `
 		SELECT id, name, code, professor, location, semester, credits, color, description, created_at, updated_at
 		FROM courses
+		WHERE deleted_at IS NULL
 		ORDER BY name ASC
 	`
 	rows, err := r.db.Query(query)
@@ -186,7 +199,7 @@ func (r *CourseRepository) GetBySemester(semester string) ([]models.Course, erro
 	query := `
 		SELECT id, name, code, professor, location, semester, credits, color, description, created_at, updated_at
 		FROM courses
-		WHERE semester = ?
+		WHERE semester = ? AND deleted_at IS NULL
 		ORDER BY name ASC
 	`
 	rows, err := r.db.Query(query, semester)
@@ -323,7 +336,7 @@ func (r *CourseRepository) GetNotes(courseID string) ([]models.CourseNote, error
 	query := `
 		SELECT id, course_id, title, content, date, tags, created_at, updated_at
 		FROM course_notes
-		WHERE course_id = ?
+		WHERE course_id = ? AND deleted_at IS NULL
 		ORDER BY date DESC
 	`
 	rows, err := r.db.Query(query, courseID)
@@ -332,15 +345,93 @@ func (r *CourseRepository) GetNotes(courseID string) ([]models.CourseNote, error
 	}
 	defer rows.Close()
 
+	return r.scanNotes(rows)
+}
+
+// GetAllNotes retrieves the notes of every course, most recently updated
+// first. Notes of courses in the trash are left out.
+func (r *CourseRepository) GetAllNotes() ([]models.CourseNote, error) {
+	query := `
+		SELECT id, course_id, title, content, date, tags, created_at, updated_at
+		FROM course_notes
+		WHERE deleted_at IS NULL AND course_id IN (SELECT id FROM courses WHERE deleted_at IS NULL)
+		ORDER BY updated_at DESC
+	`
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course notes: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanNotes(rows)
+}
+
+// UpdateNote updates a course note
+func (r *CourseRepository) UpdateNote(note *models.CourseNote) error {
+	note.UpdatedAt = time.Now()
+
+	tagsJSON, err := json.Marshal(note.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tags: %w", err)
+	}
+
+	query := `
+		UPDATE course_notes
+		SET course_id = ?, title = ?, content = ?, date = ?, tags = ?, updated_at = ?
+		WHERE id = ?
+	`
+	result, err := r.db.Exec(query,
+		note.CourseID,
+		note.Title,
+		note.Content,
+		note.Date,
+		string(tagsJSON),
+		note.UpdatedAt,
+		note.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update course note: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("course note not found: %s", note.ID)
+	}
+	return nil
+}
+
+// DeleteNote moves a course note to the trash, see TrashRepository
+func (r *CourseRepository) DeleteNote(id string) error {
+	query := "UPDATE course_notes SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL"
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete course note: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("course note not found: %s", id)
+	}
+	return nil
+}
+
+// scanNotes scans course notes from query rows
+func (r *CourseRepository) scanNotes(rows *sql.Rows) ([]models.CourseNote, error) {
 	var notes []models.CourseNote
 	for rows.Next() {
 		var note models.CourseNote
-		var tagsJSON string
+		var content, tagsJSON sql.NullString
 		err := rows.Scan(
 			&note.ID,
 			&note.CourseID,
 			&note.Title,
-			&note.Content,
+			&content,
 			&note.Date,
 			&tagsJSON,
 			&note.CreatedAt,
@@ -350,8 +441,10 @@ func (r *CourseRepository) GetNotes(courseID string) ([]models.CourseNote, error
 			return nil, fmt.Errorf("failed to scan course note: %w", err)
 		}
 
-		if tagsJSON != "" {
-			if err := json.Unmarshal([]byte(tagsJSON), &note.Tags); err != nil {
+		note.Content = content.String
+		note.Tags = []string{}
+		if tagsJSON.Valid && tagsJSON.String != "" {
+			if err := json.Unmarshal([]byte(tagsJSON.String), &note.Tags); err != nil {
 				return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
 			}
 		}
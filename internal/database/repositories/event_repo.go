@@ -24,9 +24,9 @@ func NewEventRepository(db *sql.DB) *EventRepository {
 func (r *EventRepository) Create(event *models.Event) error {
 	query := `
 		INSERT INTO events (
-			id, title, description, start_datetime, end_datetime, type, category_id,
+			id, title, description, location, start_datetime, end_datetime, type, category_id,
 			recurrence_rule, recurrence_end_date, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := r.DB().Exec(
@@ -34,6 +34,7 @@ func (r *EventRepository) Create(event *models.Event) error {
 		event.ID,
 		event.Title,
 		event.Description,
+		event.Location,
 		event.StartDatetime,
 		event.EndDatetime,
 		event.Type,
@@ -53,10 +54,10 @@ func (r *EventRepository) Create(event *models.Event) error {
 // FindByID retrieves an event by its ID
 func (r *EventRepository) FindByID(id string) (*models.Event, error) {
 	query := `
-		SELECT id, title, description, start_datetime, end_datetime, type, category_id,
+		SELECT id, title, description, COALESCE(location, ''), start_datetime, end_datetime, type, category_id,
 			   recurrence_rule, recurrence_end_date, created_at
 		FROM events
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`
 
 	event := &models.Event{}
@@ -67,6 +68,7 @@ func (r *EventRepository) FindByID(id string) (*models.Event, error) {
 		&event.ID,
 		&event.Title,
 		&event.Description,
+		&event.Location,
 		&event.StartDatetime,
 		&endDatetime,
 		&event.Type,
@@ -127,9 +129,10 @@ func (r *EventRepository) GetEventsByMonth(year int, month time.Month) ([]models
 // FindAll retrieves all events from the database
 func (r *EventRepository) FindAll() ([]models.Event, error) {
 	query := `
-		SELECT id, title, description, start_datetime, end_datetime, type, category_id,
+		SELECT id, title, description, COALESCE(location, ''), start_datetime, end_datetime, type, category_id,
 			   recurrence_rule, recurrence_end_date, created_at
 		FROM events
+		WHERE deleted_at IS NULL
 		ORDER BY start_datetime ASC
 	`
 
@@ -149,6 +152,7 @@ func (r *EventRepository) FindAll() ([]models.Event, error) {
 			&event.ID,
 			&event.Title,
 			&event.Description,
+			&event.Location,
 			&event.StartDatetime,
 			&endDatetime,
 			&event.Type,
@@ -236,7 +240,7 @@ func generateOccurrences(event models.Event, year int, month time.Month) []model
 func (r *EventRepository) Update(event *models.Event) error {
 	query := `
 		UPDATE events
-		SET title = ?, description = ?, start_datetime = ?, end_datetime = ?, type = ?, category_id = ?,
+		SET title = ?, description = ?, location = ?, start_datetime = ?, end_datetime = ?, type = ?, category_id = ?,
 			recurrence_rule = ?, recurrence_end_date = ?
 		WHERE id = ?
 	`
@@ -245,6 +249,7 @@ func (r *EventRepository) Update(event *models.Event) error {
 		query,
 		event.Title,
 		event.Description,
+		event.Location,
 		event.StartDatetime,
 		event.EndDatetime,
 		event.Type,
@@ -270,10 +275,11 @@ func (r *EventRepository) Update(event *models.Event) error {
 	return nil
 }
 
+// Delete moves an event to the trash, see TrashRepository
 func (r *EventRepository) Delete(id string) error {
-	query := `DELETE FROM events WHERE id = ?`
+	query := `UPDATE events SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`
 
-	result, err := r.DB().Exec(query, id)
+	result, err := r.DB().Exec(query, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("failed to delete event: %w", err)
 	}
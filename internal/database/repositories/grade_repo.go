@@ -0,0 +1,219 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/stiffis/UniCLI/internal/models"
+)
+
+// GradeRepository handles grade data operations
+type GradeRepository struct {
+	*BaseRepository
+}
+
+// NewGradeRepository creates a new grade repository
+func NewGradeRepository(db *sql.DB) *GradeRepository {
+	return &GradeRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *GradeRepository) Create(grade *models.Grade) error {
+	query := `
+		INSERT INTO grades (id, course_id, name, score, max_score, weight, graded, date, type, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.DB().Exec(
+		query,
+		grade.ID,
+		grade.CourseID,
+		grade.Name,
+		grade.Score,
+		grade.MaxScore,
+		grade.Weight,
+		grade.Graded,
+		grade.Date,
+		grade.Type,
+		grade.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create grade: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a grade by its ID
+func (r *GradeRepository) FindByID(id string) (*models.Grade, error) {
+	query := `
+		SELECT id, course_id, name, score, max_score, weight, graded, date, type, created_at
+		FROM grades
+		WHERE id = ?
+	`
+
+	rows, err := r.DB().Query(query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find grade: %w", err)
+	}
+	defer rows.Close()
+
+	grades, err := r.scanGrades(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(grades) == 0 {
+		return nil, fmt.Errorf("grade not found: %s", id)
+	}
+
+	return &grades[0], nil
+}
+
+// FindByCourse retrieves all grades of a course, oldest first
+func (r *GradeRepository) FindByCourse(courseID string) ([]models.Grade, error) {
+	query := `
+		SELECT id, course_id, name, score, max_score, weight, graded, date, type, created_at
+		FROM grades
+		WHERE course_id = ?
+		ORDER BY date IS NULL, date ASC, created_at ASC
+	`
+
+	rows, err := r.DB().Query(query, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query grades: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanGrades(rows)
+}
+
+// FindAll retrieves all grades
+func (r *GradeRepository) FindAll() ([]models.Grade, error) {
+	query := `
+		SELECT id, course_id, name, score, max_score, weight, graded, date, type, created_at
+		FROM grades
+		ORDER BY course_id, date IS NULL, date ASC, created_at ASC
+	`
+
+	rows, err := r.DB().Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query grades: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanGrades(rows)
+}
+
+func (r *GradeRepository) Update(grade *models.Grade) error {
+	query := `
+		UPDATE grades
+		SET name = ?, score = ?, max_score = ?, weight = ?, graded = ?, date = ?, type = ?
+		WHERE id = ?
+	`
+
+	result, err := r.DB().Exec(
+		query,
+		grade.Name,
+		grade.Score,
+		grade.MaxScore,
+		grade.Weight,
+		grade.Graded,
+		grade.Date,
+		grade.Type,
+		grade.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update grade: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("grade not found: %s", grade.ID)
+	}
+
+	return nil
+}
+
+func (r *GradeRepository) Delete(id string) error {
+	query := `DELETE FROM grades WHERE id = ?`
+
+	result, err := r.DB().Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete grade: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("grade not found: %s", id)
+	}
+
+	return nil
+}
+
+// ComputeGPA loads every course and grade and computes the per-semester and
+// cumulative GPA on the given grading scale
+func (r *GradeRepository) ComputeGPA(courseRepo *CourseRepository, scale models.GradingScale) (*models.GPAReport, error) {
+	courses, err := courseRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	report := models.ComputeGPA(courses, models.GroupGradesByCourse(all), scale)
+	return &report, nil
+}
+
+// scanGrades scans multiple grades from query rows
+func (r *GradeRepository) scanGrades(rows *sql.Rows) ([]models.Grade, error) {
+	var grades []models.Grade
+
+	for rows.Next() {
+		var grade models.Grade
+		var date sql.NullTime
+		var gradeType sql.NullString
+
+		err := rows.Scan(
+			&grade.ID,
+			&grade.CourseID,
+			&grade.Name,
+			&grade.Score,
+			&grade.MaxScore,
+			&grade.Weight,
+			&grade.Graded,
+			&date,
+			&gradeType,
+			&grade.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan grade: %w", err)
+		}
+
+		if date.Valid {
+			grade.Date = &date.Time
+		}
+		if gradeType.Valid {
+			grade.Type = gradeType.String
+		}
+
+		grades = append(grades, grade)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating grades: %w", err)
+	}
+
+	return grades, nil
+}
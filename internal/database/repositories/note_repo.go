@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/stiffis/UniCLI/internal/models"
+)
+
+// NoteRepository handles standalone note data operations
+type NoteRepository struct {
+	*BaseRepository
+}
+
+// NewNoteRepository creates a new note repository
+func NewNoteRepository(db *sql.DB) *NoteRepository {
+	return &NoteRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *NoteRepository) Create(note *models.Note) error {
+	tagsJSON, err := json.Marshal(note.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tags: %w", err)
+	}
+
+	query := `
+		INSERT INTO notes (id, title, content, tags, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.DB().Exec(
+		query,
+		note.ID,
+		note.Title,
+		note.Content,
+		string(tagsJSON),
+		note.CreatedAt,
+		note.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create note: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a note by its ID
+func (r *NoteRepository) FindByID(id string) (*models.Note, error) {
+	query := `
+		SELECT id, title, content, tags, created_at, updated_at
+		FROM notes
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	rows, err := r.DB().Query(query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find note: %w", err)
+	}
+	defer rows.Close()
+
+	notes, err := r.scanNotes(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(notes) == 0 {
+		return nil, fmt.Errorf("note not found: %s", id)
+	}
+
+	return &notes[0], nil
+}
+
+// FindAll retrieves all notes, most recently updated first
+func (r *NoteRepository) FindAll() ([]models.Note, error) {
+	query := `
+		SELECT id, title, content, tags, created_at, updated_at
+		FROM notes
+		WHERE deleted_at IS NULL
+		ORDER BY updated_at DESC
+	`
+
+	rows, err := r.DB().Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notes: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanNotes(rows)
+}
+
+func (r *NoteRepository) Update(note *models.Note) error {
+	note.UpdatedAt = time.Now()
+
+	tagsJSON, err := json.Marshal(note.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tags: %w", err)
+	}
+
+	query := `
+		UPDATE notes
+		SET title = ?, content = ?, tags = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := r.DB().Exec(
+		query,
+		note.Title,
+		note.Content,
+		string(tagsJSON),
+		note.UpdatedAt,
+		note.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update note: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("note not found: %s", note.ID)
+	}
+
+	return nil
+}
+
+// Delete moves a note to the trash, see TrashRepository
+func (r *NoteRepository) Delete(id string) error {
+	query := `UPDATE notes SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`
+
+	result, err := r.DB().Exec(query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("note not found: %s", id)
+	}
+
+	return nil
+}
+
+// scanNotes scans multiple notes from query rows
+func (r *NoteRepository) scanNotes(rows *sql.Rows) ([]models.Note, error) {
+	var notes []models.Note
+
+	for rows.Next() {
+		var note models.Note
+		var content, tagsJSON sql.NullString
+
+		err := rows.Scan(
+			&note.ID,
+			&note.Title,
+			&content,
+			&tagsJSON,
+			&note.CreatedAt,
+			&note.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan note: %w", err)
+		}
+
+		note.Content = content.String
+		note.Tags = []string{}
+		if tagsJSON.Valid && tagsJSON.String != "" {
+			if err := json.Unmarshal([]byte(tagsJSON.String), &note.Tags); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+			}
+		}
+
+		notes = append(notes, note)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notes: %w", err)
+	}
+
+	return notes, nil
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/stiffis/UniCLI/internal/models"
+)
+
+// PomodoroRepository handles Pomodoro log operations
+type PomodoroRepository struct {
+	*BaseRepository
+}
+
+// NewPomodoroRepository creates a new pomodoro repository
+func NewPomodoroRepository(db *sql.DB) *PomodoroRepository {
+	return &PomodoroRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Create logs a completed Pomodoro
+func (r *PomodoroRepository) Create(pomodoro *models.Pomodoro) error {
+	query := `
+		INSERT INTO pomodoros (id, task_id, started_at, ended_at, duration_minutes)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := r.DB().Exec(query,
+		pomodoro.ID,
+		pomodoro.TaskID,
+		pomodoro.StartedAt,
+		pomodoro.EndedAt,
+		pomodoro.DurationMinutes,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to log pomodoro: %w", err)
+	}
+
+	return nil
+}
+
+// CountSince returns the number of Pomodoros completed since a time
+func (r *PomodoroRepository) CountSince(since time.Time) (int, error) {
+	var count int
+	err := r.DB().QueryRow(`SELECT COUNT(*) FROM pomodoros WHERE ended_at >= ?`, since).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pomodoros: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/stiffis/UniCLI/internal/models"
+)
+
+// SavedFilterRepository handles saved task filter data operations
+type SavedFilterRepository struct {
+	*BaseRepository
+}
+
+// NewSavedFilterRepository creates a new saved filter repository
+func NewSavedFilterRepository(db *sql.DB) *SavedFilterRepository {
+	return &SavedFilterRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *SavedFilterRepository) Create(filter *models.SavedFilter) error {
+	query := `
+		INSERT INTO saved_filters (id, name, query, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+
+	_, err := r.DB().Exec(query, filter.ID, filter.Name, filter.Query, filter.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create saved filter: %w", err)
+	}
+
+	return nil
+}
+
+// FindAll retrieves all saved filters ordered by name
+func (r *SavedFilterRepository) FindAll() ([]models.SavedFilter, error) {
+	query := `
+		SELECT id, name, query, created_at
+		FROM saved_filters
+		ORDER BY name COLLATE NOCASE
+	`
+
+	rows, err := r.DB().Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved filters: %w", err)
+	}
+	defer rows.Close()
+
+	filters := []models.SavedFilter{}
+	for rows.Next() {
+		var filter models.SavedFilter
+		if err := rows.Scan(&filter.ID, &filter.Name, &filter.Query, &filter.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan saved filter: %w", err)
+		}
+		filters = append(filters, filter)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saved filters: %w", err)
+	}
+
+	return filters, nil
+}
+
+func (r *SavedFilterRepository) Update(filter *models.SavedFilter) error {
+	query := `UPDATE saved_filters SET name = ?, query = ? WHERE id = ?`
+
+	result, err := r.DB().Exec(query, filter.Name, filter.Query, filter.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update saved filter: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("saved filter not found: %s", filter.ID)
+	}
+
+	return nil
+}
+
+func (r *SavedFilterRepository) Delete(id string) error {
+	query := `DELETE FROM saved_filters WHERE id = ?`
+
+	result, err := r.DB().Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved filter: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("saved filter not found: %s", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/stiffis/UniCLI/internal/models"
+)
+
+// SearchRepository runs full-text searches over the FTS5 indexes
+type SearchRepository struct {
+	*BaseRepository
+}
+
+// NewSearchRepository creates a new search repository
+func NewSearchRepository(db *sql.DB) *SearchRepository {
+	return &SearchRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// searchSnippetTokens is the number of tokens shown around a match
+const searchSnippetTokens = 12
+
+// Search returns the items matching the text, best matches first. Each word
+// of the text is matched as a prefix and titles weigh more than bodies.
+func (r *SearchRepository) Search(text string, limit int) ([]models.SearchResult, error) {
+	match := models.SearchQuery(text)
+	if match == "" {
+		return []models.SearchResult{}, nil
+	}
+
+	// The first column of every index is the unindexed item ID. Items in the
+	// trash stay indexed until they are purged and are filtered out here.
+	query := `
+		SELECT 'task', id, title,
+			snippet(tasks_fts, -1, ?, ?, '…', ?), bm25(tasks_fts, 0, 10, 1, 3, 3),
+			id IN (SELECT id FROM tasks WHERE archived_at IS NOT NULL)
+		FROM tasks_fts WHERE tasks_fts MATCH ?
+			AND id IN (SELECT id FROM tasks WHERE deleted_at IS NULL)
+		UNION ALL
+		SELECT 'event', id, title,
+			snippet(events_fts, -1, ?, ?, '…', ?), bm25(events_fts, 0, 10, 1), 0
+		FROM events_fts WHERE events_fts MATCH ?
+			AND id IN (SELECT id FROM events WHERE deleted_at IS NULL)
+		UNION ALL
+		SELECT 'course', id, CASE WHEN code != '' THEN code || ' - ' || name ELSE name END,
+			snippet(courses_fts, -1, ?, ?, '…', ?), bm25(courses_fts, 0, 10, 10, 3, 1), 0
+		FROM courses_fts WHERE courses_fts MATCH ?
+			AND id IN (SELECT id FROM courses WHERE deleted_at IS NULL)
+		UNION ALL
+		SELECT 'course_note', id, title,
+			snippet(course_notes_fts, -1, ?, ?, '…', ?), bm25(course_notes_fts, 0, 10, 1, 3), 0
+		FROM course_notes_fts WHERE course_notes_fts MATCH ?
+			AND id IN (SELECT n.id FROM course_notes n JOIN courses c ON c.id = n.course_id
+				WHERE n.deleted_at IS NULL AND c.deleted_at IS NULL)
+		UNION ALL
+		SELECT 'note', id, title,
+			snippet(notes_fts, -1, ?, ?, '…', ?), bm25(notes_fts, 0, 10, 1, 3), 0
+		FROM notes_fts WHERE notes_fts MATCH ?
+			AND id IN (SELECT id FROM notes WHERE deleted_at IS NULL)
+		ORDER BY 5
+		LIMIT ?
+	`
+
+	var args []any
+	for i := 0; i < 5; i++ {
+		args = append(args, models.SearchHighlightStart, models.SearchHighlightEnd, searchSnippetTokens, match)
+	}
+	args = append(args, limit)
+
+	rows, err := r.DB().Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.SearchResult{}
+	for rows.Next() {
+		var result models.SearchResult
+		if err := rows.Scan(&result.Kind, &result.ID, &result.Title, &result.Snippet, &result.Rank, &result.Archived); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate search results: %w", err)
+	}
+
+	return results, nil
+}
@@ -3,11 +3,20 @@ package repositories
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/stiffis/UniCLI/internal/models"
 )
 
+// taskColumns are the task columns read by scanTask, in order. The tracked
+// time sums the stopped time entries of the task.
+const taskColumns = `id, title, description, status, priority, category, course_id,
+	due_date, recurrence_rule, estimated_minutes,
+	(SELECT COALESCE(SUM(duration_seconds), 0) FROM time_entries WHERE task_id = tasks.id),
+	(SELECT COUNT(*) FROM pomodoros WHERE task_id = tasks.id),
+	created_at, updated_at, completed_at, archived_at, position`
+
 // TaskRepository handles task data operations
 type TaskRepository struct {
 	*BaseRepository
@@ -20,15 +29,53 @@ func NewTaskRepository(db *sql.DB) *TaskRepository {
 	}
 }
 
+// Create inserts a task with its tags and dependencies
 func (r *TaskRepository) Create(task *models.Task) error {
+	tx, err := r.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.insertTask(tx, task); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CreateWithSubtasks inserts a task together with its checklist in a single
+// transaction, so a failing subtask leaves no half-built task behind
+func (r *TaskRepository) CreateWithSubtasks(task *models.Task) error {
+	tx, err := r.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.insertTask(tx, task); err != nil {
+		return err
+	}
+	for i := range task.Subtasks {
+		task.Subtasks[i].TaskID = task.ID
+	}
+	if err := r.insertSubtasks(tx, task.Subtasks); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertTask inserts a task with its tags and dependencies
+func (r *TaskRepository) insertTask(ex executor, task *models.Task) error {
 	query := `
 		INSERT INTO tasks (
-			id, title, description, status, priority, category,
-			due_date, created_at, updated_at, completed_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			id, title, description, status, priority, category, course_id,
+			due_date, recurrence_rule, estimated_minutes, created_at, updated_at, completed_at, position
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, (SELECT COALESCE(MAX(position), -1) + 1 FROM tasks))
 	`
 
-	_, err := r.DB().Exec(
+	_, err := ex.Exec(
 		query,
 		task.ID,
 		task.Title,
@@ -36,7 +83,10 @@ func (r *TaskRepository) Create(task *models.Task) error {
 		task.Status,
 		task.Priority,
 		task.Category,
+		nullIfEmpty(task.CourseID),
 		task.DueDate,
+		task.RecurrenceRule,
+		task.EstimatedMinutes,
 		task.CreatedAt,
 		task.UpdatedAt,
 		task.CompletedAt,
@@ -48,39 +98,29 @@ func (r *TaskRepository) Create(task *models.Task) error {
 
 	// Insert tags if any
 	if len(task.Tags) > 0 {
-		if err := r.updateTags(task.ID, task.Tags); err != nil {
+		if err := writeTags(ex, task.ID, task.Tags); err != nil {
 			return fmt.Errorf("failed to create task tags: %w", err)
 		}
 	}
 
+	if len(task.Dependencies) > 0 {
+		if err := writeDependencies(ex, task.ID, task.Dependencies); err != nil {
+			return fmt.Errorf("failed to create task dependencies: %w", err)
+		}
+	}
+
 	return nil
 }
 
 // FindByID retrieves a task by its ID
 func (r *TaskRepository) FindByID(id string) (*models.Task, error) {
 	query := `
-		SELECT id, title, description, status, priority, category,
-			   due_date, created_at, updated_at, completed_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`
 
-	task := &models.Task{}
-	var dueDate, completedAt sql.NullTime
-
-	err := r.DB().QueryRow(query, id).Scan(
-		&task.ID,
-		&task.Title,
-		&task.Description,
-		&task.Status,
-		&task.Priority,
-		&task.Category,
-		&dueDate,
-		&task.CreatedAt,
-		&task.UpdatedAt,
-		&completedAt,
-	)
-
+	task, err := scanTask(r.DB().QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("task not found: %s", id)
@@ -88,13 +128,6 @@ func (r *TaskRepository) FindByID(id string) (*models.Task, error) {
 		return nil, fmt.Errorf("failed to find task: %w", err)
 	}
 
-	if dueDate.Valid {
-		task.DueDate = &dueDate.Time
-	}
-	if completedAt.Valid {
-		task.CompletedAt = &completedAt.Time
-	}
-
 	tags, err := r.loadTags(task.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load tags: %w", err)
@@ -107,15 +140,21 @@ func (r *TaskRepository) FindByID(id string) (*models.Task, error) {
 	}
 	task.Subtasks = subtasks
 
+	dependencies, err := r.loadDependencies(task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+	task.Dependencies = dependencies
+
 	return task, nil
 }
 
-// FindAll retrieves all tasks
+// FindAll retrieves all tasks that are not archived
 func (r *TaskRepository) FindAll() ([]models.Task, error) {
 	query := `
-		SELECT id, title, description, status, priority, category,
-			   due_date, created_at, updated_at, completed_at
+		SELECT ` + taskColumns + `
 		FROM tasks
+		WHERE deleted_at IS NULL AND archived_at IS NULL
 		ORDER BY created_at DESC
 	`
 
@@ -131,10 +170,9 @@ func (r *TaskRepository) FindAll() ([]models.Task, error) {
 // FindByStatus retrieves tasks by status
 func (r *TaskRepository) FindByStatus(status models.TaskStatus) ([]models.Task, error) {
 	query := `
-		SELECT id, title, description, status, priority, category,
-			   due_date, created_at, updated_at, completed_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE status = ?
+		WHERE status = ? AND deleted_at IS NULL AND archived_at IS NULL
 		ORDER BY created_at DESC
 	`
 
@@ -147,6 +185,31 @@ func (r *TaskRepository) FindByStatus(status models.TaskStatus) ([]models.Task,
 	return r.scanTasks(rows)
 }
 
+// CountByStatus counts the tasks on the board with one of the statuses
+func (r *TaskRepository) CountByStatus(statuses ...models.TaskStatus) (int, error) {
+	if len(statuses) == 0 {
+		return 0, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(statuses)), ", ")
+	args := make([]any, len(statuses))
+	for i, status := range statuses {
+		args[i] = status
+	}
+
+	query := `
+		SELECT COUNT(*) FROM tasks
+		WHERE status IN (` + placeholders + `) AND deleted_at IS NULL AND archived_at IS NULL
+	`
+
+	var count int
+	if err := r.DB().QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	return count, nil
+}
+
 // FindDueToday retrieves tasks due today
 func (r *TaskRepository) FindDueToday() ([]models.Task, error) {
 	now := time.Now()
@@ -154,10 +217,9 @@ func (r *TaskRepository) FindDueToday() ([]models.Task, error) {
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
 	query := `
-		SELECT id, title, description, status, priority, category,
-			   due_date, created_at, updated_at, completed_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE due_date >= ? AND due_date < ?
+		WHERE due_date >= ? AND due_date < ? AND deleted_at IS NULL AND archived_at IS NULL
 		ORDER BY due_date ASC
 	`
 
@@ -177,10 +239,9 @@ func (r *TaskRepository) FindUpcoming() ([]models.Task, error) {
 	nextWeek := tomorrow.Add(7 * 24 * time.Hour)
 
 	query := `
-		SELECT id, title, description, status, priority, category,
-			   due_date, created_at, updated_at, completed_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE due_date >= ? AND due_date < ? AND status != ?
+		WHERE due_date >= ? AND due_date < ? AND status != ? AND deleted_at IS NULL AND archived_at IS NULL
 		ORDER BY due_date ASC
 	`
 
@@ -198,10 +259,9 @@ func (r *TaskRepository) FindOverdue() ([]models.Task, error) {
 	now := time.Now()
 
 	query := `
-		SELECT id, title, description, status, priority, category,
-			   due_date, created_at, updated_at, completed_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE due_date < ? AND status != ?
+		WHERE due_date < ? AND status != ? AND deleted_at IS NULL AND archived_at IS NULL
 		ORDER BY due_date ASC
 	`
 
@@ -214,7 +274,299 @@ func (r *TaskRepository) FindOverdue() ([]models.Task, error) {
 	return r.scanTasks(rows)
 }
 
+// FindOutstandingByCourse retrieves the tasks linked to a course that are not
+// completed or cancelled, soonest due first
+func (r *TaskRepository) FindOutstandingByCourse(courseID string) ([]models.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE course_id = ? AND status NOT IN (?, ?) AND deleted_at IS NULL AND archived_at IS NULL
+		ORDER BY due_date IS NULL, due_date ASC, created_at ASC
+	`
+
+	rows, err := r.DB().Query(query, courseID, models.TaskStatusCompleted, models.TaskStatusCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query course tasks: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanTasks(rows)
+}
+
+// FindByFilter retrieves the tasks matching a parsed filter query, archived
+// tasks excluded
+func (r *TaskRepository) FindByFilter(filter models.TaskFilter) ([]models.Task, error) {
+	where, args, err := taskFilterClause(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE (` + where + `) AND deleted_at IS NULL AND archived_at IS NULL
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.DB().Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query filtered tasks: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanTasks(rows)
+}
+
+// FindArchived retrieves the archived tasks matching a filter query, most
+// recently archived first
+func (r *TaskRepository) FindArchived(filter models.TaskFilter) ([]models.Task, error) {
+	where, args, err := taskFilterClause(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE (` + where + `) AND deleted_at IS NULL AND archived_at IS NOT NULL
+		ORDER BY archived_at DESC, completed_at DESC
+	`
+
+	rows, err := r.DB().Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archived tasks: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanTasks(rows)
+}
+
+// Archive moves a task off the board into the archive
+func (r *TaskRepository) Archive(id string) error {
+	query := `UPDATE tasks SET archived_at = ? WHERE id = ? AND deleted_at IS NULL AND archived_at IS NULL`
+
+	result, err := r.DB().Exec(query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to archive task: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("task not found: %s", id)
+	}
+
+	return nil
+}
+
+// Unarchive moves an archived task back to the board
+func (r *TaskRepository) Unarchive(id string) error {
+	query := `UPDATE tasks SET archived_at = NULL WHERE id = ? AND deleted_at IS NULL AND archived_at IS NOT NULL`
+
+	result, err := r.DB().Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to unarchive task: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("archived task not found: %s", id)
+	}
+
+	return nil
+}
+
+// ArchiveCompletedBefore archives the tasks completed before the cutoff and
+// returns how many were archived
+func (r *TaskRepository) ArchiveCompletedBefore(cutoff time.Time) (int64, error) {
+	query := `
+		UPDATE tasks SET archived_at = ?
+		WHERE status = ? AND completed_at < ? AND deleted_at IS NULL AND archived_at IS NULL
+	`
+
+	result, err := r.DB().Exec(query, time.Now(), models.TaskStatusCompleted, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive completed tasks: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
+
+// taskFilterClause builds the WHERE clause of a task filter
+func taskFilterClause(filter models.TaskFilter) (string, []any, error) {
+	if filter.IsEmpty() {
+		return "1 = 1", nil, nil
+	}
+
+	var conditions []string
+	var args []any
+
+	for _, term := range filter.Terms {
+		condition, termArgs, err := taskFilterCondition(term)
+		if err != nil {
+			return "", nil, err
+		}
+		if term.Negate {
+			condition = "NOT (" + condition + ")"
+		}
+		conditions = append(conditions, condition)
+		args = append(args, termArgs...)
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+// taskPriorityRank ranks priorities in SQL so they can be compared
+const taskPriorityRank = `CASE priority
+	WHEN 'low' THEN 1 WHEN 'medium' THEN 2 WHEN 'high' THEN 3 WHEN 'urgent' THEN 4 ELSE 0 END`
+
+// taskFilterCondition builds the SQL condition of a single filter term
+func taskFilterCondition(term models.TaskFilterTerm) (string, []any, error) {
+	var args []any
+
+	// anyOf matches one of the term values with the given condition
+	anyOf := func(condition string, value func(string) any) string {
+		var parts []string
+		for _, v := range term.Values {
+			parts = append(parts, condition)
+			args = append(args, value(v))
+		}
+		return "(" + strings.Join(parts, " OR ") + ")"
+	}
+	same := func(v string) any { return v }
+
+	switch term.Field {
+	case models.FilterFieldText:
+		pattern := "%" + escapeLike(term.Values[0]) + "%"
+		args = append(args, pattern, pattern)
+		return `(title LIKE ? ESCAPE '\' OR COALESCE(description, '') LIKE ? ESCAPE '\')`, args, nil
+
+	case models.FilterFieldPriority:
+		if term.Op == "=" {
+			return anyOf("priority = ?", same), args, nil
+		}
+		args = append(args, models.TaskPriority(term.Values[0]).Rank())
+		return taskPriorityRank + " " + term.Op + " ?", args, nil
+
+	case models.FilterFieldStatus:
+		return anyOf("status = ?", same), args, nil
+
+	case models.FilterFieldTag:
+		return anyOf(`EXISTS (SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+			WHERE tt.task_id = tasks.id AND tg.name = ? COLLATE NOCASE)`, same), args, nil
+
+	case models.FilterFieldCategory:
+		return anyOf("category = ? COLLATE NOCASE", same), args, nil
+
+	case models.FilterFieldCourse:
+		// Courses match by code or name
+		var parts []string
+		for _, v := range term.Values {
+			parts = append(parts, "code = ? COLLATE NOCASE OR name = ? COLLATE NOCASE")
+			args = append(args, v, v)
+		}
+		return "(course_id IS NOT NULL AND course_id IN (SELECT id FROM courses WHERE " + strings.Join(parts, " OR ") + "))", args, nil
+
+	case models.FilterFieldDue:
+		return taskDueCondition(term)
+	}
+
+	return "", nil, fmt.Errorf("unknown filter field: %s", term.Field)
+}
+
+// taskDueCondition builds the SQL condition of a due date term
+func taskDueCondition(term models.TaskFilterTerm) (string, []any, error) {
+	switch term.Values[0] {
+	case models.FilterDueNone:
+		return "due_date IS NULL", nil, nil
+	case models.FilterDueAny:
+		return "due_date IS NOT NULL", nil, nil
+	case models.FilterDueOverdue:
+		now := time.Now()
+		startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		return "(due_date < ? AND status != ?)", []any{startOfToday, models.TaskStatusCompleted}, nil
+	}
+
+	// Whole days include the entire day of the date
+	t := term.Time
+	next := t
+	if term.Days {
+		next = t.AddDate(0, 0, 1)
+	}
+
+	switch term.Op {
+	case "=":
+		return "(due_date >= ? AND due_date < ?)", []any{t, next}, nil
+	case "<":
+		return "due_date < ?", []any{t}, nil
+	case "<=":
+		if term.Days {
+			return "due_date < ?", []any{next}, nil
+		}
+		return "due_date <= ?", []any{t}, nil
+	case ">":
+		if term.Days {
+			return "due_date >= ?", []any{next}, nil
+		}
+		return "due_date > ?", []any{t}, nil
+	case ">=":
+		return "due_date >= ?", []any{t}, nil
+	}
+
+	return "", nil, fmt.Errorf("invalid due comparison: %s", term.Op)
+}
+
+// escapeLike escapes the LIKE wildcards of a search value
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func (r *TaskRepository) Update(task *models.Task) error {
+	tx, err := r.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.updateTask(tx, task); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateMany saves several tasks in a single transaction, so either all of
+// them are changed or none is
+func (r *TaskRepository) UpdateMany(tasks []models.Task) error {
+	tx, err := r.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i := range tasks {
+		if err := r.updateTask(tx, &tasks[i]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// updateTask saves a task with its tags and dependencies
+func (r *TaskRepository) updateTask(ex executor, task *models.Task) error {
 	task.UpdatedAt = time.Now()
 
 	if task.Status == models.TaskStatusCompleted && task.CompletedAt == nil {
@@ -229,18 +581,22 @@ func (r *TaskRepository) Update(task *models.Task) error {
 	query := `
 		UPDATE tasks
 		SET title = ?, description = ?, status = ?, priority = ?,
-		    category = ?, due_date = ?, updated_at = ?, completed_at = ?
+		    category = ?, course_id = ?, due_date = ?, recurrence_rule = ?,
+		    estimated_minutes = ?, updated_at = ?, completed_at = ?
 		WHERE id = ?
 	`
 
-	result, err := r.DB().Exec(
+	result, err := ex.Exec(
 		query,
 		task.Title,
 		task.Description,
 		task.Status,
 		task.Priority,
 		task.Category,
+		nullIfEmpty(task.CourseID),
 		task.DueDate,
+		task.RecurrenceRule,
+		task.EstimatedMinutes,
 		task.UpdatedAt,
 		task.CompletedAt,
 		task.ID,
@@ -259,17 +615,22 @@ func (r *TaskRepository) Update(task *models.Task) error {
 		return fmt.Errorf("task not found: %s", task.ID)
 	}
 
-	if err := r.updateTags(task.ID, task.Tags); err != nil {
+	if err := writeTags(ex, task.ID, task.Tags); err != nil {
 		return fmt.Errorf("failed to update task tags: %w", err)
 	}
 
+	if err := writeDependencies(ex, task.ID, task.Dependencies); err != nil {
+		return fmt.Errorf("failed to update task dependencies: %w", err)
+	}
+
 	return nil
 }
 
+// Delete moves a task to the trash, see TrashRepository
 func (r *TaskRepository) Delete(id string) error {
-	query := `DELETE FROM tasks WHERE id = ?`
+	query := `UPDATE tasks SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`
 
-	result, err := r.DB().Exec(query, id)
+	result, err := r.DB().Exec(query, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
@@ -286,6 +647,115 @@ func (r *TaskRepository) Delete(id string) error {
 	return nil
 }
 
+// DeleteCreated permanently deletes a task made by a change that is undone,
+// such as the next instance of a completed recurring task. It refuses if the
+// task was changed, worked on, archived or moved to the trash since.
+func (r *TaskRepository) DeleteCreated(task models.Task) error {
+	tx, err := r.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var updatedAt time.Time
+	var archivedAt, deletedAt sql.NullTime
+	err = tx.QueryRow(`SELECT updated_at, archived_at, deleted_at FROM tasks WHERE id = ?`, task.ID).
+		Scan(&updatedAt, &archivedAt, &deletedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("task not found: %s", task.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find task: %w", err)
+	}
+
+	var work int
+	err = tx.QueryRow(`
+		SELECT (SELECT COUNT(*) FROM subtasks WHERE task_id = ? AND is_completed = 1)
+		     + (SELECT COUNT(*) FROM time_entries WHERE task_id = ?)
+		     + (SELECT COUNT(*) FROM pomodoros WHERE task_id = ?)
+	`, task.ID, task.ID, task.ID).Scan(&work)
+	if err != nil {
+		return fmt.Errorf("failed to check task: %w", err)
+	}
+
+	if !updatedAt.Equal(task.UpdatedAt) || archivedAt.Valid || deletedAt.Valid || work > 0 {
+		return fmt.Errorf("%q was changed since it was created", task.Title)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM tasks WHERE id = ?`, task.ID); err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// DeleteMany moves several tasks to the trash in a single transaction
+func (r *TaskRepository) DeleteMany(ids []string) error {
+	return r.stampEach(ids, "delete", `UPDATE tasks SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`)
+}
+
+// ArchiveMany moves several tasks into the archive in a single transaction
+func (r *TaskRepository) ArchiveMany(ids []string) error {
+	return r.stampEach(ids, "archive", `UPDATE tasks SET archived_at = ? WHERE id = ? AND deleted_at IS NULL AND archived_at IS NULL`)
+}
+
+// SwapPositions swaps the manual order of two tasks in a single transaction.
+// Positions are unique, so the tasks between them, including the ones hidden
+// by a filter, keep their place.
+func (r *TaskRepository) SwapPositions(a, b string) error {
+	tx, err := r.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var positionA, positionB int
+	if err := tx.QueryRow(`SELECT position FROM tasks WHERE id = ?`, a).Scan(&positionA); err != nil {
+		return fmt.Errorf("task not found: %s", a)
+	}
+	if err := tx.QueryRow(`SELECT position FROM tasks WHERE id = ?`, b).Scan(&positionB); err != nil {
+		return fmt.Errorf("task not found: %s", b)
+	}
+
+	if _, err := tx.Exec(`UPDATE tasks SET position = ? WHERE id = ?`, positionB, a); err != nil {
+		return fmt.Errorf("failed to reorder task: %w", err)
+	}
+	if _, err := tx.Exec(`UPDATE tasks SET position = ? WHERE id = ?`, positionA, b); err != nil {
+		return fmt.Errorf("failed to reorder task: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// stampEach runs a query setting the current time on each task in a single
+// transaction, failing if one of the tasks is not found
+func (r *TaskRepository) stampEach(ids []string, action, query string) error {
+	tx, err := r.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, id := range ids {
+		result, err := tx.Exec(query, now, id)
+		if err != nil {
+			return fmt.Errorf("failed to %s task: %w", action, err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rows == 0 {
+			return fmt.Errorf("task not found: %s", id)
+		}
+	}
+
+	return tx.Commit()
+}
+
 // ToggleComplete toggles the completion status of a task
 func (r *TaskRepository) ToggleComplete(id string) error {
 	task, err := r.FindByID(id)
@@ -297,14 +767,86 @@ func (r *TaskRepository) ToggleComplete(id string) error {
 		// Mark as pending
 		task.Status = models.TaskStatusPending
 		task.CompletedAt = nil
-	} else {
-		// Mark as completed
-		task.Status = models.TaskStatusCompleted
-		now := time.Now()
-		task.CompletedAt = &now
+		return r.Update(task)
+	}
+
+	_, err = r.Complete(task)
+	return err
+}
+
+// Complete marks a task as completed. A recurring task hands its recurrence
+// over to a new instance with the next due date and a reset checklist, which
+// is returned; nil is returned for tasks that do not repeat.
+func (r *TaskRepository) Complete(task *models.Task) (*models.Task, error) {
+	tx, err := r.BeginTx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	next, err := r.completeTask(tx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	return next, tx.Commit()
+}
+
+// CompleteMany completes several tasks in a single transaction, see
+// Complete. It returns the next instances of the recurring tasks.
+func (r *TaskRepository) CompleteMany(tasks []models.Task) ([]models.Task, error) {
+	tx, err := r.BeginTx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var created []models.Task
+	for i := range tasks {
+		next, err := r.completeTask(tx, &tasks[i])
+		if err != nil {
+			return nil, err
+		}
+		if next != nil {
+			created = append(created, *next)
+		}
 	}
 
-	return r.Update(task)
+	return created, tx.Commit()
+}
+
+// completeTask marks a task as completed and creates its next instance
+func (r *TaskRepository) completeTask(ex executor, task *models.Task) (*models.Task, error) {
+	now := time.Now()
+	task.Status = models.TaskStatusCompleted
+	task.CompletedAt = &now
+
+	var next *models.Task
+	if task.IsRecurring() {
+		var err error
+		next, err = task.NextOccurrence(now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule next occurrence: %w", err)
+		}
+		// Completing the task again after reopening it must not repeat it twice
+		task.RecurrenceRule = ""
+	}
+
+	if err := r.updateTask(ex, task); err != nil {
+		return nil, err
+	}
+	if next == nil {
+		return nil, nil
+	}
+
+	if err := r.insertTask(ex, next); err != nil {
+		return nil, fmt.Errorf("failed to create next occurrence: %w", err)
+	}
+	if err := r.insertSubtasks(ex, next.Subtasks); err != nil {
+		return nil, fmt.Errorf("failed to create next occurrence: %w", err)
+	}
+
+	return next, nil
 }
 
 // scanTasks scans multiple tasks from query rows
@@ -312,33 +854,11 @@ func (r *TaskRepository) scanTasks(rows *sql.Rows) ([]models.Task, error) {
 	var tasks []models.Task
 
 	for rows.Next() {
-		var task models.Task
-		var dueDate, completedAt sql.NullTime
-
-		err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.Priority,
-			&task.Category,
-			&dueDate,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-			&completedAt,
-		)
-
+		task, err := scanTask(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan task: %w", err)
 		}
 
-		if dueDate.Valid {
-			task.DueDate = &dueDate.Time
-		}
-		if completedAt.Valid {
-			task.CompletedAt = &completedAt.Time
-		}
-
 		tags, err := r.loadTags(task.ID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load tags for task %s: %w", task.ID, err)
@@ -351,7 +871,13 @@ func (r *TaskRepository) scanTasks(rows *sql.Rows) ([]models.Task, error) {
 		}
 		task.Subtasks = subtasks
 
-		tasks = append(tasks, task)
+		dependencies, err := r.loadDependencies(task.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load dependencies for task %s: %w", task.ID, err)
+		}
+		task.Dependencies = dependencies
+
+		tasks = append(tasks, *task)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -361,6 +887,59 @@ func (r *TaskRepository) scanTasks(rows *sql.Rows) ([]models.Task, error) {
 	return tasks, nil
 }
 
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask scans the taskColumns of a single row
+func scanTask(row rowScanner) (*models.Task, error) {
+	task := &models.Task{}
+	var dueDate, completedAt, archivedAt sql.NullTime
+	var courseID, recurrenceRule sql.NullString
+
+	err := row.Scan(
+		&task.ID,
+		&task.Title,
+		&task.Description,
+		&task.Status,
+		&task.Priority,
+		&task.Category,
+		&courseID,
+		&dueDate,
+		&recurrenceRule,
+		&task.EstimatedMinutes,
+		&task.TrackedSeconds,
+		&task.Pomodoros,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+		&completedAt,
+		&archivedAt,
+		&task.Position,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if courseID.Valid {
+		task.CourseID = courseID.String
+	}
+	if dueDate.Valid {
+		task.DueDate = &dueDate.Time
+	}
+	if recurrenceRule.Valid {
+		task.RecurrenceRule = recurrenceRule.String
+	}
+	if completedAt.Valid {
+		task.CompletedAt = &completedAt.Time
+	}
+	if archivedAt.Valid {
+		task.ArchivedAt = &archivedAt.Time
+	}
+
+	return task, nil
+}
+
 // loadTags loads tags for a task
 func (r *TaskRepository) loadTags(taskID string) ([]string, error) {
 	query := `
@@ -389,13 +968,13 @@ func (r *TaskRepository) loadTags(taskID string) ([]string, error) {
 	return tags, rows.Err()
 }
 
-// loadSubtasks loads subtasks for a task
+// loadSubtasks loads subtasks for a task, in checklist order
 func (r *TaskRepository) loadSubtasks(taskID string) ([]models.Subtask, error) {
 	query := `
-		SELECT id, task_id, title, is_completed, created_at
+		SELECT id, task_id, parent_id, title, is_completed, position, created_at
 		FROM subtasks
 		WHERE task_id = ?
-		ORDER BY created_at ASC
+		ORDER BY position ASC, created_at ASC
 	`
 	rows, err := r.DB().Query(query, taskID)
 	if err != nil {
@@ -406,12 +985,20 @@ func (r *TaskRepository) loadSubtasks(taskID string) ([]models.Subtask, error) {
 	var subtasks []models.Subtask
 	for rows.Next() {
 		var subtask models.Subtask
-		if err := rows.Scan(&subtask.ID, &subtask.TaskID, &subtask.Title, &subtask.IsCompleted, &subtask.CreatedAt); err != nil {
+		var parentID sql.NullInt64
+		if err := rows.Scan(&subtask.ID, &subtask.TaskID, &parentID, &subtask.Title, &subtask.IsCompleted, &subtask.Position, &subtask.CreatedAt); err != nil {
 			return nil, err
 		}
+		if parentID.Valid {
+			id := int(parentID.Int64)
+			subtask.ParentID = &id
+		}
 		subtasks = append(subtasks, subtask)
 	}
-	return subtasks, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return models.SortSubtasks(subtasks), nil
 }
 
 // UpdateSubtask updates a subtask's completion status.
@@ -424,12 +1011,104 @@ func (r *TaskRepository) UpdateSubtask(subtask *models.Subtask) error {
 	return nil
 }
 
-// CreateSubtask inserts a new subtask into the database.
+// RenameSubtask changes the title of a subtask.
+func (r *TaskRepository) RenameSubtask(id int, title string) error {
+	query := `UPDATE subtasks SET title = ? WHERE id = ?`
+	result, err := r.DB().Exec(query, title, id)
+	if err != nil {
+		return fmt.Errorf("failed to rename subtask %d: %w", id, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("subtask not found: %d", id)
+	}
+
+	return nil
+}
+
+// UpdateSubtaskLayout saves the parent and position of subtasks, as
+// rearranged by Task.MoveSubtask, IndentSubtask or OutdentSubtask.
+func (r *TaskRepository) UpdateSubtaskLayout(subtasks []models.Subtask) error {
+	tx, err := r.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, subtask := range subtasks {
+		query := `UPDATE subtasks SET parent_id = ?, position = ? WHERE id = ?`
+		if _, err := tx.Exec(query, subtask.ParentID, subtask.Position, subtask.ID); err != nil {
+			return fmt.Errorf("failed to move subtask %d: %w", subtask.ID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CreateSubtask inserts a new subtask into the database, after the other
+// subtasks with the same parent.
 func (r *TaskRepository) CreateSubtask(subtask *models.Subtask) error {
-	query := `INSERT INTO subtasks (task_id, title, is_completed, created_at) VALUES (?, ?, ?, ?)`
+	return r.insertSubtask(r.DB(), subtask)
+}
+
+// CreateSubtasks inserts subtasks in a single transaction. Subtasks come in
+// checklist order, and parent IDs referring to subtasks in the list are
+// updated to the IDs the parents get.
+func (r *TaskRepository) CreateSubtasks(subtasks []models.Subtask) error {
+	tx, err := r.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.insertSubtasks(tx, subtasks); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertSubtasks inserts subtasks in checklist order, keeping their nesting
+func (r *TaskRepository) insertSubtasks(ex executor, subtasks []models.Subtask) error {
+	newIDs := make(map[int]int)
+	for i := range subtasks {
+		subtask := &subtasks[i]
+		if subtask.ParentID != nil {
+			if id, ok := newIDs[*subtask.ParentID]; ok {
+				subtask.ParentID = &id
+			}
+		}
+
+		oldID := subtask.ID
+		if err := r.insertSubtask(ex, subtask); err != nil {
+			return err
+		}
+		if oldID != 0 {
+			newIDs[oldID] = subtask.ID
+		}
+	}
+	return nil
+}
+
+// insertSubtask inserts a subtask, on the connection or in a transaction
+func (r *TaskRepository) insertSubtask(ex executor, subtask *models.Subtask) error {
+	err := ex.QueryRow(
+		`SELECT COALESCE(MAX(position), -1) + 1 FROM subtasks WHERE task_id = ? AND parent_id IS ?`,
+		subtask.TaskID, subtask.ParentID,
+	).Scan(&subtask.Position)
+	if err != nil {
+		return fmt.Errorf("failed to position subtask: %w", err)
+	}
+
+	query := `INSERT INTO subtasks (task_id, parent_id, title, is_completed, position, created_at) VALUES (?, ?, ?, ?, ?, ?)`
 	subtask.CreatedAt = time.Now()
 
-	result, err := r.DB().Exec(query, subtask.TaskID, subtask.Title, subtask.IsCompleted, subtask.CreatedAt)
+	result, err := ex.Exec(query, subtask.TaskID, subtask.ParentID, subtask.Title, subtask.IsCompleted, subtask.Position, subtask.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create subtask: %w", err)
 	}
@@ -442,9 +1121,17 @@ func (r *TaskRepository) CreateSubtask(subtask *models.Subtask) error {
 	return nil
 }
 
-// DeleteSubtask removes a subtask from the database.
+// DeleteSubtask removes a subtask and the subtasks nested under it from the
+// database.
 func (r *TaskRepository) DeleteSubtask(id int) error {
-	query := `DELETE FROM subtasks WHERE id = ?`
+	query := `
+		WITH RECURSIVE branch(id) AS (
+			SELECT ?
+			UNION
+			SELECT s.id FROM subtasks s JOIN branch b ON s.parent_id = b.id
+		)
+		DELETE FROM subtasks WHERE id IN (SELECT id FROM branch)
+	`
 	_, err := r.DB().Exec(query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete subtask %d: %w", id, err)
@@ -452,24 +1139,18 @@ func (r *TaskRepository) DeleteSubtask(id int) error {
 	return nil
 }
 
-// updateTags updates tags for a task
-func (r *TaskRepository) updateTags(taskID string, tags []string) error {
-	tx, err := r.BeginTx()
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	if _, err := tx.Exec("DELETE FROM task_tags WHERE task_id = ?", taskID); err != nil {
+// writeTags replaces the tags of a task
+func writeTags(ex executor, taskID string, tags []string) error {
+	if _, err := ex.Exec("DELETE FROM task_tags WHERE task_id = ?", taskID); err != nil {
 		return err
 	}
 
 	// Insert new tags
 	for _, tag := range tags {
 		var tagID int64
-		err := tx.QueryRow("SELECT id FROM tags WHERE name = ?", tag).Scan(&tagID)
+		err := ex.QueryRow("SELECT id FROM tags WHERE name = ?", tag).Scan(&tagID)
 		if err == sql.ErrNoRows {
-			result, err := tx.Exec("INSERT INTO tags (name) VALUES (?)", tag)
+			result, err := ex.Exec("INSERT INTO tags (name) VALUES (?)", tag)
 			if err != nil {
 				return err
 			}
@@ -482,10 +1163,81 @@ func (r *TaskRepository) updateTags(taskID string, tags []string) error {
 		}
 
 		// Link task with tag
-		if _, err := tx.Exec("INSERT INTO task_tags (task_id, tag_id) VALUES (?, ?)", taskID, tagID); err != nil {
+		if _, err := ex.Exec("INSERT INTO task_tags (task_id, tag_id) VALUES (?, ?)", taskID, tagID); err != nil {
 			return err
 		}
 	}
 
-	return tx.Commit()
+	return nil
+}
+
+// loadDependencies loads the prerequisites of a task
+func (r *TaskRepository) loadDependencies(taskID string) ([]models.TaskDependency, error) {
+	query := `
+		SELECT t.id, t.title, t.status
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.depends_on_id
+		WHERE d.task_id = ? AND t.deleted_at IS NULL
+		ORDER BY t.title COLLATE NOCASE
+	`
+
+	rows, err := r.DB().Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dependencies []models.TaskDependency
+	for rows.Next() {
+		var dep models.TaskDependency
+		if err := rows.Scan(&dep.ID, &dep.Title, &dep.Status); err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, dep)
+	}
+
+	return dependencies, rows.Err()
+}
+
+// writeDependencies replaces the prerequisites of a task. A prerequisite that
+// already depends on the task, directly or through other tasks, is rejected.
+// Prerequisites in the trash are not loaded with the task, so their links are
+// kept for when they are restored.
+func writeDependencies(ex executor, taskID string, dependencies []models.TaskDependency) error {
+	_, err := ex.Exec(`
+		DELETE FROM task_dependencies
+		WHERE task_id = ? AND depends_on_id IN (SELECT id FROM tasks WHERE deleted_at IS NULL)
+	`, taskID)
+	if err != nil {
+		return err
+	}
+
+	for _, dep := range dependencies {
+		if dep.ID == taskID {
+			return fmt.Errorf("a task cannot depend on itself")
+		}
+
+		var cycle int
+		err := ex.QueryRow(`
+			WITH RECURSIVE prerequisites(id) AS (
+				SELECT ?
+				UNION
+				SELECT d.depends_on_id FROM task_dependencies d
+				JOIN prerequisites p ON d.task_id = p.id
+			)
+			SELECT COUNT(*) FROM prerequisites WHERE id = ?
+		`, dep.ID, taskID).Scan(&cycle)
+		if err != nil {
+			return err
+		}
+		if cycle > 0 {
+			return fmt.Errorf("\"%s\" already depends on this task", dep.Title)
+		}
+
+		if _, err := ex.Exec("INSERT OR IGNORE INTO task_dependencies (task_id, depends_on_id) VALUES (?, ?)", taskID, dep.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stiffis/UniCLI/internal/models"
+)
+
+// TaskTemplateRepository handles task template data operations
+type TaskTemplateRepository struct {
+	*BaseRepository
+}
+
+// NewTaskTemplateRepository creates a new task template repository
+func NewTaskTemplateRepository(db *sql.DB) *TaskTemplateRepository {
+	return &TaskTemplateRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *TaskTemplateRepository) Create(template *models.TaskTemplate) error {
+	tagsJSON, subtasksJSON, err := marshalTemplateLists(template)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO task_templates (id, name, title_pattern, priority, tags, due_in, subtasks, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.DB().Exec(
+		query,
+		template.ID,
+		template.Name,
+		template.TitlePattern,
+		template.Priority,
+		tagsJSON,
+		template.DueIn,
+		subtasksJSON,
+		template.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create task template: %w", err)
+	}
+
+	return nil
+}
+
+// FindAll retrieves all task templates ordered by name
+func (r *TaskTemplateRepository) FindAll() ([]models.TaskTemplate, error) {
+	query := `
+		SELECT id, name, title_pattern, priority, tags, due_in, subtasks, created_at
+		FROM task_templates
+		ORDER BY name COLLATE NOCASE
+	`
+
+	rows, err := r.DB().Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []models.TaskTemplate{}
+	for rows.Next() {
+		var template models.TaskTemplate
+		var tagsJSON, subtasksJSON string
+		err := rows.Scan(
+			&template.ID,
+			&template.Name,
+			&template.TitlePattern,
+			&template.Priority,
+			&tagsJSON,
+			&template.DueIn,
+			&subtasksJSON,
+			&template.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task template: %w", err)
+		}
+
+		if err := json.Unmarshal([]byte(tagsJSON), &template.Tags); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal template tags: %w", err)
+		}
+		if err := json.Unmarshal([]byte(subtasksJSON), &template.Subtasks); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal template subtasks: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating task templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+func (r *TaskTemplateRepository) Update(template *models.TaskTemplate) error {
+	tagsJSON, subtasksJSON, err := marshalTemplateLists(template)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE task_templates
+		SET name = ?, title_pattern = ?, priority = ?, tags = ?, due_in = ?, subtasks = ?
+		WHERE id = ?
+	`
+
+	result, err := r.DB().Exec(
+		query,
+		template.Name,
+		template.TitlePattern,
+		template.Priority,
+		tagsJSON,
+		template.DueIn,
+		subtasksJSON,
+		template.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update task template: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("task template not found: %s", template.ID)
+	}
+
+	return nil
+}
+
+func (r *TaskTemplateRepository) Delete(id string) error {
+	query := `DELETE FROM task_templates WHERE id = ?`
+
+	result, err := r.DB().Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete task template: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("task template not found: %s", id)
+	}
+
+	return nil
+}
+
+// marshalTemplateLists encodes the tags and the checklist of a template as
+// JSON arrays
+func marshalTemplateLists(template *models.TaskTemplate) (string, string, error) {
+	tags := template.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	tagsJSON, err := json.Marshal(tags)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal tags: %w", err)
+	}
+
+	subtasks := template.Subtasks
+	if subtasks == nil {
+		subtasks = []string{}
+	}
+	subtasksJSON, err := json.Marshal(subtasks)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal subtasks: %w", err)
+	}
+
+	return string(tagsJSON), string(subtasksJSON), nil
+}
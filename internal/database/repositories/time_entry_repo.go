@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/stiffis/UniCLI/internal/models"
+)
+
+// TimeEntryRepository handles time tracking data operations
+type TimeEntryRepository struct {
+	*BaseRepository
+}
+
+// NewTimeEntryRepository creates a new time entry repository
+func NewTimeEntryRepository(db *sql.DB) *TimeEntryRepository {
+	return &TimeEntryRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Start starts tracking time on a task. Only one timer runs at a time, so a
+// running entry is stopped first.
+func (r *TimeEntryRepository) Start(taskID string) (*models.TimeEntry, error) {
+	if _, err := r.Stop(); err != nil {
+		return nil, err
+	}
+
+	entry := models.NewTimeEntry(taskID)
+	query := `INSERT INTO time_entries (id, task_id, started_at) VALUES (?, ?, ?)`
+	if _, err := r.DB().Exec(query, entry.ID, entry.TaskID, entry.StartedAt); err != nil {
+		return nil, fmt.Errorf("failed to start timer: %w", err)
+	}
+
+	return entry, nil
+}
+
+// Stop stops the running entry and returns it, nil if no timer was running
+func (r *TimeEntryRepository) Stop() (*models.TimeEntry, error) {
+	entry, err := r.FindRunning()
+	if err != nil || entry == nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entry.EndedAt = &now
+	entry.DurationSeconds = int(now.Sub(entry.StartedAt).Seconds())
+
+	query := `UPDATE time_entries SET ended_at = ?, duration_seconds = ? WHERE id = ?`
+	if _, err := r.DB().Exec(query, entry.EndedAt, entry.DurationSeconds, entry.ID); err != nil {
+		return nil, fmt.Errorf("failed to stop timer: %w", err)
+	}
+
+	return entry, nil
+}
+
+// FindRunning retrieves the running entry, nil if no timer is running
+func (r *TimeEntryRepository) FindRunning() (*models.TimeEntry, error) {
+	query := `
+		SELECT id, task_id, started_at
+		FROM time_entries
+		WHERE ended_at IS NULL
+		ORDER BY started_at DESC
+		LIMIT 1
+	`
+
+	entry := &models.TimeEntry{}
+	err := r.DB().QueryRow(query).Scan(&entry.ID, &entry.TaskID, &entry.StartedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find running timer: %w", err)
+	}
+
+	return entry, nil
+}
+
+// taskTimeTotals sums the stopped entries of each task
+const taskTimeTotals = `
+	SELECT task_id, SUM(duration_seconds) AS seconds
+	FROM time_entries
+	GROUP BY task_id
+`
+
+// CompareByCourse compares estimated and tracked time of the tasks of each course
+func (r *TimeEntryRepository) CompareByCourse() ([]models.TimeComparison, error) {
+	query := `
+		SELECT CASE WHEN c.code != '' THEN c.code ELSE c.name END,
+			COUNT(*), SUM(t.estimated_minutes), SUM(COALESCE(te.seconds, 0))
+		FROM tasks t
+		JOIN courses c ON c.id = t.course_id
+		LEFT JOIN (` + taskTimeTotals + `) te ON te.task_id = t.id
+		WHERE t.deleted_at IS NULL AND c.deleted_at IS NULL
+			AND (t.estimated_minutes > 0 OR te.seconds > 0)
+		GROUP BY c.id
+		ORDER BY 1 COLLATE NOCASE
+	`
+
+	return r.queryComparisons(query)
+}
+
+// CompareByTag compares estimated and tracked time of the tasks of each tag
+func (r *TimeEntryRepository) CompareByTag() ([]models.TimeComparison, error) {
+	query := `
+		SELECT tg.name, COUNT(*), SUM(t.estimated_minutes), SUM(COALESCE(te.seconds, 0))
+		FROM tasks t
+		JOIN task_tags tt ON tt.task_id = t.id
+		JOIN tags tg ON tg.id = tt.tag_id
+		LEFT JOIN (` + taskTimeTotals + `) te ON te.task_id = t.id
+		WHERE t.deleted_at IS NULL AND (t.estimated_minutes > 0 OR te.seconds > 0)
+		GROUP BY tg.id
+		ORDER BY 1 COLLATE NOCASE
+	`
+
+	return r.queryComparisons(query)
+}
+
+// queryComparisons runs a comparison query and scans its rows
+func (r *TimeEntryRepository) queryComparisons(query string) ([]models.TimeComparison, error) {
+	rows, err := r.DB().Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare tracked time: %w", err)
+	}
+	defer rows.Close()
+
+	comparisons := []models.TimeComparison{}
+	for rows.Next() {
+		var c models.TimeComparison
+		if err := rows.Scan(&c.Label, &c.Tasks, &c.EstimatedMinutes, &c.TrackedSeconds); err != nil {
+			return nil, fmt.Errorf("failed to scan time comparison: %w", err)
+		}
+		comparisons = append(comparisons, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating time comparisons: %w", err)
+	}
+
+	return comparisons, nil
+}
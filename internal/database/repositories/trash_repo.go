@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/stiffis/UniCLI/internal/models"
+)
+
+// trashTables are the tables of the items that can be moved to the trash
+var trashTables = map[models.SearchKind]string{
+	models.SearchKindTask:       "tasks",
+	models.SearchKindEvent:      "events",
+	models.SearchKindCourse:     "courses",
+	models.SearchKindCourseNote: "course_notes",
+	models.SearchKindNote:       "notes",
+}
+
+// TrashRepository lists, restores and purges deleted items. Deleting a task,
+// event, course or note only sets its deleted_at column; the row and the rows
+// depending on it are removed once the item is purged.
+type TrashRepository struct {
+	*BaseRepository
+}
+
+// NewTrashRepository creates a new trash repository
+func NewTrashRepository(db *sql.DB) *TrashRepository {
+	return &TrashRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindAll retrieves the items in the trash, most recently deleted first
+func (r *TrashRepository) FindAll() ([]models.TrashItem, error) {
+	query := `
+		SELECT 'task', id, title, '', deleted_at FROM tasks WHERE deleted_at IS NOT NULL
+		UNION ALL
+		SELECT 'event', id, title, '', deleted_at FROM events WHERE deleted_at IS NOT NULL
+		UNION ALL
+		SELECT 'course', id, CASE WHEN code != '' THEN code || ' - ' || name ELSE name END, '', deleted_at
+		FROM courses WHERE deleted_at IS NOT NULL
+		UNION ALL
+		SELECT 'course_note', n.id, n.title, CASE WHEN c.code != '' THEN c.code ELSE c.name END, n.deleted_at
+		FROM course_notes n JOIN courses c ON c.id = n.course_id WHERE n.deleted_at IS NOT NULL
+		UNION ALL
+		SELECT 'note', id, title, '', deleted_at FROM notes WHERE deleted_at IS NOT NULL
+		ORDER BY 5 DESC
+	`
+
+	rows, err := r.DB().Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trash: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.TrashItem{}
+	for rows.Next() {
+		var item models.TrashItem
+		if err := rows.Scan(&item.Kind, &item.ID, &item.Title, &item.Course, &item.DeletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan trash item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate trash: %w", err)
+	}
+
+	return items, nil
+}
+
+// Restore moves an item out of the trash
+func (r *TrashRepository) Restore(kind models.SearchKind, id string) error {
+	table, ok := trashTables[kind]
+	if !ok {
+		return fmt.Errorf("unknown item kind: %s", kind)
+	}
+
+	query := `UPDATE ` + table + ` SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`
+	result, err := r.DB().Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %w", kind.Label(), err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("%s not found in trash: %s", kind.Label(), id)
+	}
+
+	return nil
+}
+
+// Purge permanently deletes an item in the trash along with the rows depending
+// on it, such as the subtasks of a task or the grades of a course
+func (r *TrashRepository) Purge(kind models.SearchKind, id string) error {
+	table, ok := trashTables[kind]
+	if !ok {
+		return fmt.Errorf("unknown item kind: %s", kind)
+	}
+
+	result, err := r.DB().Exec(`DELETE FROM `+table+` WHERE id = ? AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to purge %s: %w", kind.Label(), err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("%s not found in trash: %s", kind.Label(), id)
+	}
+
+	return nil
+}
+
+// PurgeOlderThan permanently deletes the items moved to the trash before a
+// time and returns how many were deleted
+func (r *TrashRepository) PurgeOlderThan(cutoff time.Time) (int, error) {
+	return r.purge("deleted_at < ?", cutoff)
+}
+
+// Empty permanently deletes every item in the trash and returns how many were
+// deleted
+func (r *TrashRepository) Empty() (int, error) {
+	return r.purge("1 = 1")
+}
+
+// purge permanently deletes the items in the trash matching a condition
+func (r *TrashRepository) purge(condition string, args ...any) (int, error) {
+	tx, err := r.BeginTx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Notes go before their courses so they are counted
+	purged := 0
+	for _, table := range []string{"tasks", "events", "course_notes", "notes", "courses"} {
+		query := `DELETE FROM ` + table + ` WHERE deleted_at IS NOT NULL AND ` + condition
+		result, err := tx.Exec(query, args...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge %s: %w", table, err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		purged += int(rows)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit purge: %w", err)
+	}
+
+	return purged, nil
+}
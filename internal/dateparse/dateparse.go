@@ -0,0 +1,289 @@
+// Package dateparse parses the dates and times typed into forms and prompts.
+// Besides ISO dates it understands relative expressions such as "today",
+// "tomorrow 9am", "fri 14:00", "next week", "+3d", "in 2h" and partial dates
+// such as "06-30" or "jun 30".
+package dateparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Result is a parsed date
+type Result struct {
+	Time    time.Time
+	HasDate bool // A date was given, otherwise Time is today
+	HasTime bool // A time of day was given, otherwise Time is midnight
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var months = map[string]time.Month{
+	"jan": time.January, "january": time.January,
+	"feb": time.February, "february": time.February,
+	"mar": time.March, "march": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May,
+	"jun": time.June, "june": time.June,
+	"jul": time.July, "july": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October,
+	"nov": time.November, "november": time.November,
+	"dec": time.December, "december": time.December,
+}
+
+// Parse parses a date with an optional time of day, resolving relative dates
+// against now:
+//
+//	2025-06-30 14:00   06-30   jun 30   30 jun 2026
+//	today   tomorrow 9am   fri   next fri   next week   next month
+//	+3d   +2w   in 3 days   in 2h   in 30m   14:00
+//
+// Weekdays are the next such day, today included; "next fri" skips today.
+// Partial dates without a year are the next such date.
+func Parse(s string, now time.Time) (Result, error) {
+	tokens := strings.Fields(strings.ToLower(s))
+	if len(tokens) == 0 {
+		return Result{}, fmt.Errorf("empty date")
+	}
+
+	if len(tokens) == 1 && tokens[0] == "now" {
+		return Result{Time: now, HasDate: true, HasTime: true}, nil
+	}
+
+	// Relative times such as "in 2h" are not combined with a time of day
+	if result, ok, err := parseRelative(tokens, now); ok || err != nil {
+		return result, err
+	}
+
+	// Split off a trailing time of day, "3 pm" and "at 15:00" included
+	var result Result
+	hour, minute := 0, 0
+	if n := len(tokens); n >= 2 && (tokens[n-1] == "am" || tokens[n-1] == "pm") {
+		tokens = append(tokens[:n-2], tokens[n-2]+tokens[n-1])
+	}
+	if n := len(tokens); n > 0 {
+		if h, m, err := ParseClock(tokens[n-1]); err == nil {
+			hour, minute, result.HasTime = h, m, true
+			tokens = tokens[:n-1]
+			if n := len(tokens); n > 0 && tokens[n-1] == "at" {
+				tokens = tokens[:n-1]
+			}
+		}
+	}
+
+	day := startOfDay(now)
+	if len(tokens) > 0 {
+		d, err := parseDay(tokens, now)
+		if err != nil {
+			return Result{}, fmt.Errorf("unrecognized date %q (try today, fri 14:00, +3d or 2025-06-30)", strings.TrimSpace(s))
+		}
+		day, result.HasDate = d, true
+	}
+
+	result.Time = time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location())
+	return result, nil
+}
+
+// ParseDate parses a date without a time of day, see Parse. The result is
+// the start of the day.
+func ParseDate(s string, now time.Time) (time.Time, error) {
+	result, err := Parse(s, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if result.HasTime && !result.HasDate {
+		return time.Time{}, fmt.Errorf("expected a date, not a time: %q", strings.TrimSpace(s))
+	}
+	return startOfDay(result.Time), nil
+}
+
+// ParseClock parses a time of day such as 9:30, 23:59, 3pm or 3:30pm
+func ParseClock(s string) (hour, minute int, err error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, layout := range []string{"15:04", "3pm", "3:04pm"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Hour(), t.Minute(), nil
+		}
+	}
+	if s == "noon" {
+		return 12, 0, nil
+	}
+	if s == "midnight" {
+		return 0, 0, nil
+	}
+	return 0, 0, fmt.Errorf("invalid time %q (use e.g. 14:30 or 2:30pm)", s)
+}
+
+// parseRelative parses "+3d", "in 3 days", "in 2h" and similar. ok is false
+// if the tokens are not a relative expression.
+func parseRelative(tokens []string, now time.Time) (result Result, ok bool, err error) {
+	var amount string
+	switch {
+	case tokens[0] == "in" && len(tokens) > 1:
+		amount = strings.Join(tokens[1:], "")
+	case strings.HasPrefix(tokens[0], "+"):
+		amount = strings.TrimPrefix(strings.Join(tokens, ""), "+")
+	default:
+		return Result{}, false, nil
+	}
+
+	digits := 0
+	for digits < len(amount) && amount[digits] >= '0' && amount[digits] <= '9' {
+		digits++
+	}
+	n, convErr := strconv.Atoi(amount[:digits])
+	if convErr != nil {
+		if tokens[0] == "in" {
+			// "in" is not necessarily a relative date
+			return Result{}, false, nil
+		}
+		return Result{}, false, fmt.Errorf("invalid relative date %q (use e.g. +3d or in 2h)", strings.Join(tokens, " "))
+	}
+
+	today := startOfDay(now)
+	switch strings.TrimSuffix(amount[digits:], "s") {
+	case "m", "min", "minute":
+		return Result{Time: now.Add(time.Duration(n) * time.Minute), HasDate: true, HasTime: true}, true, nil
+	case "h", "hr", "hour":
+		return Result{Time: now.Add(time.Duration(n) * time.Hour), HasDate: true, HasTime: true}, true, nil
+	case "d", "day":
+		return Result{Time: today.AddDate(0, 0, n), HasDate: true}, true, nil
+	case "w", "wk", "week":
+		return Result{Time: today.AddDate(0, 0, 7*n), HasDate: true}, true, nil
+	case "mo", "month":
+		return Result{Time: today.AddDate(0, n, 0), HasDate: true}, true, nil
+	}
+
+	if tokens[0] == "in" {
+		return Result{}, false, nil
+	}
+	return Result{}, false, fmt.Errorf("invalid relative date %q (use e.g. +3d or in 2h)", strings.Join(tokens, " "))
+}
+
+// parseDay parses the date part of an expression into the start of the day
+func parseDay(tokens []string, now time.Time) (time.Time, error) {
+	today := startOfDay(now)
+	expr := strings.Join(tokens, " ")
+
+	switch expr {
+	case "today", "tod":
+		return today, nil
+	case "tomorrow", "tmr", "tmrw":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "next week":
+		// Monday of next week
+		return today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7), nil
+	case "next month":
+		return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()), nil
+	}
+
+	// Weekdays
+	if len(tokens) <= 2 {
+		name, skipToday := tokens[len(tokens)-1], false
+		if len(tokens) == 2 {
+			switch tokens[0] {
+			case "next":
+				skipToday = true
+			case "this", "on":
+			default:
+				name = ""
+			}
+		}
+		if day, ok := weekdays[name]; ok {
+			offset := (int(day) - int(today.Weekday()) + 7) % 7
+			if offset == 0 && skipToday {
+				offset = 7
+			}
+			return today.AddDate(0, 0, offset), nil
+		}
+	}
+
+	// Numeric dates: 2025-06-30, 2025/06/30, 06-30 or 6/30
+	if len(tokens) == 1 {
+		parts := strings.FieldsFunc(tokens[0], func(r rune) bool { return r == '-' || r == '/' || r == '.' })
+		switch {
+		case len(parts) == 3 && len(parts[0]) == 4:
+			return makeDate(parts[0], parts[1], parts[2], now)
+		case len(parts) == 2:
+			return makeDate("", parts[0], parts[1], now)
+		}
+	}
+
+	// Month names: jun 30, 30 jun, jun 30 2026 or 30 jun 2026
+	if len(tokens) == 2 || len(tokens) == 3 {
+		year := ""
+		if len(tokens) == 3 {
+			year = tokens[2]
+		}
+		first, second := strings.TrimSuffix(tokens[0], ","), strings.TrimSuffix(tokens[1], ",")
+		if month, ok := months[first]; ok {
+			return makeDate(year, strconv.Itoa(int(month)), trimOrdinal(second), now)
+		}
+		if month, ok := months[second]; ok {
+			return makeDate(year, strconv.Itoa(int(month)), trimOrdinal(first), now)
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized date %q", expr)
+}
+
+// makeDate builds a date from its parts. Without a year the date is the next
+// such date, today included.
+func makeDate(year, month, day string, now time.Time) (time.Time, error) {
+	m, err := strconv.Atoi(month)
+	if err != nil {
+		return time.Time{}, err
+	}
+	d, err := strconv.Atoi(day)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	today := startOfDay(now)
+	y := today.Year()
+	if year != "" {
+		if y, err = strconv.Atoi(year); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	date := time.Date(y, time.Month(m), d, 0, 0, 0, 0, now.Location())
+	// Reject dates that overflow into the next month, e.g. 02-30
+	if date.Month() != time.Month(m) || date.Day() != d {
+		return time.Time{}, fmt.Errorf("invalid date %s-%s", month, day)
+	}
+
+	if year == "" && date.Before(today) {
+		date = date.AddDate(1, 0, 0)
+	}
+	return date, nil
+}
+
+// trimOrdinal removes the suffix of 1st, 2nd, 3rd and 4th
+func trimOrdinal(s string) string {
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if strings.HasSuffix(s, suffix) {
+			return strings.TrimSuffix(s, suffix)
+		}
+	}
+	return s
+}
+
+// startOfDay returns midnight of the day of t
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,142 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+// now is Wednesday 2025-11-12 10:30, the dates below are relative to it
+var now = time.Date(2025, time.November, 12, 10, 30, 0, 0, time.UTC)
+
+func date(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+// expectParse checks that s parses to want
+func expectParse(t *testing.T, s string, want time.Time) Result {
+	t.Helper()
+	result, err := Parse(s, now)
+	if err != nil {
+		t.Errorf("Parse(%q) failed: %v", s, err)
+		return result
+	}
+	if !result.Time.Equal(want) {
+		t.Errorf("Parse(%q) = %v, want %v", s, result.Time, want)
+	}
+	return result
+}
+
+func TestParseRelativeOffsets(t *testing.T) {
+	// Days, weeks and months count from the start of today
+	if result := expectParse(t, "+3d", date(2025, time.November, 15, 0, 0)); result.HasTime {
+		t.Error("+3d should not have a time of day")
+	}
+	expectParse(t, "+2w", date(2025, time.November, 26, 0, 0))
+	expectParse(t, "+1mo", date(2025, time.December, 12, 0, 0))
+	expectParse(t, "in 3 days", date(2025, time.November, 15, 0, 0))
+	expectParse(t, "+50d", date(2026, time.January, 1, 0, 0))
+
+	// Hours and minutes count from now
+	if result := expectParse(t, "in 2h", date(2025, time.November, 12, 12, 30)); !result.HasTime {
+		t.Error("in 2h should have a time of day")
+	}
+	expectParse(t, "in 30m", date(2025, time.November, 12, 11, 0))
+}
+
+func TestParseNamedDays(t *testing.T) {
+	expectParse(t, "now", now)
+	expectParse(t, "today", date(2025, time.November, 12, 0, 0))
+	expectParse(t, "tomorrow 9am", date(2025, time.November, 13, 9, 0))
+	expectParse(t, "yesterday", date(2025, time.November, 11, 0, 0))
+	expectParse(t, "next week", date(2025, time.November, 17, 0, 0))
+	expectParse(t, "next month", date(2025, time.December, 1, 0, 0))
+
+	// A time alone is today
+	if result := expectParse(t, "14:00", date(2025, time.November, 12, 14, 0)); result.HasDate {
+		t.Error("14:00 should not have a date")
+	}
+}
+
+func TestParseWeekdays(t *testing.T) {
+	expectParse(t, "fri", date(2025, time.November, 14, 0, 0))
+	expectParse(t, "Friday 14:00", date(2025, time.November, 14, 14, 0))
+	expectParse(t, "mon", date(2025, time.November, 17, 0, 0))
+	expectParse(t, "on thu at 3 pm", date(2025, time.November, 13, 15, 0))
+
+	// Today is a Wednesday: "wed" is today, "next wed" is a week later
+	expectParse(t, "wed", date(2025, time.November, 12, 0, 0))
+	expectParse(t, "next wed", date(2025, time.November, 19, 0, 0))
+}
+
+func TestParseFullDates(t *testing.T) {
+	expectParse(t, "2025-06-30", date(2025, time.June, 30, 0, 0))
+	expectParse(t, "2026/01/05 23:59", date(2026, time.January, 5, 23, 59))
+	expectParse(t, "30 jun 2026", date(2026, time.June, 30, 0, 0))
+}
+
+func TestParsePartialDatesRollIntoNextYear(t *testing.T) {
+	// Dates still ahead this year, today included
+	expectParse(t, "11-12", date(2025, time.November, 12, 0, 0))
+	expectParse(t, "12-25", date(2025, time.December, 25, 0, 0))
+	expectParse(t, "dec 3rd 5pm", date(2025, time.December, 3, 17, 0))
+
+	// Dates already past are next year's
+	expectParse(t, "06-30", date(2026, time.June, 30, 0, 0))
+	expectParse(t, "11/11", date(2026, time.November, 11, 0, 0))
+	expectParse(t, "jun 30", date(2026, time.June, 30, 0, 0))
+	expectParse(t, "1st jan", date(2026, time.January, 1, 0, 0))
+}
+
+func TestParseRejectsUnknownInput(t *testing.T) {
+	for _, s := range []string{"", "   ", "someday", "next someday", "+3x", "+d", "in a while"} {
+		if result, err := Parse(s, now); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", s, result.Time)
+		}
+	}
+
+	// Dates that do not exist are not moved to the next month
+	for _, s := range []string{"02-30", "jun 31", "2025-13-01"} {
+		if result, err := Parse(s, now); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", s, result.Time)
+		}
+	}
+
+	if _, err := Parse("fri 25:00", now); err == nil {
+		t.Error("Parse accepted an hour past 23")
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	got, err := ParseDate("tomorrow 9am", now)
+	if err != nil {
+		t.Fatalf("ParseDate failed: %v", err)
+	}
+	if want := date(2025, time.November, 13, 0, 0); !got.Equal(want) {
+		t.Errorf("ParseDate = %v, want the start of the day %v", got, want)
+	}
+
+	if _, err := ParseDate("14:00", now); err == nil {
+		t.Error("ParseDate accepted a time without a date")
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	if hour, minute, err := ParseClock("3:30PM"); err != nil || hour != 15 || minute != 30 {
+		t.Errorf("ParseClock(3:30PM) = %d:%02d, %v", hour, minute, err)
+	}
+	if hour, minute, err := ParseClock("23:59"); err != nil || hour != 23 || minute != 59 {
+		t.Errorf("ParseClock(23:59) = %d:%02d, %v", hour, minute, err)
+	}
+	if hour, _, err := ParseClock("noon"); err != nil || hour != 12 {
+		t.Errorf("ParseClock(noon) = %d, %v", hour, err)
+	}
+	if hour, _, err := ParseClock("midnight"); err != nil || hour != 0 {
+		t.Errorf("ParseClock(midnight) = %d, %v", hour, err)
+	}
+
+	for _, s := range []string{"24:00", "13pm", "soon"} {
+		if _, _, err := ParseClock(s); err == nil {
+			t.Errorf("ParseClock(%q) should fail", s)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BoardColumn is a column of the kanban board
+type BoardColumn struct {
+	Name     string
+	Statuses []TaskStatus // Tasks moved into the column get the first status
+	WIPLimit int          // Most tasks the column takes, 0 for no limit
+}
+
+// DefaultBoardColumns returns the To Do, In Progress and Done columns.
+// Cancelled tasks are shown in Done.
+func DefaultBoardColumns() []BoardColumn {
+	return []BoardColumn{
+		{Name: "To Do", Statuses: []TaskStatus{TaskStatusPending}},
+		{Name: "In Progress", Statuses: []TaskStatus{TaskStatusInProgress}},
+		{Name: "Done", Statuses: []TaskStatus{TaskStatusCompleted, TaskStatusCancelled}},
+	}
+}
+
+// ValidateBoardColumns checks that every column has a name and a status and
+// that no status is shown in two columns
+func ValidateBoardColumns(columns []BoardColumn) error {
+	if len(columns) == 0 {
+		return fmt.Errorf("the board needs at least one column")
+	}
+
+	seen := make(map[TaskStatus]string)
+	for i, column := range columns {
+		if strings.TrimSpace(column.Name) == "" {
+			return fmt.Errorf("column %d has no name", i+1)
+		}
+		if len(column.Statuses) == 0 {
+			return fmt.Errorf("column %q has no status", column.Name)
+		}
+		if column.WIPLimit < 0 {
+			return fmt.Errorf("column %q has a negative WIP limit", column.Name)
+		}
+		for _, status := range column.Statuses {
+			if other, ok := seen[status]; ok {
+				return fmt.Errorf("status %s is shown in both %q and %q", status, other, column.Name)
+			}
+			seen[status] = column.Name
+		}
+	}
+
+	return nil
+}
+
+// Status returns the status of tasks moved into the column
+func (c BoardColumn) Status() TaskStatus {
+	return c.Statuses[0]
+}
+
+// Holds returns true if tasks with the status are shown in the column
+func (c BoardColumn) Holds(status TaskStatus) bool {
+	for _, s := range c.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFull returns true if the column reached its WIP limit with count tasks
+func (c BoardColumn) IsFull(count int) bool {
+	return c.WIPLimit > 0 && count >= c.WIPLimit
+}
+
+// BoardColumnOf returns the index of the column showing tasks with the
+// status. Statuses no column maps are shown in the last column, so no task
+// disappears from the board.
+func BoardColumnOf(columns []BoardColumn, status TaskStatus) int {
+	for i, column := range columns {
+		if column.Holds(status) {
+			return i
+		}
+	}
+	return len(columns) - 1
+}
@@ -10,6 +10,7 @@ type Event struct {
 	ID                string     `json:"id"`
 	Title             string     `json:"title"`
 	Description       string     `json:"description"`
+	Location          string     `json:"location"`
 	StartDatetime     time.Time  `json:"start_datetime"`
 	EndDatetime       *time.Time `json:"end_datetime"`
 	Type              string     `json:"type"`
@@ -0,0 +1,286 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GradeBand maps a minimum percentage to a letter and grade points
+type GradeBand struct {
+	Min    float64 // Minimum percentage (0-100) to reach the band
+	Label  string  // "A-"
+	Points float64 // 3.7
+}
+
+// GradingScale converts course percentages into grade points.
+// Scales with bands use the first band whose minimum is reached; scales
+// without bands map the percentage linearly onto 0..Max.
+type GradingScale struct {
+	Name  string      // "letter"
+	Title string      // "4.0 letter scale"
+	Max   float64     // Highest possible grade point
+	Bands []GradeBand // Sorted from highest to lowest minimum
+}
+
+// Built-in grading scales
+var (
+	LetterScale = GradingScale{
+		Name:  "letter",
+		Title: "4.0 letter scale",
+		Max:   4,
+		Bands: []GradeBand{
+			{Min: 93, Label: "A", Points: 4.0},
+			{Min: 90, Label: "A-", Points: 3.7},
+			{Min: 87, Label: "B+", Points: 3.3},
+			{Min: 83, Label: "B", Points: 3.0},
+			{Min: 80, Label: "B-", Points: 2.7},
+			{Min: 77, Label: "C+", Points: 2.3},
+			{Min: 73, Label: "C", Points: 2.0},
+			{Min: 70, Label: "C-", Points: 1.7},
+			{Min: 67, Label: "D+", Points: 1.3},
+			{Min: 60, Label: "D", Points: 1.0},
+			{Min: 0, Label: "F", Points: 0},
+		},
+	}
+
+	VigesimalScale = GradingScale{
+		Name:  "vigesimal",
+		Title: "0-20 scale",
+		Max:   20,
+	}
+
+	PercentageScale = GradingScale{
+		Name:  "percentage",
+		Title: "percentage",
+		Max:   100,
+	}
+)
+
+// GradingScales lists the built-in grading scales
+var GradingScales = []GradingScale{LetterScale, VigesimalScale, PercentageScale}
+
+// GradingScaleByName returns the built-in scale with the given name, falling
+// back to the letter scale when the name is unknown
+func GradingScaleByName(name string) (GradingScale, bool) {
+	for _, scale := range GradingScales {
+		if strings.EqualFold(scale.Name, strings.TrimSpace(name)) {
+			return scale, true
+		}
+	}
+	return LetterScale, false
+}
+
+// Convert returns the grade points and display label for a percentage
+func (s GradingScale) Convert(pct float64) (float64, string) {
+	if len(s.Bands) == 0 {
+		points := pct / 100 * s.Max
+		return points, s.Format(points)
+	}
+
+	for _, band := range s.Bands {
+		if pct >= band.Min {
+			return band.Points, band.Label
+		}
+	}
+
+	last := s.Bands[len(s.Bands)-1]
+	return last.Points, last.Label
+}
+
+// Percentage returns the lowest percentage that converts to at least the
+// given grade points
+func (s GradingScale) Percentage(points float64) float64 {
+	if len(s.Bands) == 0 {
+		if s.Max <= 0 {
+			return 0
+		}
+		return points / s.Max * 100
+	}
+
+	for i := len(s.Bands) - 1; i >= 0; i-- {
+		if s.Bands[i].Points >= points {
+			return s.Bands[i].Min
+		}
+	}
+	return s.Bands[0].Min
+}
+
+// ParseTarget converts a target grade into a percentage. It accepts a band
+// label ("B+"), grade points on the scale ("3.0", "16") or a percentage ("85%").
+func (s GradingScale) ParseTarget(target string) (float64, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return 0, fmt.Errorf("target is required")
+	}
+
+	for _, band := range s.Bands {
+		if strings.EqualFold(band.Label, target) {
+			return band.Min, nil
+		}
+	}
+
+	if strings.HasSuffix(target, "%") {
+		pct, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(target, "%")), 64)
+		if err != nil || pct < 0 || pct > 100 {
+			return 0, fmt.Errorf("invalid percentage: %s", target)
+		}
+		return pct, nil
+	}
+
+	points, err := strconv.ParseFloat(strings.ReplaceAll(target, ",", "."), 64)
+	if err != nil || points < 0 || points > s.Max {
+		return 0, fmt.Errorf("target must be a grade between 0 and %s on the %s", strconv.FormatFloat(s.Max, 'f', -1, 64), s.Title)
+	}
+	return s.Percentage(points), nil
+}
+
+// Format formats grade points with the precision used by the scale
+func (s GradingScale) Format(points float64) string {
+	switch {
+	case s.Max >= 100:
+		return fmt.Sprintf("%.1f%%", points)
+	case s.Max > 4:
+		return fmt.Sprintf("%.1f", points)
+	default:
+		return fmt.Sprintf("%.2f", points)
+	}
+}
+
+// CourseResult is the outcome of a course on a grading scale
+type CourseResult struct {
+	Course  Course
+	Average float64 // Weighted average (0-100)
+	Points  float64 // Grade points on the scale
+	Label   string  // "B+", "16.5", ...
+}
+
+// SemesterGPA is the credit-weighted GPA of a single semester
+type SemesterGPA struct {
+	Semester string
+	Courses  []CourseResult
+	GPA      float64
+	Credits  int
+}
+
+// GPAReport holds per-semester and cumulative credit-weighted GPAs
+type GPAReport struct {
+	Scale      GradingScale
+	Semesters  []SemesterGPA // Oldest semester first
+	Cumulative float64
+	Credits    int
+}
+
+// HasGPA returns true if at least one course counted towards the GPA
+func (r GPAReport) HasGPA() bool {
+	return r.Credits > 0
+}
+
+// Semester returns the GPA of the given semester, if it has any graded course
+func (r GPAReport) Semester(semester string) (SemesterGPA, bool) {
+	for _, s := range r.Semesters {
+		if s.Semester == semester {
+			return s, true
+		}
+	}
+	return SemesterGPA{}, false
+}
+
+// ComputeGPA computes per-semester and cumulative GPAs weighted by course
+// credits. Only courses with credits and at least one graded assessment count.
+func ComputeGPA(courses []Course, grades map[string][]Grade, scale GradingScale) GPAReport {
+	report := GPAReport{Scale: scale}
+	bySemester := map[string]*SemesterGPA{}
+	var totalPoints float64
+
+	for _, course := range courses {
+		if course.Credits <= 0 {
+			continue
+		}
+		avg, ok := WeightedAverage(grades[course.ID])
+		if !ok {
+			continue
+		}
+
+		points, label := scale.Convert(avg)
+		sem, exists := bySemester[course.Semester]
+		if !exists {
+			sem = &SemesterGPA{Semester: course.Semester}
+			bySemester[course.Semester] = sem
+		}
+		sem.Courses = append(sem.Courses, CourseResult{
+			Course:  course,
+			Average: avg,
+			Points:  points,
+			Label:   label,
+		})
+		sem.GPA += points * float64(course.Credits)
+		sem.Credits += course.Credits
+
+		totalPoints += points * float64(course.Credits)
+		report.Credits += course.Credits
+	}
+
+	for _, sem := range bySemester {
+		sem.GPA /= float64(sem.Credits)
+		report.Semesters = append(report.Semesters, *sem)
+	}
+	sort.Slice(report.Semesters, func(i, j int) bool {
+		return SemesterLess(report.Semesters[i].Semester, report.Semesters[j].Semester)
+	})
+
+	if report.Credits > 0 {
+		report.Cumulative = totalPoints / float64(report.Credits)
+	}
+
+	return report
+}
+
+var semesterYearRegex = regexp.MustCompile(`\d{4}`)
+
+// semesterTerms orders the terms of an academic year
+var semesterTerms = []string{"winter", "spring", "summer", "fall", "autumn"}
+
+// SemesterLess orders semester names such as "Spring 2025", "Fall 2025" or
+// "2025-1" chronologically. Semesters without a year sort last.
+func SemesterLess(a, b string) bool {
+	yearA, termA := semesterKey(a)
+	yearB, termB := semesterKey(b)
+
+	if yearA != yearB {
+		if yearA == 0 || yearB == 0 {
+			return yearB == 0
+		}
+		return yearA < yearB
+	}
+	if termA != termB {
+		return termA < termB
+	}
+	return a < b
+}
+
+// semesterKey extracts the year and term order of a semester name
+func semesterKey(semester string) (int, int) {
+	lower := strings.ToLower(semester)
+
+	year := 0
+	if match := semesterYearRegex.FindString(lower); match != "" {
+		year, _ = strconv.Atoi(match)
+	}
+
+	for i, term := range semesterTerms {
+		if strings.Contains(lower, term) {
+			return year, i
+		}
+	}
+
+	// Numbered terms like "2025-1" or "2025-II"
+	rest := strings.TrimSpace(semesterYearRegex.ReplaceAllString(lower, ""))
+	rest = strings.Trim(rest, "-/ ")
+	if n, err := strconv.Atoi(rest); err == nil {
+		return year, n
+	}
+	return year, len(rest)
+}
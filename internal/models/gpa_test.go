@@ -0,0 +1,110 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// graded returns a graded assessment of a course
+func graded(courseID string, score, maxScore, weight float64) Grade {
+	return Grade{ID: fmt.Sprintf("%s-%v", courseID, score), CourseID: courseID, Score: score, MaxScore: maxScore, Weight: weight, Graded: true}
+}
+
+// closeTo returns true if two grades are equal to the precision shown
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) < 0.005
+}
+
+func TestGradingScaleConvert(t *testing.T) {
+	tests := []struct {
+		scale  GradingScale
+		pct    float64
+		points float64
+		label  string
+	}{
+		{LetterScale, 95, 4.0, "A"},
+		{LetterScale, 90, 3.7, "A-"},
+		{LetterScale, 89.99, 3.3, "B+"},
+		{LetterScale, 60, 1.0, "D"},
+		{LetterScale, 59.9, 0, "F"},
+		{VigesimalScale, 80, 16, "16.0"},
+		{VigesimalScale, 0, 0, "0.0"},
+		{PercentageScale, 87.5, 87.5, "87.5%"},
+	}
+
+	for _, tt := range tests {
+		points, label := tt.scale.Convert(tt.pct)
+		if !closeTo(points, tt.points) || label != tt.label {
+			t.Errorf("%s: Convert(%v) = %v %q, want %v %q", tt.scale.Name, tt.pct, points, label, tt.points, tt.label)
+		}
+	}
+}
+
+func TestGradingScaleByName(t *testing.T) {
+	if scale, ok := GradingScaleByName(" Vigesimal "); !ok || scale.Name != VigesimalScale.Name {
+		t.Errorf("got %s, %v", scale.Name, ok)
+	}
+	if scale, ok := GradingScaleByName("gpa5"); ok || scale.Name != LetterScale.Name {
+		t.Errorf("unknown scale: got %s, %v, want the letter scale", scale.Name, ok)
+	}
+}
+
+func TestComputeGPA(t *testing.T) {
+	courses := []Course{
+		{ID: "calc", Semester: "Fall 2025", Credits: 4},
+		{ID: "phys", Semester: "Fall 2025", Credits: 3},
+		{ID: "chem", Semester: "Spring 2025", Credits: 2},
+		{ID: "seminar", Semester: "Fall 2025", Credits: 0}, // No credits
+		{ID: "lab", Semester: "Fall 2025", Credits: 3},     // Nothing graded yet
+	}
+	grades := map[string][]Grade{
+		"calc":    {graded("calc", 19, 20, 1)},
+		"phys":    {graded("phys", 80, 100, 1), graded("phys", 90, 100, 1)},
+		"chem":    {graded("chem", 75, 100, 1)},
+		"seminar": {graded("seminar", 10, 100, 1)},
+		"lab":     {{ID: "lab-1", CourseID: "lab", MaxScore: 100, Weight: 1}},
+	}
+
+	// calc 95%, phys 85% and chem 75%
+	tests := []struct {
+		scale                    GradingScale
+		spring, fall, cumulative float64
+	}{
+		{LetterScale, 2.0, (4.0*4 + 3.0*3) / 7, (4.0*4 + 3.0*3 + 2.0*2) / 9},
+		{VigesimalScale, 15, (19.0*4 + 17*3) / 7, (19.0*4 + 17*3 + 15*2) / 9},
+		{PercentageScale, 75, (95.0*4 + 85*3) / 7, (95.0*4 + 85*3 + 75*2) / 9},
+	}
+
+	for _, tt := range tests {
+		report := ComputeGPA(courses, grades, tt.scale)
+
+		if report.Credits != 9 || len(report.Semesters) != 2 {
+			t.Fatalf("%s: got %d credits in %d semesters, want 9 in 2", tt.scale.Name, report.Credits, len(report.Semesters))
+		}
+		spring, fall := report.Semesters[0], report.Semesters[1]
+		if spring.Semester != "Spring 2025" || !closeTo(spring.GPA, tt.spring) {
+			t.Errorf("%s: first semester %s GPA %v, want Spring 2025 %v", tt.scale.Name, spring.Semester, spring.GPA, tt.spring)
+		}
+		if len(fall.Courses) != 2 || fall.Credits != 7 || !closeTo(fall.GPA, tt.fall) {
+			t.Errorf("%s: Fall 2025 has %d courses, %d credits, GPA %v, want 2, 7, %v",
+				tt.scale.Name, len(fall.Courses), fall.Credits, fall.GPA, tt.fall)
+		}
+		if !closeTo(report.Cumulative, tt.cumulative) {
+			t.Errorf("%s: cumulative GPA %v, want %v", tt.scale.Name, report.Cumulative, tt.cumulative)
+		}
+	}
+}
+
+func TestComputeGPAWithoutCountingCourses(t *testing.T) {
+	courses := []Course{
+		{ID: "seminar", Semester: "Fall 2025", Credits: 0},
+		{ID: "lab", Semester: "Fall 2025", Credits: 3},
+	}
+	grades := map[string][]Grade{"seminar": {graded("seminar", 90, 100, 1)}}
+
+	report := ComputeGPA(courses, grades, LetterScale)
+	if report.HasGPA() || len(report.Semesters) != 0 || report.Cumulative != 0 {
+		t.Errorf("got %d credits, %d semesters, cumulative %v, want no GPA", report.Credits, len(report.Semesters), report.Cumulative)
+	}
+}
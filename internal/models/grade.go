@@ -0,0 +1,155 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Grade represents a single graded (or still pending) assessment of a course
+type Grade struct {
+	ID        string     `json:"id"`
+	CourseID  string     `json:"course_id"`
+	Name      string     `json:"name"`      // "Midterm 1"
+	Score     float64    `json:"score"`     // 17.5
+	MaxScore  float64    `json:"max_score"` // 20
+	Weight    float64    `json:"weight"`    // 0.3 or 30, weights are relative to each other
+	Graded    bool       `json:"graded"`    // false while the assessment has no score yet
+	Date      *time.Time `json:"date"`
+	Type      string     `json:"type"` // "exam", "quiz", "homework", "project", "lab"
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewGrade creates a new ungraded assessment for a course
+func NewGrade(courseID, name string) *Grade {
+	return &Grade{
+		ID:        uuid.New().String(),
+		CourseID:  courseID,
+		Name:      name,
+		MaxScore:  100,
+		Weight:    1,
+		Type:      "exam",
+		CreatedAt: time.Now(),
+	}
+}
+
+// Percentage returns the score as a percentage of the maximum score
+func (g *Grade) Percentage() float64 {
+	if g.MaxScore <= 0 {
+		return 0
+	}
+	return g.Score / g.MaxScore * 100
+}
+
+// WeightedAverage returns the weighted running average (0-100) of the graded
+// assessments. Ungraded assessments are ignored. The second return value is
+// false when there is nothing graded yet.
+func WeightedAverage(grades []Grade) (float64, bool) {
+	var total, weights float64
+	for _, g := range grades {
+		if !g.Graded || g.MaxScore <= 0 || g.Weight <= 0 {
+			continue
+		}
+		total += g.Percentage() * g.Weight
+		weights += g.Weight
+	}
+
+	if weights == 0 {
+		return 0, false
+	}
+	return total / weights, true
+}
+
+// GradedWeight returns the share (0-1) of the total weight that is already graded
+func GradedWeight(grades []Grade) float64 {
+	var graded, total float64
+	for _, g := range grades {
+		if g.Weight <= 0 {
+			continue
+		}
+		total += g.Weight
+		if g.Graded {
+			graded += g.Weight
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+	return graded / total
+}
+
+// GroupGradesByCourse groups grades by their course ID
+func GroupGradesByCourse(grades []Grade) map[string][]Grade {
+	byCourse := map[string][]Grade{}
+	for _, g := range grades {
+		byCourse[g.CourseID] = append(byCourse[g.CourseID], g)
+	}
+	return byCourse
+}
+
+// SimulateGrades returns a copy of grades where the hypothetical scores (by
+// grade ID) replace the missing scores of ungraded assessments
+func SimulateGrades(grades []Grade, hypothetical map[string]float64) []Grade {
+	simulated := make([]Grade, len(grades))
+	copy(simulated, grades)
+
+	for i := range simulated {
+		if simulated[i].Graded {
+			continue
+		}
+		if score, ok := hypothetical[simulated[i].ID]; ok {
+			simulated[i].Score = score
+			simulated[i].Graded = true
+		}
+	}
+
+	return simulated
+}
+
+// Requirement describes the score needed on the remaining assessments of a
+// course to reach a target average
+type Requirement struct {
+	Target    float64 // Target average (0-100)
+	Needed    float64 // Percentage needed on every remaining assessment
+	Remaining float64 // Share (0-1) of the total weight that is still ungraded
+}
+
+// Secured returns true if the target is reached even with zero on the remaining assessments
+func (r Requirement) Secured() bool {
+	return r.Needed <= 0
+}
+
+// Achievable returns true if the target can still be reached
+func (r Requirement) Achievable() bool {
+	return r.Needed <= 100
+}
+
+// RequiredScore solves for the minimum percentage needed on the remaining
+// (ungraded) weight so the final course average reaches the target. The second
+// return value is false when there is no ungraded weight left.
+func RequiredScore(grades []Grade, target float64) (Requirement, bool) {
+	var gradedTotal, gradedWeight, remainingWeight float64
+	for _, g := range grades {
+		if g.Weight <= 0 || g.MaxScore <= 0 {
+			continue
+		}
+		if g.Graded {
+			gradedTotal += g.Percentage() * g.Weight
+			gradedWeight += g.Weight
+		} else {
+			remainingWeight += g.Weight
+		}
+	}
+
+	if remainingWeight == 0 {
+		return Requirement{Target: target}, false
+	}
+
+	totalWeight := gradedWeight + remainingWeight
+	return Requirement{
+		Target:    target,
+		Needed:    (target*totalWeight - gradedTotal) / remainingWeight,
+		Remaining: remainingWeight / totalWeight,
+	}, true
+}
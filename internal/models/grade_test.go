@@ -0,0 +1,102 @@
+package models
+
+import "testing"
+
+// finalTerm returns a course with a graded midterm of 70% worth 40 and an
+// ungraded final worth 60
+func finalTerm() []Grade {
+	return []Grade{
+		{ID: "midterm", Score: 14, MaxScore: 20, Weight: 40, Graded: true},
+		{ID: "final", MaxScore: 100, Weight: 60},
+	}
+}
+
+func TestRequiredScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     float64
+		needed     float64
+		achievable bool
+		secured    bool
+	}{
+		{"reachable", 85, 95, true, false},
+		{"exactly full marks", 88, 100, true, false},
+		{"cannot be reached", 93, 108.33, false, false},
+		{"secured already", 25, -5, true, true},
+	}
+
+	for _, tt := range tests {
+		req, ok := RequiredScore(finalTerm(), tt.target)
+		if !ok {
+			t.Fatalf("%s: no remaining weight", tt.name)
+		}
+		if !closeTo(req.Needed, tt.needed) || !closeTo(req.Remaining, 0.6) {
+			t.Errorf("%s: needed %v on %v of the weight, want %v on 0.6", tt.name, req.Needed, req.Remaining, tt.needed)
+		}
+		if req.Achievable() != tt.achievable || req.Secured() != tt.secured {
+			t.Errorf("%s: achievable %v secured %v, want %v %v", tt.name, req.Achievable(), req.Secured(), tt.achievable, tt.secured)
+		}
+	}
+}
+
+func TestRequiredScoreWithoutRemainingWeight(t *testing.T) {
+	// Everything graded
+	grades := []Grade{
+		{ID: "midterm", Score: 14, MaxScore: 20, Weight: 40, Graded: true},
+		{ID: "final", Score: 80, MaxScore: 100, Weight: 60, Graded: true},
+	}
+	if req, ok := RequiredScore(grades, 85); ok || req.Target != 85 || req.Needed != 0 {
+		t.Errorf("all graded: got %+v, %v", req, ok)
+	}
+
+	// The only ungraded assessment weighs nothing
+	grades = []Grade{
+		{ID: "midterm", Score: 14, MaxScore: 20, Weight: 40, Graded: true},
+		{ID: "bonus", MaxScore: 10, Weight: 0},
+	}
+	if _, ok := RequiredScore(grades, 85); ok {
+		t.Error("zero weight left: a score should not be required")
+	}
+}
+
+func TestSimulateGrades(t *testing.T) {
+	grades := finalTerm()
+	simulated := SimulateGrades(grades, map[string]float64{"midterm": 20, "final": 90})
+
+	// Only the missing score is filled in
+	if simulated[0].Score != 14 {
+		t.Errorf("graded midterm changed to %v", simulated[0].Score)
+	}
+	if !simulated[1].Graded || simulated[1].Score != 90 {
+		t.Errorf("final: got graded %v score %v", simulated[1].Graded, simulated[1].Score)
+	}
+	if grades[1].Graded {
+		t.Error("the simulation changed the real grades")
+	}
+
+	// 70% on 40 and 90% on 60
+	if avg, ok := WeightedAverage(simulated); !ok || !closeTo(avg, 82) {
+		t.Errorf("simulated average %v, want 82", avg)
+	}
+}
+
+func TestGradingScaleParseTarget(t *testing.T) {
+	if pct, err := LetterScale.ParseTarget("b+"); err != nil || pct != 87 {
+		t.Errorf("B+: got %v, %v", pct, err)
+	}
+	if pct, err := LetterScale.ParseTarget("3.0"); err != nil || pct != 83 {
+		t.Errorf("3.0 on the letter scale: got %v, %v", pct, err)
+	}
+	if pct, err := VigesimalScale.ParseTarget("16,5"); err != nil || !closeTo(pct, 82.5) {
+		t.Errorf("16,5 on the 0-20 scale: got %v, %v", pct, err)
+	}
+	if pct, err := PercentageScale.ParseTarget("85%"); err != nil || pct != 85 {
+		t.Errorf("85%%: got %v, %v", pct, err)
+	}
+
+	for _, target := range []string{"", "5", "A++", "120%"} {
+		if _, err := LetterScale.ParseTarget(target); err == nil {
+			t.Errorf("ParseTarget(%q) should fail", target)
+		}
+	}
+}
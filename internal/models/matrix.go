@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+// Quadrant is a quadrant of the Eisenhower priority matrix. The matrix is
+// laid out with urgent tasks on the left and important tasks on top.
+type Quadrant int
+
+const (
+	QuadrantDoFirst   Quadrant = iota // Urgent and important
+	QuadrantSchedule                  // Important but not urgent
+	QuadrantDelegate                  // Urgent but not important
+	QuadrantEliminate                 // Neither urgent nor important
+	QuadrantCount
+)
+
+// Title returns the name of the quadrant
+func (q Quadrant) Title() string {
+	switch q {
+	case QuadrantDoFirst:
+		return "Do First"
+	case QuadrantSchedule:
+		return "Schedule"
+	case QuadrantDelegate:
+		return "Delegate"
+	}
+	return "Eliminate"
+}
+
+// Description returns what the tasks of the quadrant have in common
+func (q Quadrant) Description() string {
+	switch q {
+	case QuadrantDoFirst:
+		return "urgent · important"
+	case QuadrantSchedule:
+		return "not urgent · important"
+	case QuadrantDelegate:
+		return "urgent · not important"
+	}
+	return "not urgent · not important"
+}
+
+// MatrixThresholds decide which tasks are urgent and which are important
+type MatrixThresholds struct {
+	UrgentWithinDays int          // Tasks due within this many days are urgent, 0 for due today or overdue
+	ImportantFrom    TaskPriority // Tasks with at least this priority are important
+}
+
+// DefaultMatrixThresholds treats tasks due within two days as urgent and
+// high priority tasks as important
+func DefaultMatrixThresholds() MatrixThresholds {
+	return MatrixThresholds{
+		UrgentWithinDays: 2,
+		ImportantFrom:    TaskPriorityHigh,
+	}
+}
+
+// IsUrgent returns true if the task is due within the threshold, overdue or
+// has the urgent priority
+func (m MatrixThresholds) IsUrgent(task Task, now time.Time) bool {
+	if task.Priority == TaskPriorityUrgent {
+		return true
+	}
+	if task.DueDate == nil {
+		return false
+	}
+	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return task.DueDate.Before(startOfToday.AddDate(0, 0, m.UrgentWithinDays+1))
+}
+
+// IsImportant returns true if the task has at least the important priority
+func (m MatrixThresholds) IsImportant(task Task) bool {
+	return task.Priority.Rank() >= m.ImportantFrom.Rank()
+}
+
+// QuadrantOf returns the quadrant the task falls in
+func (m MatrixThresholds) QuadrantOf(task Task, now time.Time) Quadrant {
+	urgent, important := m.IsUrgent(task, now), m.IsImportant(task)
+	switch {
+	case urgent && important:
+		return QuadrantDoFirst
+	case important:
+		return QuadrantSchedule
+	case urgent:
+		return QuadrantDelegate
+	}
+	return QuadrantEliminate
+}
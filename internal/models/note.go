@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Note represents a standalone note that is not linked to any course
+type Note struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"` // Markdown content
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewNote creates a new standalone note
+func NewNote(title, content string) *Note {
+	return &Note{
+		ID:        uuid.New().String(),
+		Title:     title,
+		Content:   content,
+		Tags:      []string{},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
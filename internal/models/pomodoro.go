@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PomodoroPhase is a phase of the Pomodoro cycle
+type PomodoroPhase string
+
+const (
+	PomodoroWork       PomodoroPhase = "work"
+	PomodoroShortBreak PomodoroPhase = "short_break"
+	PomodoroLongBreak  PomodoroPhase = "long_break"
+)
+
+// Label returns a human readable name for the phase
+func (p PomodoroPhase) Label() string {
+	switch p {
+	case PomodoroWork:
+		return "Focus"
+	case PomodoroShortBreak:
+		return "Short break"
+	case PomodoroLongBreak:
+		return "Long break"
+	default:
+		return string(p)
+	}
+}
+
+// PomodoroSettings are the lengths of the Pomodoro phases
+type PomodoroSettings struct {
+	Work           time.Duration
+	ShortBreak     time.Duration
+	LongBreak      time.Duration
+	LongBreakEvery int // Work sessions before a long break
+}
+
+// DefaultPomodoroSettings returns the classic 25/5/15 cycle with a long break
+// every four work sessions
+func DefaultPomodoroSettings() PomodoroSettings {
+	return PomodoroSettings{
+		Work:           25 * time.Minute,
+		ShortBreak:     5 * time.Minute,
+		LongBreak:      15 * time.Minute,
+		LongBreakEvery: 4,
+	}
+}
+
+// Duration returns the length of a phase
+func (s PomodoroSettings) Duration(phase PomodoroPhase) time.Duration {
+	switch phase {
+	case PomodoroShortBreak:
+		return s.ShortBreak
+	case PomodoroLongBreak:
+		return s.LongBreak
+	default:
+		return s.Work
+	}
+}
+
+// NextPhase returns the phase that follows one, given the number of work
+// sessions completed in the cycle so far
+func (s PomodoroSettings) NextPhase(phase PomodoroPhase, completed int) PomodoroPhase {
+	if phase != PomodoroWork {
+		return PomodoroWork
+	}
+	if s.LongBreakEvery > 0 && completed > 0 && completed%s.LongBreakEvery == 0 {
+		return PomodoroLongBreak
+	}
+	return PomodoroShortBreak
+}
+
+// Pomodoro is a completed work session logged against a task
+type Pomodoro struct {
+	ID              string    `json:"id"`
+	TaskID          string    `json:"task_id"`
+	StartedAt       time.Time `json:"started_at"`
+	EndedAt         time.Time `json:"ended_at"`
+	DurationMinutes int       `json:"duration_minutes"`
+}
+
+// NewPomodoro creates a new completed Pomodoro
+func NewPomodoro(taskID string, startedAt, endedAt time.Time) *Pomodoro {
+	return &Pomodoro{
+		ID:              uuid.New().String(),
+		TaskID:          taskID,
+		StartedAt:       startedAt,
+		EndedAt:         endedAt,
+		DurationMinutes: int(endedAt.Sub(startedAt).Round(time.Minute) / time.Minute),
+	}
+}
@@ -0,0 +1,221 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stiffis/UniCLI/internal/dateparse"
+)
+
+// QuickAdd is a task or an event parsed from a single line of text
+type QuickAdd struct {
+	Task  *Task  // Set if the line describes a task
+	Event *Event // Set if the line describes an event
+}
+
+// ParseQuickAdd parses a quick-add line such as
+//
+//	Finish lab report #cs !high due fri 23:59
+//	Study group tomorrow 15:00-17:00 @library
+//
+// #word adds a tag, !word sets the priority, @word (or @"two words") sets the
+// location and "due" introduces the due date. Dates and times are anything
+// dateparse understands, e.g. "fri 23:59", "next week" or "in 2h", and
+// 15:00-17:00 is a time range. Without "due" a date is only read at the end of
+// the line, followed by nothing but tags, a priority, a location or a time
+// range, so that titles such as "Watch Now You See Me" keep their words. A
+// time range or a location makes the line an event, anything else is a task.
+// The remaining words are the title.
+func ParseQuickAdd(input string, now time.Time) (QuickAdd, error) {
+	tokens, err := splitFilterQuery(input)
+	if err != nil {
+		return QuickAdd{}, err
+	}
+
+	hasDue := false
+	for _, token := range tokens {
+		hasDue = hasDue || strings.EqualFold(token, "due")
+	}
+
+	var (
+		title      []string
+		tags       []string
+		priority   TaskPriority
+		location   string
+		date       *time.Time
+		start, end *time.Duration // Times of day
+		isEvent    bool
+	)
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		switch {
+		case strings.HasPrefix(token, "#") && len(token) > 1:
+			tags = append(tags, strings.ToLower(token[1:]))
+
+		case strings.HasPrefix(token, "!") && len(token) > 1:
+			p, ok := ParseTaskPriority(token[1:])
+			if !ok {
+				return QuickAdd{}, fmt.Errorf("unknown priority: %s", token[1:])
+			}
+			priority = p
+
+		case strings.HasPrefix(token, "@") && len(token) > 1:
+			location = strings.Trim(token[1:], `"`)
+			isEvent = true
+
+		case strings.ToLower(token) == "due":
+			result, n := matchQuickDate(tokens[i+1:], now)
+			if n == 0 {
+				if i+1 >= len(tokens) {
+					return QuickAdd{}, fmt.Errorf("missing date after due")
+				}
+				return QuickAdd{}, fmt.Errorf("invalid due date: %s", tokens[i+1])
+			}
+			date, start = resultDay(result), resultClock(result)
+			i += n
+
+		default:
+			if s, e, ok := timeRangeOf(token); ok {
+				start, end = &s, &e
+				isEvent = true
+				continue
+			}
+			if result, n := matchQuickDate(tokens[i:], now); n > 0 && !hasDue && onlyQuickMarkers(tokens[i+n:]) {
+				if result.HasDate {
+					date = resultDay(result)
+				}
+				if result.HasTime {
+					start = resultClock(result)
+				}
+				i += n - 1
+				continue
+			}
+			title = append(title, strings.Trim(token, `"`))
+		}
+	}
+
+	text := strings.Join(title, " ")
+	if text == "" {
+		return QuickAdd{}, fmt.Errorf("missing title")
+	}
+
+	day := startOfDay(now)
+	if date != nil {
+		day = *date
+	}
+
+	if isEvent {
+		if len(tags) > 0 || priority != "" {
+			return QuickAdd{}, fmt.Errorf("events take no tags or priority")
+		}
+
+		event := NewEvent(text, day)
+		event.Location = location
+		if start != nil {
+			event.StartDatetime = atClock(day, *start)
+		} else {
+			// All day
+			endOfDay := day.AddDate(0, 0, 1)
+			event.EndDatetime = &endOfDay
+		}
+		if end != nil {
+			endTime := atClock(day, *end)
+			if !endTime.After(event.StartDatetime) {
+				// A range past midnight ends the next day
+				endTime = endTime.AddDate(0, 0, 1)
+			}
+			event.EndDatetime = &endTime
+		}
+		return QuickAdd{Event: event}, nil
+	}
+
+	task := NewTask(text)
+	task.Tags = tags
+	if priority != "" {
+		task.Priority = priority
+	}
+	if date != nil || start != nil {
+		due := day
+		if start != nil {
+			due = atClock(day, *start)
+		}
+		task.DueDate = &due
+	}
+	return QuickAdd{Task: task}, nil
+}
+
+// quickDateWords is the longest run of words read as a single date, e.g.
+// "in 3 days" or "fri 9:30 am"
+const quickDateWords = 4
+
+// matchQuickDate reads the longest date at the start of tokens. It returns
+// the number of tokens used, 0 if they do not start with a date.
+func matchQuickDate(tokens []string, now time.Time) (dateparse.Result, int) {
+	for n := min(quickDateWords, len(tokens)); n > 0; n-- {
+		if result, err := dateparse.Parse(strings.Join(tokens[:n], " "), now); err == nil {
+			return result, n
+		}
+	}
+	return dateparse.Result{}, 0
+}
+
+// onlyQuickMarkers returns true if tokens set nothing but tags, a priority, a
+// location or a time range, i.e. they add no words to the title
+func onlyQuickMarkers(tokens []string) bool {
+	for _, token := range tokens {
+		if _, _, ok := timeRangeOf(token); ok {
+			continue
+		}
+		if len(token) < 2 || !strings.ContainsRune("#!@", rune(token[0])) {
+			return false
+		}
+	}
+	return true
+}
+
+// timeRangeOf parses a time range such as 15:00-17:00 into its start and end
+// times of day
+func timeRangeOf(token string) (start, end time.Duration, ok bool) {
+	from, to, found := strings.Cut(token, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, errStart := clockOf(from)
+	end, errEnd := clockOf(to)
+	return start, end, errStart == nil && errEnd == nil
+}
+
+// resultDay returns the day of a parsed date
+func resultDay(result dateparse.Result) *time.Time {
+	day := startOfDay(result.Time)
+	return &day
+}
+
+// resultClock returns the time of day of a parsed date, nil if it has none
+func resultClock(result dateparse.Result) *time.Duration {
+	if !result.HasTime {
+		return nil
+	}
+	clock := time.Duration(result.Time.Hour())*time.Hour + time.Duration(result.Time.Minute())*time.Minute
+	return &clock
+}
+
+// clockOf parses a time of day such as 9:30 or 3pm
+func clockOf(s string) (time.Duration, error) {
+	hour, minute, err := dateparse.ParseClock(s)
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, err
+}
+
+// atClock returns the time of day on a day
+func atClock(day time.Time, clock time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(),
+		int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, day.Location())
+}
+
+// startOfDay returns midnight of the day of t
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// quickNow is Wednesday 2025-11-12 10:30
+var quickNow = at(2025, time.November, 12, 10, 30)
+
+// quickTask parses a line that must be a task
+func quickTask(t *testing.T, line string) *Task {
+	t.Helper()
+	parsed, err := ParseQuickAdd(line, quickNow)
+	if err != nil {
+		t.Fatalf("ParseQuickAdd(%q) failed: %v", line, err)
+	}
+	if parsed.Task == nil {
+		t.Fatalf("ParseQuickAdd(%q) made an event, want a task", line)
+	}
+	return parsed.Task
+}
+
+// quickEvent parses a line that must be an event
+func quickEvent(t *testing.T, line string) *Event {
+	t.Helper()
+	parsed, err := ParseQuickAdd(line, quickNow)
+	if err != nil {
+		t.Fatalf("ParseQuickAdd(%q) failed: %v", line, err)
+	}
+	if parsed.Event == nil {
+		t.Fatalf("ParseQuickAdd(%q) made a task, want an event", line)
+	}
+	return parsed.Event
+}
+
+func TestQuickAddTask(t *testing.T) {
+	task := quickTask(t, "Finish lab report #cs !high due fri 23:59")
+
+	if task.Title != "Finish lab report" {
+		t.Errorf("title: got %q", task.Title)
+	}
+	if strings.Join(task.Tags, ",") != "cs" {
+		t.Errorf("tags: got %v", task.Tags)
+	}
+	if task.Priority != TaskPriorityHigh {
+		t.Errorf("priority: got %s", task.Priority)
+	}
+	if want := at(2025, time.November, 14, 23, 59); task.DueDate == nil || !task.DueDate.Equal(want) {
+		t.Errorf("due: got %v, want %v", task.DueDate, want)
+	}
+}
+
+func TestQuickAddEvent(t *testing.T) {
+	event := quickEvent(t, "Study group tomorrow 15:00-17:00 @library")
+
+	if event.Title != "Study group" || event.Location != "library" {
+		t.Errorf("got %q at %q", event.Title, event.Location)
+	}
+	if want := at(2025, time.November, 13, 15, 0); !event.StartDatetime.Equal(want) {
+		t.Errorf("start: got %v, want %v", event.StartDatetime, want)
+	}
+	if want := at(2025, time.November, 13, 17, 0); event.EndDatetime == nil || !event.EndDatetime.Equal(want) {
+		t.Errorf("end: got %v, want %v", event.EndDatetime, want)
+	}
+
+	// A quoted location keeps its spaces
+	if event := quickEvent(t, `Recital sat @"main hall"`); event.Location != "main hall" {
+		t.Errorf("quoted location: got %q", event.Location)
+	}
+}
+
+func TestQuickAddTimeRangePastMidnight(t *testing.T) {
+	event := quickEvent(t, "Hackathon fri 22:00-02:00 @lab")
+
+	if want := at(2025, time.November, 14, 22, 0); !event.StartDatetime.Equal(want) {
+		t.Errorf("start: got %v, want %v", event.StartDatetime, want)
+	}
+	if want := at(2025, time.November, 15, 2, 0); event.EndDatetime == nil || !event.EndDatetime.Equal(want) {
+		t.Errorf("end: got %v, want the next day %v", event.EndDatetime, want)
+	}
+}
+
+func TestQuickAddKeepsDateWordsInTitles(t *testing.T) {
+	// A date followed by more title words is part of the title
+	task := quickTask(t, "Watch Now You See Me")
+	if task.Title != "Watch Now You See Me" || task.DueDate != nil {
+		t.Errorf("got %q due %v", task.Title, task.DueDate)
+	}
+
+	task = quickTask(t, "Read May Day essay #history")
+	if task.Title != "Read May Day essay" || task.DueDate != nil {
+		t.Errorf("got %q due %v", task.Title, task.DueDate)
+	}
+
+	// With "due" only the words after it are a date
+	task = quickTask(t, "Plan Friday party due tomorrow")
+	if task.Title != "Plan Friday party" {
+		t.Errorf("title: got %q", task.Title)
+	}
+	if want := at(2025, time.November, 13, 0, 0); task.DueDate == nil || !task.DueDate.Equal(want) {
+		t.Errorf("due: got %v, want %v", task.DueDate, want)
+	}
+
+	// At the end of the line a date is a date
+	task = quickTask(t, "Call the dentist mon 9am !low")
+	if task.Title != "Call the dentist" {
+		t.Errorf("title: got %q", task.Title)
+	}
+	if want := at(2025, time.November, 17, 9, 0); task.DueDate == nil || !task.DueDate.Equal(want) {
+		t.Errorf("due: got %v, want %v", task.DueDate, want)
+	}
+}
+
+func TestQuickAddRejects(t *testing.T) {
+	for _, line := range []string{
+		"",
+		"#cs !high",
+		"Essay !highest",
+		"Essay due",
+		"Essay due someday",
+		`Meet @"main hall`,
+		"Party #fun 20:00-23:00",
+	} {
+		if _, err := ParseQuickAdd(line, quickNow); err == nil {
+			t.Errorf("ParseQuickAdd(%q) should fail", line)
+		}
+	}
+}
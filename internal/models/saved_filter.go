@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedFilter is a named task filter shown as a smart list
+type SavedFilter struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query"` // Filter query, parsed again on every use
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewSavedFilter creates a new saved filter
+func NewSavedFilter(name, query string) *SavedFilter {
+	return &SavedFilter{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Query:     query,
+		CreatedAt: time.Now(),
+	}
+}
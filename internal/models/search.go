@@ -0,0 +1,62 @@
+package models
+
+import "strings"
+
+// SearchKind identifies the type of item a search result points to
+type SearchKind string
+
+const (
+	SearchKindTask       SearchKind = "task"
+	SearchKindEvent      SearchKind = "event"
+	SearchKindCourse     SearchKind = "course"
+	SearchKindCourseNote SearchKind = "course_note"
+	SearchKindNote       SearchKind = "note"
+)
+
+// Markers around the matched terms in a search snippet
+const (
+	SearchHighlightStart = "\x02"
+	SearchHighlightEnd   = "\x03"
+)
+
+// SearchResult is a single full-text search match
+type SearchResult struct {
+	Kind     SearchKind `json:"kind"`
+	ID       string     `json:"id"`
+	Title    string     `json:"title"`
+	Snippet  string     `json:"snippet"`  // Matched terms are wrapped in the highlight markers
+	Rank     float64    `json:"rank"`     // Lower is a better match
+	Archived bool       `json:"archived"` // Set for archived tasks
+}
+
+// Label returns a human readable name for the kind
+func (k SearchKind) Label() string {
+	switch k {
+	case SearchKindTask:
+		return "Task"
+	case SearchKindEvent:
+		return "Event"
+	case SearchKindCourse:
+		return "Course"
+	case SearchKindCourseNote:
+		return "Course note"
+	case SearchKindNote:
+		return "Note"
+	default:
+		return string(k)
+	}
+}
+
+// SearchQuery turns free text into an FTS5 query matching every word as a
+// prefix. Quotes are dropped so user input never breaks the query syntax.
+func SearchQuery(text string) string {
+	var terms []string
+	for _, word := range strings.Fields(text) {
+		word = strings.ReplaceAll(word, `"`, "")
+		if word == "" {
+			continue
+		}
+		terms = append(terms, `"`+word+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
@@ -1,12 +1,216 @@
 package models
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 // Subtask represents a single item in a task's checklist.
 type Subtask struct {
 	ID          int       `json:"id"`
 	TaskID      string    `json:"task_id"`
+	ParentID    *int      `json:"parent_id"` // Subtask this one is nested under, nil at the top level
 	Title       string    `json:"title"`
 	IsCompleted bool      `json:"is_completed"`
+	Position    int       `json:"position"` // Order among the subtasks with the same parent
+	Depth       int       `json:"depth"`    // Nesting level, set by SortSubtasks
 	CreatedAt   time.Time `json:"created_at"`
 }
+
+// SortSubtasks orders a checklist depth first, every subtask followed by the
+// subtasks nested under it and siblings by position, and sets their depth.
+// Subtasks whose parent is missing are shown at the top level.
+func SortSubtasks(subtasks []Subtask) []Subtask {
+	ids := make(map[int]bool, len(subtasks))
+	for _, st := range subtasks {
+		ids[st.ID] = true
+	}
+
+	children := make(map[int][]Subtask)
+	for _, st := range subtasks {
+		parent := parentKey(st, ids)
+		children[parent] = append(children[parent], st)
+	}
+	for _, siblings := range children {
+		sort.SliceStable(siblings, func(i, j int) bool {
+			return siblings[i].Position < siblings[j].Position
+		})
+	}
+
+	return flattenSubtasks(children, 0, 0, make([]Subtask, 0, len(subtasks)))
+}
+
+// parentKey returns the ID of the parent of a subtask, 0 at the top level
+func parentKey(st Subtask, ids map[int]bool) int {
+	if st.ParentID == nil || !ids[*st.ParentID] {
+		return 0
+	}
+	return *st.ParentID
+}
+
+// flattenSubtasks appends the subtasks under parent to out, depth first
+func flattenSubtasks(children map[int][]Subtask, parent, depth int, out []Subtask) []Subtask {
+	for _, st := range children[parent] {
+		st.Depth = depth
+		out = append(out, st)
+		// Subtasks that were not saved yet have no children
+		if st.ID != 0 {
+			out = flattenSubtasks(children, st.ID, depth+1, out)
+		}
+	}
+	return out
+}
+
+// SubtaskBranch returns the subtask with the given ID followed by every
+// subtask nested under it, in checklist order
+func (t *Task) SubtaskBranch(id int) []Subtask {
+	for i, st := range t.Subtasks {
+		if st.ID != id {
+			continue
+		}
+		end := i + 1
+		for end < len(t.Subtasks) && t.Subtasks[end].Depth > st.Depth {
+			end++
+		}
+		return append([]Subtask{}, t.Subtasks[i:end]...)
+	}
+	return nil
+}
+
+// subtaskProgress returns how far a subtask is from 0 to 1. A checked
+// subtask is done, otherwise a subtask with nested subtasks is as far as
+// their average.
+func (t *Task) subtaskProgress(i int) (float64, int) {
+	st := t.Subtasks[i]
+	next := i + 1
+	total, count := 0.0, 0
+	for next < len(t.Subtasks) && t.Subtasks[next].Depth > st.Depth {
+		progress, end := t.subtaskProgress(next)
+		total += progress
+		count++
+		next = end
+	}
+
+	switch {
+	case st.IsCompleted:
+		return 1, next
+	case count == 0:
+		return 0, next
+	}
+	return total / float64(count), next
+}
+
+// topLevelProgress returns the progress of every top-level subtask
+func (t *Task) topLevelProgress() []float64 {
+	var progress []float64
+	for i := 0; i < len(t.Subtasks); {
+		p, next := t.subtaskProgress(i)
+		progress = append(progress, p)
+		i = next
+	}
+	return progress
+}
+
+// MoveSubtask moves a subtask up (delta -1) or down (delta 1) among its
+// siblings, taking the subtasks nested under it along. It returns false if
+// there is no sibling to pass.
+func (t *Task) MoveSubtask(id, delta int) bool {
+	return t.relayoutSubtasks(func(children map[int][]int, parentOf map[int]int) bool {
+		siblings := children[parentOf[id]]
+		i := indexOf(siblings, id)
+		j := i + delta
+		if i < 0 || j < 0 || j >= len(siblings) {
+			return false
+		}
+		siblings[i], siblings[j] = siblings[j], siblings[i]
+		return true
+	})
+}
+
+// IndentSubtask nests a subtask under the sibling above it, as its last
+// subtask. It returns false for the first of its siblings.
+func (t *Task) IndentSubtask(id int) bool {
+	return t.relayoutSubtasks(func(children map[int][]int, parentOf map[int]int) bool {
+		parent := parentOf[id]
+		siblings := children[parent]
+		i := indexOf(siblings, id)
+		if i <= 0 {
+			return false
+		}
+		above := siblings[i-1]
+		children[parent] = append(siblings[:i:i], siblings[i+1:]...)
+		children[above] = append(children[above], id)
+		parentOf[id] = above
+		return true
+	})
+}
+
+// OutdentSubtask moves a nested subtask out of its parent, right after it.
+// It returns false for top-level subtasks.
+func (t *Task) OutdentSubtask(id int) bool {
+	return t.relayoutSubtasks(func(children map[int][]int, parentOf map[int]int) bool {
+		parent := parentOf[id]
+		if parent == 0 {
+			return false
+		}
+		siblings := children[parent]
+		i := indexOf(siblings, id)
+		children[parent] = append(siblings[:i:i], siblings[i+1:]...)
+
+		grandparent := parentOf[parent]
+		outer := children[grandparent]
+		j := indexOf(outer, parent) + 1
+		children[grandparent] = append(outer[:j:j], append([]int{id}, outer[j:]...)...)
+		parentOf[id] = grandparent
+		return true
+	})
+}
+
+// relayoutSubtasks lets change rearrange the IDs of the subtasks under each
+// parent (0 for the top level), then renumbers their positions and sorts the
+// checklist again. It returns the result of change.
+func (t *Task) relayoutSubtasks(change func(children map[int][]int, parentOf map[int]int) bool) bool {
+	ids := make(map[int]bool, len(t.Subtasks))
+	for _, st := range t.Subtasks {
+		ids[st.ID] = true
+	}
+
+	children := make(map[int][]int)
+	parentOf := make(map[int]int)
+	for _, st := range t.Subtasks {
+		parent := parentKey(st, ids)
+		children[parent] = append(children[parent], st.ID)
+		parentOf[st.ID] = parent
+	}
+
+	if !change(children, parentOf) {
+		return false
+	}
+
+	position := make(map[int]int)
+	for _, ids := range children {
+		for i, id := range ids {
+			position[id] = i
+		}
+	}
+	for i := range t.Subtasks {
+		st := &t.Subtasks[i]
+		st.Position = position[st.ID]
+		st.ParentID = nil
+		if parent := parentOf[st.ID]; parent != 0 {
+			st.ParentID = &parent
+		}
+	}
+	t.Subtasks = SortSubtasks(t.Subtasks)
+	return true
+}
+
+// indexOf returns the index of id in ids, -1 if missing
+func indexOf(ids []int, id int) int {
+	for i, other := range ids {
+		if other == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -34,18 +34,26 @@ func (p TaskPriority) String() string {
 }
 
 type Task struct {
-	ID          string       `json:"id"`
-	Title       string       `json:"title"`
-	Description string       `json:"description"`
-	Status      TaskStatus   `json:"status"`
-	Priority    TaskPriority `json:"priority"`
-	Category    string       `json:"category"`
-	Tags        []string     `json:"tags"`
-	Subtasks    []Subtask    `json:"subtasks"`
-	DueDate     *time.Time   `json:"due_date"`
-	CreatedAt   time.Time    `json:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at"`
-	CompletedAt *time.Time   `json:"completed_at"`
+	ID               string           `json:"id"`
+	Title            string           `json:"title"`
+	Description      string           `json:"description"`
+	Status           TaskStatus       `json:"status"`
+	Priority         TaskPriority     `json:"priority"`
+	Category         string           `json:"category"`
+	CourseID         string           `json:"course_id"` // Empty if the task is not linked to a course
+	Tags             []string         `json:"tags"`
+	Subtasks         []Subtask        `json:"subtasks"`
+	Dependencies     []TaskDependency `json:"dependencies"` // Prerequisite tasks
+	DueDate          *time.Time       `json:"due_date"`
+	RecurrenceRule   string           `json:"recurrence_rule"`   // See TaskRecurrence, empty if the task does not repeat
+	EstimatedMinutes int              `json:"estimated_minutes"` // 0 if the task has no estimate
+	TrackedSeconds   int              `json:"tracked_seconds"`   // Time of the stopped time entries
+	Pomodoros        int              `json:"pomodoros"`         // Completed Pomodoro work sessions
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+	CompletedAt      *time.Time       `json:"completed_at"`
+	ArchivedAt       *time.Time       `json:"archived_at"` // Archived tasks are hidden from the board
+	Position         int              `json:"position"`    // Order in board columns sorted manually, unique across tasks
 }
 
 func NewTask(title string) *Task {
@@ -71,6 +79,11 @@ func (t *Task) IsOverdue() bool {
 	return t.DueDate.Before(startOfToday)
 }
 
+// IsArchived returns true if the task was moved to the archive
+func (t *Task) IsArchived() bool {
+	return t.ArchivedAt != nil
+}
+
 func (t *Task) IsDueToday() bool {
 	if t.DueDate == nil {
 		return false
@@ -81,34 +94,37 @@ func (t *Task) IsDueToday() bool {
 		t.DueDate.Day() == now.Day()
 }
 
+// CompletionPercentage returns how much of the checklist is done. Nested
+// subtasks count towards their parent, see subtaskProgress.
 func (t *Task) CompletionPercentage() int {
-	if len(t.Subtasks) == 0 {
+	progress := t.topLevelProgress()
+	if len(progress) == 0 {
 		return 0
 	}
 
-	completed := 0
-	for _, st := range t.Subtasks {
-		if st.IsCompleted {
-			completed++
-		}
+	total := 0.0
+	for _, p := range progress {
+		total += p
 	}
 
-	return int(float64(completed) / float64(len(t.Subtasks)) * 100)
+	return int(total / float64(len(progress)) * 100)
 }
 
+// CompletionRatio returns the done and total top-level subtasks, e.g. (2/4)
 func (t *Task) CompletionRatio() string {
-	if len(t.Subtasks) == 0 {
+	progress := t.topLevelProgress()
+	if len(progress) == 0 {
 		return ""
 	}
 
 	completed := 0
-	for _, st := range t.Subtasks {
-		if st.IsCompleted {
+	for _, p := range progress {
+		if p == 1 {
 			completed++
 		}
 	}
 
-	return fmt.Sprintf("(%d/%d)", completed, len(t.Subtasks))
+	return fmt.Sprintf("(%d/%d)", completed, len(progress))
 }
 
 func (t *Task) GetID() string {
@@ -0,0 +1,30 @@
+package models
+
+// TaskDependency is a prerequisite of a task, a task that has to be completed
+// before the dependent task can be
+type TaskDependency struct {
+	ID     string     `json:"id"` // ID of the prerequisite task
+	Title  string     `json:"title"`
+	Status TaskStatus `json:"status"`
+}
+
+// IsDone returns true if the prerequisite no longer blocks its dependent
+func (d TaskDependency) IsDone() bool {
+	return d.Status == TaskStatusCompleted || d.Status == TaskStatusCancelled
+}
+
+// BlockedBy returns the prerequisites of the task that are not done yet
+func (t *Task) BlockedBy() []TaskDependency {
+	var blockers []TaskDependency
+	for _, dep := range t.Dependencies {
+		if !dep.IsDone() {
+			blockers = append(blockers, dep)
+		}
+	}
+	return blockers
+}
+
+// IsBlocked returns true if a prerequisite of the task is not done yet
+func (t *Task) IsBlocked() bool {
+	return len(t.BlockedBy()) > 0
+}
@@ -0,0 +1,278 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stiffis/UniCLI/internal/dateparse"
+)
+
+// Task filter fields
+const (
+	FilterFieldText     = "text"
+	FilterFieldPriority = "priority"
+	FilterFieldStatus   = "status"
+	FilterFieldTag      = "tag"
+	FilterFieldCategory = "category"
+	FilterFieldCourse   = "course"
+	FilterFieldDue      = "due"
+)
+
+// Keyword values of the due field that are not a date
+const (
+	FilterDueOverdue = "overdue"
+	FilterDueNone    = "none"
+	FilterDueAny     = "any"
+)
+
+// TaskFilterTerm is a single condition of a task filter
+type TaskFilterTerm struct {
+	Field  string
+	Op     string    // "=", "<", "<=", ">" or ">="
+	Values []string  // Alternatives, a task matches any of them
+	Time   time.Time // Resolved date of due comparisons
+	Days   bool      // Time is the start of a day and compares whole days
+	Negate bool
+}
+
+// TaskFilter is a parsed task filter query. A task must match every term.
+type TaskFilter struct {
+	Query string
+	Terms []TaskFilterTerm
+}
+
+// IsEmpty returns true if the filter has no terms
+func (f TaskFilter) IsEmpty() bool {
+	return len(f.Terms) == 0
+}
+
+// ParseTaskFilter parses a filter query such as
+//
+//	priority:high tag:math due:<7d course:"MATH 101" -status:done text
+//
+// Terms are field:value pairs or free text matched against the title and
+// description. A leading "-" negates a term and comma-separated values match
+// any of them. Relative due dates are resolved against now.
+func ParseTaskFilter(query string, now time.Time) (TaskFilter, error) {
+	filter := TaskFilter{Query: strings.TrimSpace(query)}
+
+	tokens, err := splitFilterQuery(query)
+	if err != nil {
+		return filter, err
+	}
+
+	for _, token := range tokens {
+		term, err := parseFilterTerm(token, now)
+		if err != nil {
+			return filter, err
+		}
+		filter.Terms = append(filter.Terms, term)
+	}
+
+	return filter, nil
+}
+
+// splitFilterQuery splits a query on spaces, keeping quoted values together
+func splitFilterQuery(query string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case r == ' ' && !inQuotes:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
+
+// parseFilterTerm parses a single token of a filter query
+func parseFilterTerm(token string, now time.Time) (TaskFilterTerm, error) {
+	term := TaskFilterTerm{Op: "="}
+
+	if strings.HasPrefix(token, "-") && len(token) > 1 {
+		term.Negate = true
+		token = token[1:]
+	}
+
+	field, value, found := strings.Cut(token, ":")
+	if !found || strings.HasPrefix(field, `"`) {
+		term.Field = FilterFieldText
+		term.Values = []string{strings.Trim(token, `"`)}
+		return term, nil
+	}
+
+	term.Field = strings.ToLower(field)
+	for _, op := range []string{"<=", ">=", "<", ">"} {
+		if strings.HasPrefix(value, op) {
+			term.Op = op
+			value = value[len(op):]
+			break
+		}
+	}
+
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(strings.Trim(v, `"`)); v != "" {
+			term.Values = append(term.Values, v)
+		}
+	}
+	if len(term.Values) == 0 {
+		return term, fmt.Errorf("missing value for %s", term.Field)
+	}
+
+	switch term.Field {
+	case FilterFieldPriority:
+		for i, v := range term.Values {
+			priority, ok := ParseTaskPriority(v)
+			if !ok {
+				return term, fmt.Errorf("unknown priority: %s", v)
+			}
+			term.Values[i] = string(priority)
+		}
+		if term.Op != "=" && len(term.Values) > 1 {
+			return term, fmt.Errorf("priority comparisons take a single value")
+		}
+
+	case FilterFieldStatus:
+		for i, v := range term.Values {
+			status, ok := ParseTaskStatus(v)
+			if !ok {
+				return term, fmt.Errorf("unknown status: %s", v)
+			}
+			term.Values[i] = string(status)
+		}
+		if term.Op != "=" {
+			return term, fmt.Errorf("status does not support comparisons")
+		}
+
+	case FilterFieldTag, FilterFieldCategory, FilterFieldCourse:
+		if term.Op != "=" {
+			return term, fmt.Errorf("%s does not support comparisons", term.Field)
+		}
+
+	case FilterFieldDue:
+		if len(term.Values) > 1 {
+			return term, fmt.Errorf("due takes a single value")
+		}
+		if err := resolveDueTerm(&term, now); err != nil {
+			return term, err
+		}
+
+	default:
+		return term, fmt.Errorf("unknown filter field: %s", field)
+	}
+
+	return term, nil
+}
+
+// resolveDueTerm turns the due value into a date. Keywords are kept as values.
+func resolveDueTerm(term *TaskFilterTerm, now time.Time) error {
+	value := strings.ToLower(term.Values[0])
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch value {
+	case FilterDueOverdue, FilterDueNone, FilterDueAny:
+		if term.Op != "=" {
+			return fmt.Errorf("due:%s does not support comparisons", value)
+		}
+		term.Values[0] = value
+		return nil
+	}
+
+	// Relative ranges such as 7d, 2w or 12h, anything else is a date such as
+	// today, fri or 2025-06-30
+	n, err := strconv.Atoi(value[:max(0, len(value)-1)])
+	if err != nil || n < 0 {
+		date, err := dateparse.ParseDate(value, now)
+		if err != nil {
+			return fmt.Errorf("invalid due date: %s", value)
+		}
+		term.Time, term.Days = date, true
+		return nil
+	}
+
+	switch value[len(value)-1] {
+	case 'h':
+		// Hours compare against the current time, not whole days
+		term.Time = now.Add(time.Duration(n) * time.Hour)
+		if term.Op == "=" {
+			term.Op = "<="
+		}
+		return nil
+	case 'd':
+		term.Time, term.Days = today.AddDate(0, 0, n), true
+	case 'w':
+		term.Time, term.Days = today.AddDate(0, 0, 7*n), true
+	default:
+		return fmt.Errorf("invalid due date: %s", value)
+	}
+
+	// due:7d means within the next seven days
+	if term.Op == "=" {
+		term.Op = "<"
+	}
+	return nil
+}
+
+// ParseTaskPriority parses a priority name
+func ParseTaskPriority(s string) (TaskPriority, bool) {
+	switch strings.ToLower(s) {
+	case "low":
+		return TaskPriorityLow, true
+	case "medium", "med":
+		return TaskPriorityMedium, true
+	case "high":
+		return TaskPriorityHigh, true
+	case "urgent":
+		return TaskPriorityUrgent, true
+	}
+	return "", false
+}
+
+// ParseTaskStatus parses a status name, accepting the kanban column names
+func ParseTaskStatus(s string) (TaskStatus, bool) {
+	switch strings.ToLower(s) {
+	case "pending", "todo":
+		return TaskStatusPending, true
+	case "in_progress", "progress", "doing":
+		return TaskStatusInProgress, true
+	case "completed", "done":
+		return TaskStatusCompleted, true
+	case "cancelled", "canceled":
+		return TaskStatusCancelled, true
+	}
+	return "", false
+}
+
+// Rank returns the priority as a number, higher is more important
+func (p TaskPriority) Rank() int {
+	switch p {
+	case TaskPriorityLow:
+		return 1
+	case TaskPriorityMedium:
+		return 2
+	case TaskPriorityHigh:
+		return 3
+	case TaskPriorityUrgent:
+		return 4
+	}
+	return 0
+}
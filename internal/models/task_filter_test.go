@@ -0,0 +1,147 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// filterNow is Wednesday 2025-11-12 10:30, relative due dates resolve from it
+var filterNow = at(2025, time.November, 12, 10, 30)
+
+// parseFilter parses query and fails the test if it is rejected
+func parseFilter(t *testing.T, query string) TaskFilter {
+	t.Helper()
+	filter, err := ParseTaskFilter(query, filterNow)
+	if err != nil {
+		t.Fatalf("ParseTaskFilter(%q) failed: %v", query, err)
+	}
+	return filter
+}
+
+func TestParseTaskFilterFields(t *testing.T) {
+	filter := parseFilter(t, "Priority:HIGH,med tag:math status:done essay")
+	if len(filter.Terms) != 4 {
+		t.Fatalf("got %d terms, want 4", len(filter.Terms))
+	}
+
+	priority := filter.Terms[0]
+	if priority.Field != FilterFieldPriority || strings.Join(priority.Values, ",") != "high,medium" {
+		t.Errorf("priority term: got %s %v", priority.Field, priority.Values)
+	}
+	if status := filter.Terms[2]; status.Values[0] != string(TaskStatusCompleted) {
+		t.Errorf("status:done resolved to %v", status.Values)
+	}
+	if text := filter.Terms[3]; text.Field != FilterFieldText || text.Values[0] != "essay" {
+		t.Errorf("free text term: got %s %v", text.Field, text.Values)
+	}
+
+	if !parseFilter(t, "   ").IsEmpty() {
+		t.Error("a blank query should be an empty filter")
+	}
+}
+
+func TestParseTaskFilterQuotedValues(t *testing.T) {
+	filter := parseFilter(t, `course:"MATH 101" "final exam" category:"lab, report"`)
+	if len(filter.Terms) != 3 {
+		t.Fatalf("got %d terms, want 3", len(filter.Terms))
+	}
+
+	if course := filter.Terms[0]; course.Field != FilterFieldCourse || len(course.Values) != 1 || course.Values[0] != "MATH 101" {
+		t.Errorf("course term: got %s %q", course.Field, course.Values)
+	}
+	if text := filter.Terms[1]; text.Field != FilterFieldText || text.Values[0] != "final exam" {
+		t.Errorf("quoted text term: got %s %q", text.Field, text.Values)
+	}
+	// Commas still separate alternatives inside quotes
+	if category := filter.Terms[2]; strings.Join(category.Values, "|") != "lab|report" {
+		t.Errorf("category term: got %q", category.Values)
+	}
+
+	// A quoted colon is text, not a field
+	if text := parseFilter(t, `"note: bring id"`).Terms[0]; text.Field != FilterFieldText || text.Values[0] != "note: bring id" {
+		t.Errorf("quoted colon: got %s %q", text.Field, text.Values)
+	}
+}
+
+func TestParseTaskFilterNegation(t *testing.T) {
+	filter := parseFilter(t, `-status:done,cancelled -tag:"group work" -draft -`)
+
+	status := filter.Terms[0]
+	if !status.Negate || status.Field != FilterFieldStatus || len(status.Values) != 2 {
+		t.Errorf("-status: got negate %v, %s %v", status.Negate, status.Field, status.Values)
+	}
+	if tag := filter.Terms[1]; !tag.Negate || tag.Values[0] != "group work" {
+		t.Errorf("-tag: got negate %v, %q", tag.Negate, tag.Values)
+	}
+	if text := filter.Terms[2]; !text.Negate || text.Field != FilterFieldText || text.Values[0] != "draft" {
+		t.Errorf("-draft: got negate %v, %s %q", text.Negate, text.Field, text.Values)
+	}
+	// A lone dash is searched for as text
+	if dash := filter.Terms[3]; dash.Negate || dash.Values[0] != "-" {
+		t.Errorf("lone dash: got negate %v, %q", dash.Negate, dash.Values)
+	}
+}
+
+func TestParseTaskFilterRelativeDue(t *testing.T) {
+	// due:<7d is before the start of the day a week from today
+	within := parseFilter(t, "due:<7d").Terms[0]
+	if within.Op != "<" || !within.Days || !within.Time.Equal(at(2025, time.November, 19, 0, 0)) {
+		t.Errorf("due:<7d: got %s %v days %v", within.Op, within.Time, within.Days)
+	}
+
+	later := parseFilter(t, "due:>2w").Terms[0]
+	if later.Op != ">" || !later.Time.Equal(at(2025, time.November, 26, 0, 0)) {
+		t.Errorf("due:>2w: got %s %v", later.Op, later.Time)
+	}
+
+	// Without an operator a range means within it
+	if soon := parseFilter(t, "due:3d").Terms[0]; soon.Op != "<" {
+		t.Errorf("due:3d: got op %s, want <", soon.Op)
+	}
+
+	// Hours compare against the current time
+	hours := parseFilter(t, "due:12h").Terms[0]
+	if hours.Op != "<=" || hours.Days || !hours.Time.Equal(at(2025, time.November, 12, 22, 30)) {
+		t.Errorf("due:12h: got %s %v days %v", hours.Op, hours.Time, hours.Days)
+	}
+}
+
+func TestParseTaskFilterAbsoluteDue(t *testing.T) {
+	exact := parseFilter(t, "due:2025-12-01").Terms[0]
+	if exact.Op != "=" || !exact.Days || !exact.Time.Equal(at(2025, time.December, 1, 0, 0)) {
+		t.Errorf("due:2025-12-01: got %s %v days %v", exact.Op, exact.Time, exact.Days)
+	}
+
+	if by := parseFilter(t, "due:<=fri").Terms[0]; by.Op != "<=" || !by.Time.Equal(at(2025, time.November, 14, 0, 0)) {
+		t.Errorf("due:<=fri: got %s %v", by.Op, by.Time)
+	}
+
+	// Keywords are kept as values
+	if overdue := parseFilter(t, "due:Overdue").Terms[0]; overdue.Values[0] != FilterDueOverdue || !overdue.Time.IsZero() {
+		t.Errorf("due:overdue: got %q %v", overdue.Values, overdue.Time)
+	}
+}
+
+func TestParseTaskFilterRejects(t *testing.T) {
+	rejected := map[string]string{
+		"owner:me":            "unknown key",
+		"course:\"MATH 101":   "unterminated quote",
+		"essay \"final draft": "unterminated quote in text",
+		"tag:":                "missing value",
+		"priority:highest":    "unknown priority",
+		"priority:>low,high":  "priority comparison with several values",
+		"status:>done":        "status comparison",
+		"tag:<math":           "tag comparison",
+		"due:someday":         "unknown due date",
+		"due:<overdue":        "keyword comparison",
+		"due:7d,2w":           "several due dates",
+		"due:3x":              "unknown range unit",
+	}
+
+	for query, reason := range rejected {
+		if _, err := ParseTaskFilter(query, filterNow); err == nil {
+			t.Errorf("ParseTaskFilter(%q) should fail: %s", query, reason)
+		}
+	}
+}
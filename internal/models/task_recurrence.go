@@ -0,0 +1,275 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Task recurrence kinds
+const (
+	TaskRepeatDaily   = "daily"
+	TaskRepeatWeekly  = "weekly"
+	TaskRepeatMonthly = "monthly"
+	TaskRepeatEvery   = "every" // Every N days after completion
+)
+
+// TaskRecurrence is a parsed task recurrence rule. Rules are stored as text:
+//
+//	daily
+//	weekly            (on the weekday of the due date)
+//	weekly mon,thu
+//	monthly           (on the day of the due date)
+//	monthly 31        (on the 31st, or the last day of shorter months)
+//	every 3d          (three days after the task is completed)
+type TaskRecurrence struct {
+	Kind     string
+	Weekdays []time.Weekday // Weekly only, empty repeats on the due date's weekday
+	Day      int            // Monthly only, the day of the month, 0 repeats on the due date's day
+	Days     int            // Days after completion of the "every" kind
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseTaskRecurrence parses a recurrence rule. An empty rule or "none" is a
+// task that does not repeat and returns a recurrence with an empty kind.
+func ParseTaskRecurrence(rule string) (TaskRecurrence, error) {
+	fields := strings.Fields(strings.ToLower(rule))
+	if len(fields) == 0 || (len(fields) == 1 && fields[0] == "none") {
+		return TaskRecurrence{}, nil
+	}
+
+	kind, args := fields[0], strings.Join(fields[1:], "")
+	switch kind {
+	case TaskRepeatDaily:
+		if args != "" {
+			return TaskRecurrence{}, fmt.Errorf("%s takes no arguments", kind)
+		}
+		return TaskRecurrence{Kind: kind}, nil
+
+	case TaskRepeatMonthly:
+		recurrence := TaskRecurrence{Kind: kind}
+		if args != "" {
+			day, err := strconv.Atoi(args)
+			if err != nil || day < 1 || day > 31 {
+				return TaskRecurrence{}, fmt.Errorf("invalid day of the month: %s", args)
+			}
+			recurrence.Day = day
+		}
+		return recurrence, nil
+
+	case TaskRepeatWeekly:
+		recurrence := TaskRecurrence{Kind: kind}
+		seen := make(map[time.Weekday]bool)
+		for _, name := range strings.Split(args, ",") {
+			if name == "" {
+				continue
+			}
+			day, ok := weekdayNames[name]
+			if !ok {
+				return TaskRecurrence{}, fmt.Errorf("unknown weekday: %s", name)
+			}
+			if !seen[day] {
+				seen[day] = true
+				recurrence.Weekdays = append(recurrence.Weekdays, day)
+			}
+		}
+		return recurrence, nil
+
+	case TaskRepeatEvery:
+		// "every 3d", "every 3 days" or "every day"
+		args = strings.TrimSuffix(strings.TrimSuffix(args, "s"), "day")
+		args = strings.TrimSuffix(args, "d")
+		days := 1
+		if args != "" {
+			n, err := strconv.Atoi(args)
+			if err != nil || n < 1 {
+				return TaskRecurrence{}, fmt.Errorf("invalid interval: %s", rule)
+			}
+			days = n
+		}
+		return TaskRecurrence{Kind: kind, Days: days}, nil
+	}
+
+	return TaskRecurrence{}, fmt.Errorf("unknown recurrence: %s (use daily, weekly mon,wed, monthly or every 3d)", fields[0])
+}
+
+// IsEmpty returns true if the recurrence does not repeat
+func (r TaskRecurrence) IsEmpty() bool {
+	return r.Kind == ""
+}
+
+// String returns the rule in its stored form
+func (r TaskRecurrence) String() string {
+	switch r.Kind {
+	case TaskRepeatWeekly:
+		if len(r.Weekdays) == 0 {
+			return TaskRepeatWeekly
+		}
+		return TaskRepeatWeekly + " " + strings.ToLower(r.weekdayList())
+	case TaskRepeatMonthly:
+		if r.Day == 0 {
+			return TaskRepeatMonthly
+		}
+		return fmt.Sprintf("%s %d", TaskRepeatMonthly, r.Day)
+	case TaskRepeatEvery:
+		return fmt.Sprintf("%s %dd", TaskRepeatEvery, r.Days)
+	}
+	return r.Kind
+}
+
+// Describe returns a human readable description of the recurrence
+func (r TaskRecurrence) Describe() string {
+	switch r.Kind {
+	case TaskRepeatDaily:
+		return "Every day"
+	case TaskRepeatWeekly:
+		if len(r.Weekdays) == 0 {
+			return "Every week"
+		}
+		return "Every week on " + r.weekdayList()
+	case TaskRepeatMonthly:
+		if r.Day == 0 {
+			return "Every month"
+		}
+		return fmt.Sprintf("Every month on day %d", r.Day)
+	case TaskRepeatEvery:
+		if r.Days == 1 {
+			return "1 day after completion"
+		}
+		return fmt.Sprintf("%d days after completion", r.Days)
+	}
+	return "Does not repeat"
+}
+
+// weekdayList returns the weekdays in week order, e.g. "Mon,Thu"
+func (r TaskRecurrence) weekdayList() string {
+	var names []string
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		for _, d := range r.Weekdays {
+			if d == day {
+				names = append(names, day.String()[:3])
+			}
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// NextDue returns the due date of the instance following one due on due and
+// completed at completedAt. Calendar rules advance from the due date, skipping
+// occurrences that are past by the completion time; "every" counts from the
+// completion day.
+func (r TaskRecurrence) NextDue(due *time.Time, completedAt time.Time) time.Time {
+	completedDay := time.Date(completedAt.Year(), completedAt.Month(), completedAt.Day(), 0, 0, 0, 0, completedAt.Location())
+
+	base := completedDay
+	if due != nil {
+		base = *due
+	}
+
+	if r.Kind == TaskRepeatEvery {
+		// Keep the time of day of the due date
+		return time.Date(completedDay.Year(), completedDay.Month(), completedDay.Day()+r.Days,
+			base.Hour(), base.Minute(), base.Second(), 0, base.Location())
+	}
+
+	next := r.advance(base)
+	for !next.After(completedAt) {
+		next = r.advance(next)
+	}
+	return next
+}
+
+// advance returns the occurrence following t
+func (r TaskRecurrence) advance(t time.Time) time.Time {
+	switch r.Kind {
+	case TaskRepeatDaily:
+		return t.AddDate(0, 0, 1)
+
+	case TaskRepeatWeekly:
+		if len(r.Weekdays) == 0 {
+			return t.AddDate(0, 0, 7)
+		}
+		for i := 1; i <= 7; i++ {
+			next := t.AddDate(0, 0, i)
+			for _, day := range r.Weekdays {
+				if next.Weekday() == day {
+					return next
+				}
+			}
+		}
+
+	case TaskRepeatMonthly:
+		// Clamp to the last day of shorter months instead of overflowing, the
+		// day is kept for the months after
+		day := r.Day
+		if day == 0 {
+			day = t.Day()
+		}
+		firstOfNext := time.Date(t.Year(), t.Month()+1, 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+		lastDay := firstOfNext.AddDate(0, 1, -1).Day()
+		return time.Date(firstOfNext.Year(), firstOfNext.Month(), min(day, lastDay),
+			t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	}
+
+	return t.AddDate(0, 0, 1)
+}
+
+// IsRecurring returns true if the task repeats
+func (t *Task) IsRecurring() bool {
+	recurrence, err := ParseTaskRecurrence(t.RecurrenceRule)
+	return err == nil && !recurrence.IsEmpty()
+}
+
+// NextOccurrence builds the instance that follows the task when it is completed
+// at completedAt. The new instance takes over the recurrence and gets a fresh
+// copy of the checklist with every subtask reset.
+func (t *Task) NextOccurrence(completedAt time.Time) (*Task, error) {
+	recurrence, err := ParseTaskRecurrence(t.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+	if recurrence.IsEmpty() {
+		return nil, fmt.Errorf("task does not repeat: %s", t.ID)
+	}
+	// A monthly task keeps the day of its first due date, which a short month
+	// would otherwise move to an earlier day for good
+	if recurrence.Kind == TaskRepeatMonthly && recurrence.Day == 0 && t.DueDate != nil {
+		recurrence.Day = t.DueDate.Day()
+	}
+
+	next := NewTask(t.Title)
+	next.Description = t.Description
+	next.Priority = t.Priority
+	next.Category = t.Category
+	next.CourseID = t.CourseID
+	next.EstimatedMinutes = t.EstimatedMinutes
+	next.Tags = append([]string{}, t.Tags...)
+	next.RecurrenceRule = recurrence.String()
+
+	due := recurrence.NextDue(t.DueDate, completedAt)
+	next.DueDate = &due
+
+	for _, st := range t.Subtasks {
+		// The IDs keep the nesting until the copies are saved
+		next.Subtasks = append(next.Subtasks, Subtask{
+			ID:       st.ID,
+			TaskID:   next.ID,
+			ParentID: st.ParentID,
+			Title:    st.Title,
+			Position: st.Position,
+			Depth:    st.Depth,
+		})
+	}
+
+	return next, nil
+}
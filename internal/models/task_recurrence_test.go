@@ -0,0 +1,132 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func at(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+// nextDue returns the due date that follows due under rule when the task is
+// completed at completedAt
+func nextDue(t *testing.T, rule string, due *time.Time, completedAt time.Time) time.Time {
+	t.Helper()
+	recurrence, err := ParseTaskRecurrence(rule)
+	if err != nil {
+		t.Fatalf("ParseTaskRecurrence(%q) failed: %v", rule, err)
+	}
+	return recurrence.NextDue(due, completedAt)
+}
+
+func TestNextDueDaily(t *testing.T) {
+	due := at(2025, time.November, 10, 9, 0)
+	if got, want := nextDue(t, "daily", &due, at(2025, time.November, 10, 15, 0)), at(2025, time.November, 11, 9, 0); !got.Equal(want) {
+		t.Errorf("completed on the day: got %v, want %v", got, want)
+	}
+
+	// Completing ahead of time still moves on from the due date
+	due = at(2025, time.November, 14, 9, 0)
+	if got, want := nextDue(t, "daily", &due, at(2025, time.November, 10, 15, 0)), at(2025, time.November, 15, 9, 0); !got.Equal(want) {
+		t.Errorf("completed early: got %v, want %v", got, want)
+	}
+
+	// Without a due date the next instance is due tomorrow
+	if got, want := nextDue(t, "daily", nil, at(2025, time.November, 12, 15, 0)), at(2025, time.November, 13, 0, 0); !got.Equal(want) {
+		t.Errorf("no due date: got %v, want %v", got, want)
+	}
+}
+
+func TestNextDueWeekly(t *testing.T) {
+	// On the weekday of the due date
+	due := at(2025, time.November, 12, 23, 59)
+	if got, want := nextDue(t, "weekly", &due, at(2025, time.November, 12, 20, 0)), at(2025, time.November, 19, 23, 59); !got.Equal(want) {
+		t.Errorf("weekly: got %v, want %v", got, want)
+	}
+
+	// On the listed weekdays, wrapping into the next week
+	monday, thursday := at(2025, time.November, 10, 8, 0), at(2025, time.November, 13, 8, 0)
+	if got := nextDue(t, "weekly mon,thu", &monday, monday); !got.Equal(thursday) {
+		t.Errorf("weekly mon,thu from Monday: got %v, want %v", got, thursday)
+	}
+	if got, want := nextDue(t, "weekly mon,thu", &thursday, thursday), at(2025, time.November, 17, 8, 0); !got.Equal(want) {
+		t.Errorf("weekly mon,thu from Thursday: got %v, want %v", got, want)
+	}
+}
+
+func TestNextDueMonthly(t *testing.T) {
+	due := at(2025, time.December, 15, 12, 0)
+	if got, want := nextDue(t, "monthly", &due, due), at(2026, time.January, 15, 12, 0); !got.Equal(want) {
+		t.Errorf("into next year: got %v, want %v", got, want)
+	}
+
+	// The 31st is clamped to the end of shorter months
+	due = at(2025, time.January, 31, 0, 0)
+	if got, want := nextDue(t, "monthly", &due, due), at(2025, time.February, 28, 0, 0); !got.Equal(want) {
+		t.Errorf("into February: got %v, want %v", got, want)
+	}
+	due = at(2024, time.January, 31, 0, 0)
+	if got, want := nextDue(t, "monthly", &due, due), at(2024, time.February, 29, 0, 0); !got.Equal(want) {
+		t.Errorf("into February of a leap year: got %v, want %v", got, want)
+	}
+
+	// After February the day of the month goes back to the 31st
+	due = at(2025, time.February, 28, 0, 0)
+	if got, want := nextDue(t, "monthly 31", &due, due), at(2025, time.March, 31, 0, 0); !got.Equal(want) {
+		t.Errorf("monthly 31 into March: got %v, want %v", got, want)
+	}
+}
+
+func TestNextOccurrenceKeepsTheMonthlyDay(t *testing.T) {
+	task := NewTask("Rent")
+	task.RecurrenceRule = "monthly"
+	due := at(2025, time.January, 31, 9, 0)
+	task.DueDate = &due
+
+	var dues []string
+	for i := 0; i < 3; i++ {
+		next, err := task.NextOccurrence(*task.DueDate)
+		if err != nil {
+			t.Fatalf("NextOccurrence failed: %v", err)
+		}
+		dues = append(dues, next.DueDate.Format("Jan 02"))
+		task = next
+	}
+
+	if got, want := strings.Join(dues, ", "), "Feb 28, Mar 31, Apr 30"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if task.RecurrenceRule != "monthly 31" {
+		t.Errorf("rule of the instances: got %q, want %q", task.RecurrenceRule, "monthly 31")
+	}
+}
+
+func TestNextDueEvery(t *testing.T) {
+	// Counted from the completion day, keeping the time of the due date
+	due := at(2025, time.November, 10, 18, 0)
+	if got, want := nextDue(t, "every 3d", &due, at(2025, time.November, 12, 8, 0)), at(2025, time.November, 15, 18, 0); !got.Equal(want) {
+		t.Errorf("every 3d: got %v, want %v", got, want)
+	}
+	if got, want := nextDue(t, "every 2 days", nil, at(2025, time.November, 12, 14, 0)), at(2025, time.November, 14, 0, 0); !got.Equal(want) {
+		t.Errorf("every 2 days without a due date: got %v, want %v", got, want)
+	}
+}
+
+func TestNextDueSkipsMissedOccurrences(t *testing.T) {
+	// Completing late does not create instances that are already past
+	due := at(2025, time.November, 5, 9, 0)
+	if got, want := nextDue(t, "daily", &due, at(2025, time.November, 12, 8, 0)), at(2025, time.November, 12, 9, 0); !got.Equal(want) {
+		t.Errorf("daily before the time of day: got %v, want %v", got, want)
+	}
+	due = at(2025, time.November, 9, 9, 0)
+	if got, want := nextDue(t, "daily", &due, at(2025, time.November, 12, 17, 0)), at(2025, time.November, 13, 9, 0); !got.Equal(want) {
+		t.Errorf("daily after the time of day: got %v, want %v", got, want)
+	}
+
+	due = at(2025, time.October, 29, 23, 59)
+	if got, want := nextDue(t, "weekly", &due, at(2025, time.November, 12, 20, 0)), at(2025, time.November, 12, 23, 59); !got.Equal(want) {
+		t.Errorf("weekly: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// TaskSortMode is how the tasks of a board column are ordered
+type TaskSortMode string
+
+const (
+	TaskSortPriority TaskSortMode = "priority" // Overdue first, then by priority, due date and age
+	TaskSortDue      TaskSortMode = "due"      // Soonest due date first, tasks without one last
+	TaskSortCreated  TaskSortMode = "created"  // Newest first
+	TaskSortUpdated  TaskSortMode = "updated"  // Most recently changed first
+	TaskSortManual   TaskSortMode = "manual"   // The order the tasks were arranged in
+)
+
+// taskSortModes are the sort modes in the order they are cycled through
+var taskSortModes = []TaskSortMode{
+	TaskSortPriority,
+	TaskSortDue,
+	TaskSortCreated,
+	TaskSortUpdated,
+	TaskSortManual,
+}
+
+// ParseTaskSortMode parses a sort mode name
+func ParseTaskSortMode(s string) (TaskSortMode, bool) {
+	for _, mode := range taskSortModes {
+		if strings.EqualFold(s, string(mode)) {
+			return mode, true
+		}
+	}
+	return "", false
+}
+
+// Next returns the sort mode after m, wrapping around
+func (m TaskSortMode) Next() TaskSortMode {
+	for i, mode := range taskSortModes {
+		if mode == m {
+			return taskSortModes[(i+1)%len(taskSortModes)]
+		}
+	}
+	return TaskSortPriority
+}
+
+// Label returns the sort mode as shown on the board
+func (m TaskSortMode) Label() string {
+	switch m {
+	case TaskSortDue:
+		return "due date"
+	case TaskSortCreated:
+		return "newest"
+	case TaskSortUpdated:
+		return "last update"
+	case TaskSortManual:
+		return "manual"
+	}
+	return "priority"
+}
+
+// SortTasks orders tasks in place by the sort mode. Ties keep the order of
+// the priority mode.
+func SortTasks(tasks []Task, mode TaskSortMode) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := &tasks[i], &tasks[j]
+		switch mode {
+		case TaskSortDue:
+			if less, ok := compareDue(a, b); ok {
+				return less
+			}
+		case TaskSortCreated:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		case TaskSortUpdated:
+			if !a.UpdatedAt.Equal(b.UpdatedAt) {
+				return a.UpdatedAt.After(b.UpdatedAt)
+			}
+		case TaskSortManual:
+			if a.Position != b.Position {
+				return a.Position < b.Position
+			}
+		}
+		return priorityLess(a, b)
+	})
+}
+
+// priorityLess orders overdue tasks first, then by priority, due date and
+// creation date
+func priorityLess(a, b *Task) bool {
+	if aOverdue := a.IsOverdue(); aOverdue != b.IsOverdue() {
+		return aOverdue
+	}
+	if a.Priority.Rank() != b.Priority.Rank() {
+		return a.Priority.Rank() > b.Priority.Rank()
+	}
+	if less, ok := compareDue(a, b); ok {
+		return less
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
+// compareDue orders the sooner due date first and tasks without one last. It
+// returns false if the due dates are the same.
+func compareDue(a, b *Task) (bool, bool) {
+	switch {
+	case a.DueDate == nil && b.DueDate == nil:
+		return false, false
+	case a.DueDate == nil || b.DueDate == nil:
+		return a.DueDate != nil, true
+	case a.DueDate.Equal(*b.DueDate):
+		return false, false
+	}
+	return a.DueDate.Before(*b.DueDate), true
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// sortedIDs sorts a copy of tasks by mode and returns their IDs separated by
+// spaces
+func sortedIDs(tasks []Task, mode TaskSortMode) string {
+	sorted := append([]Task{}, tasks...)
+	SortTasks(sorted, mode)
+
+	var ids []string
+	for _, task := range sorted {
+		ids = append(ids, task.ID)
+	}
+	return strings.Join(ids, " ")
+}
+
+// boardTasks returns tasks with distinct priorities, due dates, creation and
+// update times and positions. Due dates are relative to today, which decides
+// what is overdue.
+func boardTasks() []Task {
+	now := time.Now()
+	noon := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, now.Location())
+	dueIn := func(days int) *time.Time {
+		due := noon.AddDate(0, 0, days)
+		return &due
+	}
+	day := func(n int) time.Time {
+		return time.Date(2025, time.September, n, 9, 0, 0, 0, time.UTC)
+	}
+
+	return []Task{
+		{ID: "high-none", Priority: TaskPriorityHigh, CreatedAt: day(5), UpdatedAt: day(22), Position: 2},
+		{ID: "low-none", Priority: TaskPriorityLow, CreatedAt: day(6), UpdatedAt: day(23), Position: 5},
+		{ID: "high-later", Priority: TaskPriorityHigh, DueDate: dueIn(3), CreatedAt: day(4), UpdatedAt: day(20), Position: 1},
+		{ID: "late", Priority: TaskPriorityLow, DueDate: dueIn(-2), CreatedAt: day(1), UpdatedAt: day(25), Position: 3},
+		{ID: "urgent", Priority: TaskPriorityUrgent, DueDate: dueIn(5), CreatedAt: day(2), UpdatedAt: day(21), Position: 0},
+		{ID: "high-soon", Priority: TaskPriorityHigh, DueDate: dueIn(1), CreatedAt: day(3), UpdatedAt: day(24), Position: 4},
+	}
+}
+
+func TestSortTasksByPriority(t *testing.T) {
+	// Overdue first, then by priority and due date, tasks without one last
+	want := "late urgent high-soon high-later high-none low-none"
+	if got := sortedIDs(boardTasks(), TaskSortPriority); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestSortTasksByDueDate(t *testing.T) {
+	want := "late high-soon high-later urgent high-none low-none"
+	if got := sortedIDs(boardTasks(), TaskSortDue); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestSortTasksByTime(t *testing.T) {
+	if got, want := sortedIDs(boardTasks(), TaskSortCreated), "low-none high-none high-later high-soon urgent late"; got != want {
+		t.Errorf("newest first: got %s, want %s", got, want)
+	}
+	if got, want := sortedIDs(boardTasks(), TaskSortUpdated), "late high-soon low-none high-none urgent high-later"; got != want {
+		t.Errorf("last update first: got %s, want %s", got, want)
+	}
+}
+
+func TestSortTasksManually(t *testing.T) {
+	want := "urgent high-later high-none late high-soon low-none"
+	if got := sortedIDs(boardTasks(), TaskSortManual); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestSortTasksTiesKeepPriorityOrder(t *testing.T) {
+	created := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+	tasks := []Task{
+		{ID: "low", Priority: TaskPriorityLow, CreatedAt: created, UpdatedAt: created},
+		{ID: "medium-new", Priority: TaskPriorityMedium, CreatedAt: created.Add(time.Hour), UpdatedAt: created},
+		{ID: "urgent", Priority: TaskPriorityUrgent, CreatedAt: created, UpdatedAt: created},
+		{ID: "medium-old", Priority: TaskPriorityMedium, CreatedAt: created.Add(-time.Hour), UpdatedAt: created},
+	}
+
+	// No due dates, the same update time and position
+	want := "urgent medium-old medium-new low"
+	for _, mode := range []TaskSortMode{TaskSortDue, TaskSortUpdated, TaskSortManual} {
+		if got := sortedIDs(tasks, mode); got != want {
+			t.Errorf("%s: got %s, want %s", mode, got, want)
+		}
+	}
+}
+
+func TestTaskSortModeNextWrapsAround(t *testing.T) {
+	if got := TaskSortManual.Next(); got != TaskSortPriority {
+		t.Errorf("after manual: got %s, want %s", got, TaskSortPriority)
+	}
+	if got := TaskSortMode("bogus").Next(); got != TaskSortPriority {
+		t.Errorf("after an unknown mode: got %s, want %s", got, TaskSortPriority)
+	}
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stiffis/UniCLI/internal/dateparse"
+)
+
+// TaskTemplate is a named blueprint for tasks that come back with the same
+// checklist, such as lab reports
+type TaskTemplate struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	TitlePattern string       `json:"title_pattern"` // Title of created tasks, see ExpandTitle
+	Priority     TaskPriority `json:"priority"`
+	Tags         []string     `json:"tags"`
+	DueIn        string       `json:"due_in"`   // Due date relative to the creation, e.g. +7d or fri 23:59, empty for none
+	Subtasks     []string     `json:"subtasks"` // Checklist of created tasks, in order
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// NewTaskTemplate creates a new task template
+func NewTaskTemplate(name, titlePattern string) *TaskTemplate {
+	return &TaskTemplate{
+		ID:           uuid.New().String(),
+		Name:         name,
+		TitlePattern: titlePattern,
+		Priority:     TaskPriorityMedium,
+		Tags:         []string{},
+		Subtasks:     []string{},
+		CreatedAt:    time.Now(),
+	}
+}
+
+// Validate checks that the template has a name and a title and that its
+// priority and due date are understood
+func (t *TaskTemplate) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("template name is required")
+	}
+	if strings.TrimSpace(t.TitlePattern) == "" {
+		return fmt.Errorf("title pattern is required")
+	}
+	if _, ok := ParseTaskPriority(string(t.Priority)); !ok {
+		return fmt.Errorf("unknown priority: %s", t.Priority)
+	}
+	if _, err := t.DueDate(time.Now()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DueDate resolves the due date of a task created from the template at now,
+// nil if the template sets none
+func (t *TaskTemplate) DueDate(now time.Time) (*time.Time, error) {
+	if strings.TrimSpace(t.DueIn) == "" {
+		return nil, nil
+	}
+	result, err := dateparse.Parse(t.DueIn, now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid due date: %w", err)
+	}
+	due := result.Time
+	return &due, nil
+}
+
+// ExpandTitle fills in the placeholders of the title pattern:
+//
+//	{date}  the creation date, e.g. 2025-06-30
+//	{week}  the ISO week number of the creation date
+//	{due}   the due date, e.g. Jun 30, empty without a due date
+func (t *TaskTemplate) ExpandTitle(now time.Time, due *time.Time) string {
+	dueText := ""
+	if due != nil {
+		dueText = due.Format("Jan 2")
+	}
+	_, week := now.ISOWeek()
+
+	return strings.TrimSpace(strings.NewReplacer(
+		"{date}", now.Format("2006-01-02"),
+		"{week}", strconv.Itoa(week),
+		"{due}", dueText,
+	).Replace(t.TitlePattern))
+}
+
+// Instantiate creates a pending task from the template at now, with the
+// checklist unchecked
+func (t *TaskTemplate) Instantiate(now time.Time) (*Task, error) {
+	due, err := t.DueDate(now)
+	if err != nil {
+		return nil, err
+	}
+
+	task := NewTask(t.ExpandTitle(now, due))
+	task.Priority = t.Priority
+	task.Tags = append([]string{}, t.Tags...)
+	task.DueDate = due
+	for _, title := range t.Subtasks {
+		task.Subtasks = append(task.Subtasks, Subtask{
+			TaskID: task.ID,
+			Title:  title,
+		})
+	}
+
+	return task, nil
+}
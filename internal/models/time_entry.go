@@ -0,0 +1,94 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimeEntry is a span of time tracked on a task. A running entry has no end.
+type TimeEntry struct {
+	ID              string     `json:"id"`
+	TaskID          string     `json:"task_id"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at"`
+	DurationSeconds int        `json:"duration_seconds"` // Set when the entry is stopped
+}
+
+// NewTimeEntry starts a new time entry for a task
+func NewTimeEntry(taskID string) *TimeEntry {
+	return &TimeEntry{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		StartedAt: time.Now(),
+	}
+}
+
+// IsRunning returns true if the entry has not been stopped
+func (e *TimeEntry) IsRunning() bool {
+	return e.EndedAt == nil
+}
+
+// Elapsed returns the tracked time, up to now for a running entry
+func (e *TimeEntry) Elapsed() time.Duration {
+	if e.IsRunning() {
+		return time.Since(e.StartedAt)
+	}
+	return time.Duration(e.DurationSeconds) * time.Second
+}
+
+// TimeComparison compares estimated and tracked time of a group of tasks
+type TimeComparison struct {
+	Label            string `json:"label"` // Course code or tag name
+	Tasks            int    `json:"tasks"`
+	EstimatedMinutes int    `json:"estimated_minutes"`
+	TrackedSeconds   int    `json:"tracked_seconds"`
+}
+
+// Ratio returns the tracked time as a percentage of the estimate, 0 without an estimate
+func (c TimeComparison) Ratio() int {
+	if c.EstimatedMinutes == 0 {
+		return 0
+	}
+	return c.TrackedSeconds * 100 / (c.EstimatedMinutes * 60)
+}
+
+// FormatMinutes formats a number of minutes as e.g. "1h 30m"
+func FormatMinutes(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+}
+
+// ParseMinutes parses a duration such as "90", "45m", "2h" or "1h 30m" into
+// minutes. A bare number is a number of minutes.
+func ParseMinutes(s string) (int, error) {
+	s = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), " ", "")
+	if s == "" {
+		return 0, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return n, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration: %s (use e.g. 90, 45m or 1h30m)", s)
+	}
+	return int(d.Round(time.Minute) / time.Minute), nil
+}
+
+// TrackedTime returns the time tracked on the task, including a running entry
+func (t *Task) TrackedTime(running *TimeEntry) time.Duration {
+	tracked := time.Duration(t.TrackedSeconds) * time.Second
+	if running != nil && running.TaskID == t.ID {
+		tracked += running.Elapsed()
+	}
+	return tracked
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// TrashItem is a deleted task, event, course or note kept in the trash
+type TrashItem struct {
+	Kind      SearchKind `json:"kind"`
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Course    string     `json:"course"` // Course of a course note
+	DeletedAt time.Time  `json:"deleted_at"`
+}
+
+// ExpiresAt returns when the item is purged for the given retention, the zero
+// time if items are kept forever
+func (i TrashItem) ExpiresAt(retention time.Duration) time.Time {
+	if retention <= 0 {
+		return time.Time{}
+	}
+	return i.DeletedAt.Add(retention)
+}
@@ -8,17 +8,19 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/stiffis/UniCLI/internal/database"
 	"github.com/stiffis/UniCLI/internal/models"
 	"github.com/stiffis/UniCLI/internal/ui/styles"
 )
 
-// FormSubmitMsg is sent when a form is successfully submitted
-type FormSubmitMsg struct{}
+// FormSubmitMsg is sent when a form is successfully submitted, with the
+// course to save
+type FormSubmitMsg struct {
+	Course *models.Course
+	IsEdit bool // Course is an existing course, otherwise a new one
+}
 
 // CourseForm represents the course creation/edit form
 type CourseForm struct {
-	db            *database.DB
 	course        *models.Course
 	inputs        []textinput.Model
 	focusedInput  int
@@ -27,6 +29,7 @@ type CourseForm struct {
 	height        int
 	err           string
 	scheduleInput string // Temporary storage for schedule input
+	description   string // Full description, the input only shows it on one line
 }
 
 const (
@@ -42,7 +45,7 @@ const (
 )
 
 // NewCourseForm creates a new course form
-func NewCourseForm(db *database.DB, course *models.Course) *CourseForm {
+func NewCourseForm(course *models.Course) *CourseForm {
 	inputs := make([]textinput.Model, 9)
 
 	// Name
@@ -92,8 +95,10 @@ func NewCourseForm(db *database.DB, course *models.Course) *CourseForm {
 	inputs[courseInputDescription].Width = 50
 
 	isEdit := course != nil
+	description := ""
 
 	if isEdit {
+		description = course.Description
 		inputs[courseInputName].SetValue(course.Name)
 		inputs[courseInputCode].SetValue(course.Code)
 		inputs[courseInputProfessor].SetValue(course.Professor)
@@ -112,11 +117,11 @@ func NewCourseForm(db *database.DB, course *models.Course) *CourseForm {
 	}
 
 	return &CourseForm{
-		db:           db,
 		course:       course,
 		inputs:       inputs,
 		focusedInput: 0,
 		isEdit:       isEdit,
+		description:  description,
 	}
 }
 
@@ -133,6 +138,16 @@ func (f *CourseForm) Update(msg tea.Msg) (*CourseForm, tea.Cmd) {
 		f.width = msg.Width
 		f.height = msg.Height
 
+	case EditorFinishedMsg:
+		if msg.Err != nil {
+			f.err = msg.Err.Error()
+		} else {
+			f.err = ""
+			f.description = msg.Content
+			f.inputs[courseInputDescription].SetValue(msg.Content)
+		}
+		return f, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c":
@@ -152,6 +167,12 @@ func (f *CourseForm) Update(msg tea.Msg) (*CourseForm, tea.Cmd) {
 		case "ctrl+s":
 			// Submit form
 			return f, f.submitForm()
+		case "ctrl+e":
+			// Edit the description in the external editor
+			f.inputs[f.focusedInput].Blur()
+			f.focusedInput = courseInputDescription
+			f.inputs[f.focusedInput].Focus()
+			return f, OpenEditor(f.currentDescription())
 		}
 	}
 
@@ -246,7 +267,7 @@ func (f *CourseForm) View() string {
 	// Help text
 	help := lipgloss.NewStyle().
 		Foreground(styles.Muted).
-		Render("\nCtrl+S to save • Esc to cancel • Tab/Shift+Tab to navigate")
+		Render("\nCtrl+S to save • Ctrl+E to edit the description in $EDITOR • Esc to cancel • Tab/Shift+Tab to navigate")
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -274,7 +295,7 @@ func (f *CourseForm) prevInput() {
 	f.inputs[f.focusedInput].Focus()
 }
 
-// submitForm saves the course
+// submitForm validates the course and hands it to the screen to save
 func (f *CourseForm) submitForm() tea.Cmd {
 	return func() tea.Msg {
 		// Validate
@@ -313,23 +334,21 @@ func (f *CourseForm) submitForm() tea.Cmd {
 		course.Semester = f.inputs[courseInputSemester].Value()
 		course.Credits = credits
 		course.Color = f.inputs[courseInputColor].Value()
-		course.Description = f.inputs[courseInputDescription].Value()
+		course.Description = f.currentDescription()
 		course.Schedule = schedules
 
-		var saveErr error
-		if f.isEdit {
-			saveErr = f.db.Courses().Update(course)
-		} else {
-			saveErr = f.db.Courses().Create(course)
-		}
-
-		if saveErr != nil {
-			f.err = saveErr.Error()
-			return nil
-		}
+		return FormSubmitMsg{Course: course, IsEdit: f.isEdit}
+	}
+}
 
-		return FormSubmitMsg{}
+// currentDescription returns the full description, keeping the line breaks of
+// a multi-line description unless it was changed in the single-line input
+func (f *CourseForm) currentDescription() string {
+	value := f.inputs[courseInputDescription].Value()
+	if value == strings.Join(strings.Fields(f.description), " ") || value == f.description {
+		return f.description
 	}
+	return value
 }
 
 // Helper functions
@@ -0,0 +1,74 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// EditorFinishedMsg is sent when the external editor exits
+type EditorFinishedMsg struct {
+	Content string
+	Err     error
+}
+
+// OpenEditor suspends the program and opens content in $VISUAL or $EDITOR
+// through a temporary file. The edited content is returned in an
+// EditorFinishedMsg once the editor exits.
+func OpenEditor(content string) tea.Cmd {
+	file, err := os.CreateTemp("", "unicli-*.md")
+	if err != nil {
+		return editorError(fmt.Errorf("failed to create temp file: %w", err))
+	}
+	path := file.Name()
+
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		os.Remove(path)
+		return editorError(fmt.Errorf("failed to write temp file: %w", err))
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return editorError(fmt.Errorf("failed to write temp file: %w", err))
+	}
+
+	// The editor variable may include arguments, e.g. "code --wait"
+	editor := strings.Fields(editorCommand())
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+
+		if err != nil {
+			return EditorFinishedMsg{Err: fmt.Errorf("editor exited with error: %w", err)}
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return EditorFinishedMsg{Err: fmt.Errorf("failed to read edited file: %w", err)}
+		}
+
+		// Editors usually add a trailing newline on save
+		return EditorFinishedMsg{Content: strings.TrimSuffix(string(data), "\n")}
+	})
+}
+
+// editorCommand returns the user's preferred editor
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+// editorError returns a command reporting an editor error
+func editorError(err error) tea.Cmd {
+	return func() tea.Msg {
+		return EditorFinishedMsg{Err: err}
+	}
+}
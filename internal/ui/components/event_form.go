@@ -7,6 +7,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/dateparse"
 	"github.com/stiffis/UniCLI/internal/models"
 	"github.com/stiffis/UniCLI/internal/ui/styles"
 )
@@ -17,6 +18,7 @@ type EventForm struct {
 	eventID               string // ID of the event being edited (empty if new event)
 	titleInput            Input
 	descriptionInput      TextArea
+	locationInput         Input
 	startDateTimeInput    Input
 	endDateTimeInput      Input
 	recurrenceRuleInput   Input
@@ -28,6 +30,7 @@ type EventForm struct {
 	focusedField int
 	submitted    bool
 	cancelled    bool
+	err          string
 
 	width  int
 	height int
@@ -36,26 +39,31 @@ type EventForm struct {
 const (
 	eventFieldTitle = iota
 	eventFieldDescription
+	eventFieldLocation
 	eventFieldStartDateTime
 	eventFieldEndDateTime
 	eventFieldCategory
 	eventFieldRecurrenceRule
 	eventFieldRecurrenceEndDate
 	eventFieldButtons
+	eventFieldCount
 )
 
 // NewEventForm creates a new event form, optionally pre-filling with existing event data
 func NewEventForm(event *models.Event, categories []models.Category) EventForm {
 	titleInput := NewInput("Title:", "Enter event title...")
 	descriptionInput := NewTextArea("Description:", "Enter event description...")
-	startDateTimeInput := NewInput("Start Time:", "YYYY-MM-DD HH:MM")
-	endDateTimeInput := NewInput("End Time (optional):", "YYYY-MM-DD HH:MM")
+	descriptionInput.SetCharLimit(0)
+	locationInput := NewInput("Location (optional):", "e.g. Library, Room 204...")
+	startDateTimeInput := NewInput("Start Time:", "e.g. tomorrow 15:00, fri 9am or 2025-06-30 14:00")
+	endDateTimeInput := NewInput("End Time (optional):", "e.g. 17:00 or 2025-06-30 16:00")
 	recurrenceRuleInput := NewInput("Recurrence:", "none, daily, weekly, monthly")
-	recurrenceEndDateInput := NewInput("Recurrence End Date:", "YYYY-MM-DD")
+	recurrenceEndDateInput := NewInput("Recurrence End Date:", "e.g. 2025-06-30, jun 30 or +8w")
 
 	form := EventForm{
 		titleInput:            titleInput,
 		descriptionInput:      descriptionInput,
+		locationInput:         locationInput,
 		startDateTimeInput:    startDateTimeInput,
 		endDateTimeInput:      endDateTimeInput,
 		recurrenceRuleInput:   recurrenceRuleInput,
@@ -73,6 +81,7 @@ func NewEventForm(event *models.Event, categories []models.Category) EventForm {
 		form.eventID = event.ID
 		form.titleInput.SetValue(event.Title)
 		form.descriptionInput.SetValue(event.Description)
+		form.locationInput.SetValue(event.Location)
 		form.startDateTimeInput.SetValue(event.StartDatetime.Format("2006-01-02 15:04"))
 		if event.EndDatetime != nil {
 			form.endDateTimeInput.SetValue(event.EndDatetime.Format("2006-01-02 15:04"))
@@ -105,6 +114,15 @@ func (f EventForm) Update(msg tea.Msg) (EventForm, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case EditorFinishedMsg:
+		if msg.Err != nil {
+			f.err = msg.Err.Error()
+		} else {
+			f.err = ""
+			f.descriptionInput.SetValue(msg.Content)
+		}
+		return f, nil
+
 	case tea.KeyMsg:
 		if f.focusedField == eventFieldCategory {
 			switch msg.String() {
@@ -127,17 +145,24 @@ func (f EventForm) Update(msg tea.Msg) (EventForm, tea.Cmd) {
 			f.cancelled = true
 			return f, nil
 
+		case "ctrl+e":
+			// Edit the description in the external editor
+			f.blurAll()
+			f.focusedField = eventFieldDescription
+			cmd = f.focusField(f.focusedField)
+			return f, tea.Batch(cmd, OpenEditor(f.descriptionInput.Value()))
+
 		case "tab", "down":
 			// Move to next field
 			f.blurAll()
-			f.focusedField = (f.focusedField + 1) % 8
+			f.focusedField = (f.focusedField + 1) % eventFieldCount
 			cmd = f.focusField(f.focusedField)
 			return f, cmd
 
 		case "shift+tab", "up":
 			// Move to previous field
 			f.blurAll()
-			f.focusedField = (f.focusedField + 7) % 8
+			f.focusedField = (f.focusedField + eventFieldCount - 1) % eventFieldCount
 			cmd = f.focusField(f.focusedField)
 			return f, cmd
 
@@ -146,7 +171,17 @@ func (f EventForm) Update(msg tea.Msg) (EventForm, tea.Cmd) {
 				// Submit form
 				titleVal := f.titleInput.Value()
 				if titleVal != "" {
+					if field, ok := f.validate(); !ok {
+						// Take the user to the first invalid field
+						f.err = "Please fix the highlighted field"
+						f.blurAll()
+						f.focusedField = field
+						return f, f.focusField(field)
+					}
+					f.err = ""
 					f.submitted = true
+				} else {
+					f.err = "Title is required"
 				}
 				return f, nil
 			}
@@ -158,19 +193,87 @@ func (f EventForm) Update(msg tea.Msg) (EventForm, tea.Cmd) {
 		cmd = f.titleInput.Update(msg)
 	case eventFieldDescription:
 		cmd = f.descriptionInput.Update(msg)
+	case eventFieldLocation:
+		cmd = f.locationInput.Update(msg)
 	case eventFieldStartDateTime:
 		cmd = f.startDateTimeInput.Update(msg)
+		f.validateField(eventFieldStartDateTime)
+		if strings.TrimSpace(f.endDateTimeInput.Value()) != "" {
+			// The end may be a time on the start day
+			f.validateField(eventFieldEndDateTime)
+		}
 	case eventFieldEndDateTime:
 		cmd = f.endDateTimeInput.Update(msg)
+		f.validateField(eventFieldEndDateTime)
 	case eventFieldRecurrenceRule:
 		cmd = f.recurrenceRuleInput.Update(msg)
 	case eventFieldRecurrenceEndDate:
 		cmd = f.recurrenceEndDateInput.Update(msg)
+		f.validateField(eventFieldRecurrenceEndDate)
 	}
 
 	return f, cmd
 }
 
+// validate checks every date field, returning the first invalid one
+func (f *EventForm) validate() (int, bool) {
+	for _, field := range []int{eventFieldStartDateTime, eventFieldEndDateTime, eventFieldRecurrenceEndDate} {
+		if !f.validateField(field) {
+			return field, false
+		}
+	}
+	return 0, true
+}
+
+// validateField checks a date field as it is typed, showing the error or the
+// resolved date below it
+func (f *EventForm) validateField(field int) bool {
+	switch field {
+	case eventFieldStartDateTime:
+		if strings.TrimSpace(f.startDateTimeInput.Value()) == "" {
+			f.startDateTimeInput.SetHint("")
+			f.startDateTimeInput.SetError("start time is required")
+			return false
+		}
+		_, ok := validateDateInput(&f.startDateTimeInput, false)
+		return ok
+
+	case eventFieldEndDateTime:
+		end, ok := f.parseEnd()
+		if !ok || end == nil {
+			return ok
+		}
+		if start, err := dateparse.Parse(f.startDateTimeInput.Value(), time.Now()); err == nil && !end.After(start.Time) {
+			f.endDateTimeInput.SetHint("")
+			f.endDateTimeInput.SetError("end must be after the start")
+			return false
+		}
+
+	case eventFieldRecurrenceEndDate:
+		_, ok := validateDateInput(&f.recurrenceEndDateInput, true)
+		return ok
+	}
+	return true
+}
+
+// parseEnd parses the end time. A time without a date is on the start day.
+func (f *EventForm) parseEnd() (*time.Time, bool) {
+	result, ok := validateDateInput(&f.endDateTimeInput, false)
+	if !ok || result.Time.IsZero() {
+		return nil, ok
+	}
+
+	end := result.Time
+	if !result.HasDate {
+		if start, err := dateparse.Parse(f.startDateTimeInput.Value(), time.Now()); err == nil {
+			end = time.Date(start.Time.Year(), start.Time.Month(), start.Time.Day(),
+				end.Hour(), end.Minute(), 0, 0, end.Location())
+			f.endDateTimeInput.SetHint(end.Format("Mon Jan 2, 2006") + " at " + end.Format("15:04"))
+		}
+	}
+	return &end, true
+}
+
 func (f EventForm) View() string {
 	var sections []string
 
@@ -192,6 +295,10 @@ func (f EventForm) View() string {
 	sections = append(sections, f.descriptionInput.View())
 	sections = append(sections, "")
 
+	// Location input
+	sections = append(sections, f.locationInput.View())
+	sections = append(sections, "")
+
 	sections = append(sections, f.startDateTimeInput.View())
 	sections = append(sections, "")
 
@@ -213,6 +320,11 @@ func (f EventForm) View() string {
 
 	// Buttons
 	sections = append(sections, f.renderButtons())
+	if f.err != "" {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(styles.Warning).
+			Render("⚠ "+f.err))
+	}
 	sections = append(sections, "")
 
 	// Help text
@@ -220,7 +332,7 @@ func (f EventForm) View() string {
 		Foreground(styles.Muted).
 		Italic(true)
 
-	help := helpStyle.Render("Tab: next field  |  Esc: cancel  |  Enter: submit")
+	help := helpStyle.Render("Tab: next field  |  Ctrl+E: $EDITOR  |  Esc: cancel  |  Enter: submit")
 	sections = append(sections, help)
 
 	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
@@ -290,6 +402,7 @@ func (f EventForm) renderButtons() string {
 func (f *EventForm) blurAll() {
 	f.titleInput.Blur()
 	f.descriptionInput.Blur()
+	f.locationInput.Blur()
 	f.startDateTimeInput.Blur()
 	f.endDateTimeInput.Blur()
 	f.recurrenceRuleInput.Blur()
@@ -303,6 +416,8 @@ func (f *EventForm) focusField(field int) tea.Cmd {
 		return f.titleInput.Focus()
 	case eventFieldDescription:
 		return f.descriptionInput.Focus()
+	case eventFieldLocation:
+		return f.locationInput.Focus()
 	case eventFieldStartDateTime:
 		return f.startDateTimeInput.Focus()
 	case eventFieldEndDateTime:
@@ -333,10 +448,11 @@ func (f EventForm) GetEvent() *models.Event {
 
 	event.Title = f.titleInput.Value()
 	event.Description = f.descriptionInput.Value()
+	event.Location = strings.TrimSpace(f.locationInput.Value())
 
 	startDateTimeStr := strings.TrimSpace(f.startDateTimeInput.Value())
-	if startDateTime, err := time.ParseInLocation("2006-01-02 15:04", startDateTimeStr, time.Local); err == nil {
-		event.StartDatetime = startDateTime
+	if startDateTime, err := dateparse.Parse(startDateTimeStr, time.Now()); err == nil {
+		event.StartDatetime = startDateTime.Time
 	} else {
 		// If parsing fails, use original start time or current time
 		if f.originalEvent != nil {
@@ -346,14 +462,7 @@ func (f EventForm) GetEvent() *models.Event {
 		}
 	}
 
-	endDateTimeStr := strings.TrimSpace(f.endDateTimeInput.Value())
-	if endDateTimeStr != "" {
-		if endDateTime, err := time.ParseInLocation("2006-01-02 15:04", endDateTimeStr, time.Local); err == nil {
-			event.EndDatetime = &endDateTime
-		}
-	} else {
-		event.EndDatetime = nil
-	}
+	event.EndDatetime, _ = f.parseEnd()
 
 	// Category
 	if len(f.categories) > 0 {
@@ -364,7 +473,7 @@ func (f EventForm) GetEvent() *models.Event {
 	event.RecurrenceRule = f.recurrenceRuleInput.Value()
 	recurrenceEndDateStr := strings.TrimSpace(f.recurrenceEndDateInput.Value())
 	if recurrenceEndDateStr != "" {
-		if recurrenceEndDate, err := time.ParseInLocation("2006-01-02", recurrenceEndDateStr, time.Local); err == nil {
+		if recurrenceEndDate, err := dateparse.ParseDate(recurrenceEndDateStr, time.Now()); err == nil {
 			event.RecurrenceEndDate = &recurrenceEndDate
 		}
 	} else {
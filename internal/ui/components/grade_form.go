@@ -0,0 +1,390 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/dateparse"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// GradeTypes lists the assessment types offered by the grade form
+var GradeTypes = []string{"exam", "quiz", "homework", "project", "lab", "other"}
+
+// GradeForm is a form for creating/editing course assessments
+type GradeForm struct {
+	original      *models.Grade
+	courseID      string
+	nameInput     Input
+	scoreInput    Input
+	maxScoreInput Input
+	weightInput   Input
+	dateInput     Input
+
+	// Type selector
+	selectedType int
+
+	// Focus tracking
+	focusedField int
+	submitted    bool
+	cancelled    bool
+	err          string
+
+	width int
+}
+
+const (
+	gradeFieldName = iota
+	gradeFieldType
+	gradeFieldScore
+	gradeFieldMaxScore
+	gradeFieldWeight
+	gradeFieldDate
+	gradeFieldButtons
+	gradeFieldCount
+)
+
+// NewGradeForm creates a new grade form for a course, optionally pre-filling with an existing grade
+func NewGradeForm(courseID string, grade *models.Grade) GradeForm {
+	form := GradeForm{
+		courseID:      courseID,
+		nameInput:     NewInput("Name:", "e.g. Midterm 1"),
+		scoreInput:    NewInput("Score (optional):", "leave empty if not graded yet"),
+		maxScoreInput: NewInput("Max Score:", "e.g. 20 or 100"),
+		weightInput:   NewInput("Weight:", "e.g. 30 (relative to the other assessments)"),
+		dateInput:     NewInput("Date (optional):", "e.g. 2025-06-30, jun 30, fri or +3d"),
+		focusedField:  gradeFieldName,
+		width:         60,
+	}
+	form.maxScoreInput.SetValue("100")
+	form.weightInput.SetValue("1")
+
+	if grade != nil {
+		form.original = grade
+		form.courseID = grade.CourseID
+		form.nameInput.SetValue(grade.Name)
+		if grade.Graded {
+			form.scoreInput.SetValue(formatNumber(grade.Score))
+		}
+		form.maxScoreInput.SetValue(formatNumber(grade.MaxScore))
+		form.weightInput.SetValue(formatNumber(grade.Weight))
+		if grade.Date != nil {
+			form.dateInput.SetValue(grade.Date.Format("2006-01-02"))
+		}
+		for i, t := range GradeTypes {
+			if t == grade.Type {
+				form.selectedType = i
+				break
+			}
+		}
+	}
+
+	form.nameInput.Focus()
+
+	return form
+}
+
+// Init initializes the form
+func (f GradeForm) Init() tea.Cmd {
+	return nil
+}
+
+func (f GradeForm) Update(msg tea.Msg) (GradeForm, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			f.cancelled = true
+			return f, nil
+
+		case "tab", "down":
+			f.blurAll()
+			f.focusedField = (f.focusedField + 1) % gradeFieldCount
+			cmd = f.focusField(f.focusedField)
+			return f, cmd
+
+		case "shift+tab", "up":
+			f.blurAll()
+			f.focusedField = (f.focusedField + gradeFieldCount - 1) % gradeFieldCount
+			cmd = f.focusField(f.focusedField)
+			return f, cmd
+
+		case "left":
+			if f.focusedField == gradeFieldType {
+				if f.selectedType > 0 {
+					f.selectedType--
+				}
+				return f, nil
+			}
+
+		case "right":
+			if f.focusedField == gradeFieldType {
+				if f.selectedType < len(GradeTypes)-1 {
+					f.selectedType++
+				}
+				return f, nil
+			}
+
+		case "enter":
+			if f.focusedField == gradeFieldButtons {
+				if err := f.validate(); err != nil {
+					f.err = err.Error()
+					return f, nil
+				}
+				f.err = ""
+				f.submitted = true
+				return f, nil
+			}
+		}
+	}
+
+	switch f.focusedField {
+	case gradeFieldName:
+		cmd = f.nameInput.Update(msg)
+	case gradeFieldScore:
+		cmd = f.scoreInput.Update(msg)
+	case gradeFieldMaxScore:
+		cmd = f.maxScoreInput.Update(msg)
+	case gradeFieldWeight:
+		cmd = f.weightInput.Update(msg)
+	case gradeFieldDate:
+		cmd = f.dateInput.Update(msg)
+		validateDateInput(&f.dateInput, true)
+	}
+
+	return f, cmd
+}
+
+func (f GradeForm) View() string {
+	var sections []string
+
+	titleText := " New Assessment"
+	if f.original != nil {
+		titleText = " Edit Assessment"
+	}
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Primary).
+		Align(lipgloss.Center).
+		Width(f.width).
+		Render(titleText)
+	sections = append(sections, title, "")
+
+	sections = append(sections, f.nameInput.View(), "")
+	sections = append(sections, f.renderTypeSelector(), "")
+	sections = append(sections, f.scoreInput.View(), "")
+	sections = append(sections, f.maxScoreInput.View(), "")
+	sections = append(sections, f.weightInput.View(), "")
+	sections = append(sections, f.dateInput.View(), "")
+	sections = append(sections, f.renderButtons())
+
+	if f.err != "" {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(styles.Warning).
+			Render("⚠ "+f.err))
+	}
+	sections = append(sections, "")
+
+	help := lipgloss.NewStyle().
+		Foreground(styles.Muted).
+		Italic(true).
+		Render("Tab: next field  |  Esc: cancel  |  Enter: submit")
+	sections = append(sections, help)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Width(f.width)
+
+	return modalStyle.Render(content)
+}
+
+// renderTypeSelector renders the assessment type selection
+func (f GradeForm) renderTypeSelector() string {
+	label := lipgloss.NewStyle().
+		Foreground(styles.Primary).
+		Bold(true).
+		Render("Type:")
+
+	var options []string
+	for i, t := range GradeTypes {
+		style := lipgloss.NewStyle().Padding(0, 1)
+		if f.focusedField == gradeFieldType && i == f.selectedType {
+			style = style.
+				Background(styles.Primary).
+				Foreground(styles.Background).
+				Bold(true)
+		} else if i == f.selectedType {
+			style = style.
+				Foreground(styles.Primary).
+				Bold(true)
+		}
+		options = append(options, style.Render(t))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		label,
+		lipgloss.JoinHorizontal(lipgloss.Top, options...),
+	)
+}
+
+// renderButtons renders the action buttons
+func (f GradeForm) renderButtons() string {
+	submitText := "[ Create ]"
+	if f.original != nil {
+		submitText = "[ Save ]"
+	}
+
+	submitStyle := lipgloss.NewStyle().
+		Padding(0, 2).
+		Foreground(styles.Success)
+
+	cancelStyle := lipgloss.NewStyle().
+		Padding(0, 2).
+		Foreground(styles.Muted)
+
+	if f.focusedField == gradeFieldButtons {
+		submitStyle = submitStyle.
+			Background(styles.Success).
+			Foreground(styles.Background).
+			Bold(true)
+	}
+
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		submitStyle.Render(submitText),
+		"  ",
+		cancelStyle.Render("[ Cancel (Esc) ]"),
+	)
+}
+
+// blurAll removes focus from all fields
+func (f *GradeForm) blurAll() {
+	f.nameInput.Blur()
+	f.scoreInput.Blur()
+	f.maxScoreInput.Blur()
+	f.weightInput.Blur()
+	f.dateInput.Blur()
+}
+
+// focusField focuses a specific field
+func (f *GradeForm) focusField(field int) tea.Cmd {
+	switch field {
+	case gradeFieldName:
+		return f.nameInput.Focus()
+	case gradeFieldScore:
+		return f.scoreInput.Focus()
+	case gradeFieldMaxScore:
+		return f.maxScoreInput.Focus()
+	case gradeFieldWeight:
+		return f.weightInput.Focus()
+	case gradeFieldDate:
+		return f.dateInput.Focus()
+	}
+	return nil
+}
+
+// validate checks the numeric and date fields before submitting
+func (f GradeForm) validate() error {
+	if strings.TrimSpace(f.nameInput.Value()) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	maxScore, err := ParseNumber(f.maxScoreInput.Value())
+	if err != nil || maxScore <= 0 {
+		return fmt.Errorf("max score must be a positive number")
+	}
+
+	if s := strings.TrimSpace(f.scoreInput.Value()); s != "" {
+		score, err := ParseNumber(s)
+		if err != nil || score < 0 {
+			return fmt.Errorf("score must be a non-negative number")
+		}
+		if score > maxScore {
+			return fmt.Errorf("score cannot be greater than the max score")
+		}
+	}
+
+	weight, err := ParseNumber(f.weightInput.Value())
+	if err != nil || weight < 0 {
+		return fmt.Errorf("weight must be a non-negative number")
+	}
+
+	if d := strings.TrimSpace(f.dateInput.Value()); d != "" {
+		if _, err := dateparse.ParseDate(d, time.Now()); err != nil {
+			return fmt.Errorf("date: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetGrade returns the grade from form data
+func (f GradeForm) GetGrade() *models.Grade {
+	var grade *models.Grade
+	if f.original != nil {
+		g := *f.original
+		grade = &g
+	} else {
+		grade = models.NewGrade(f.courseID, "")
+	}
+
+	grade.Name = strings.TrimSpace(f.nameInput.Value())
+	grade.Type = GradeTypes[f.selectedType]
+	grade.MaxScore, _ = ParseNumber(f.maxScoreInput.Value())
+	grade.Weight, _ = ParseNumber(f.weightInput.Value())
+
+	if s := strings.TrimSpace(f.scoreInput.Value()); s != "" {
+		grade.Score, _ = ParseNumber(s)
+		grade.Graded = true
+	} else {
+		grade.Score = 0
+		grade.Graded = false
+	}
+
+	grade.Date = nil
+	if d := strings.TrimSpace(f.dateInput.Value()); d != "" {
+		if date, err := dateparse.ParseDate(d, time.Now()); err == nil {
+			grade.Date = &date
+		}
+	}
+
+	return grade
+}
+
+// IsSubmitted returns true if form was submitted
+func (f GradeForm) IsSubmitted() bool {
+	return f.submitted
+}
+
+// IsCancelled returns true if form was cancelled
+func (f GradeForm) IsCancelled() bool {
+	return f.cancelled
+}
+
+// IsNewGrade returns true if this is a new grade (not editing existing)
+func (f GradeForm) IsNewGrade() bool {
+	return f.original == nil
+}
+
+// ParseNumber parses a decimal number, accepting a comma as decimal separator
+func ParseNumber(s string) (float64, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", ".")
+	return strconv.ParseFloat(s, 64)
+}
+
+// formatNumber formats a number without trailing zeros
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
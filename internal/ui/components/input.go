@@ -1,9 +1,14 @@
 package components
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/dateparse"
 	"github.com/stiffis/UniCLI/internal/ui/styles"
 )
 
@@ -12,6 +17,8 @@ type Input struct {
 	textInput textinput.Model
 	label     string
 	width     int
+	err       string // Validation error shown below the input
+	hint      string // Shown below the input when there is no error
 }
 
 // NewInput creates a new input field
@@ -43,6 +50,21 @@ func (i *Input) SetValue(value string) {
 	i.textInput.SetValue(value)
 }
 
+// SetError sets the validation error shown below the input, empty to clear it
+func (i *Input) SetError(err string) {
+	i.err = err
+}
+
+// SetHint sets a note shown below the input, e.g. how the value was understood
+func (i *Input) SetHint(hint string) {
+	i.hint = hint
+}
+
+// Error returns the validation error of the input
+func (i Input) Error() string {
+	return i.err
+}
+
 // Value returns the input value
 func (i *Input) Value() string {
 	return i.textInput.Value()
@@ -62,9 +84,51 @@ func (i Input) View() string {
 	label := labelStyle.Render(i.label)
 	input := i.textInput.View()
 
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
-		label,
-		input,
-	)
+	lines := []string{label, input}
+	if i.err != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.Danger).Render("  ⚠ "+i.err))
+	} else if i.hint != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.Muted).Italic(true).Render("  → "+i.hint))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// ViewInline renders the label and the input on a single line
+func (i Input) ViewInline() string {
+	label := lipgloss.NewStyle().
+		Foreground(styles.Primary).
+		Bold(true).
+		Render(i.label)
+
+	return label + " " + i.textInput.View()
+}
+
+// validateDateInput parses the value of a date input, showing the error or
+// the resolved date below it. An empty value is valid and returns a zero
+// result. Date only inputs reject a time of day.
+func validateDateInput(input *Input, dateOnly bool) (dateparse.Result, bool) {
+	input.SetError("")
+	input.SetHint("")
+
+	value := strings.TrimSpace(input.Value())
+	if value == "" {
+		return dateparse.Result{}, true
+	}
+
+	result, err := dateparse.Parse(value, time.Now())
+	if err == nil && dateOnly && result.HasTime {
+		err = fmt.Errorf("enter a date without a time")
+	}
+	if err != nil {
+		input.SetError(err.Error())
+		return dateparse.Result{}, false
+	}
+
+	hint := result.Time.Format("Mon Jan 2, 2006")
+	if result.HasTime {
+		hint += " at " + result.Time.Format("15:04")
+	}
+	input.SetHint(hint)
+	return result, true
 }
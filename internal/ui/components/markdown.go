@@ -0,0 +1,240 @@
+package components
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// Markdown styles using the Kanagawa palette
+var (
+	mdH1Style        = lipgloss.NewStyle().Bold(true).Underline(true).Foreground(styles.Primary)
+	mdH2Style        = lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary)
+	mdH3Style        = lipgloss.NewStyle().Bold(true).Foreground(styles.AutumnYellow)
+	mdBoldStyle      = lipgloss.NewStyle().Bold(true)
+	mdItalicStyle    = lipgloss.NewStyle().Italic(true)
+	mdStrikeStyle    = lipgloss.NewStyle().Strikethrough(true).Foreground(styles.Muted)
+	mdCodeStyle      = lipgloss.NewStyle().Foreground(styles.Accent).Background(styles.BackgroundLight)
+	mdCodeBlockStyle = lipgloss.NewStyle().Foreground(styles.Foreground).Background(styles.BackgroundLight)
+	mdCodeLangStyle  = lipgloss.NewStyle().Italic(true).Foreground(styles.Muted)
+	mdLinkStyle      = lipgloss.NewStyle().Underline(true).Foreground(styles.Info)
+	mdURLStyle       = lipgloss.NewStyle().Foreground(styles.Muted)
+	mdBulletStyle    = lipgloss.NewStyle().Foreground(styles.SakuraPink)
+	mdCheckedStyle   = lipgloss.NewStyle().Foreground(styles.Success)
+	mdUncheckedStyle = lipgloss.NewStyle().Foreground(styles.Muted)
+	mdDoneItemStyle  = lipgloss.NewStyle().Strikethrough(true).Foreground(styles.Muted)
+	mdQuoteStyle     = lipgloss.NewStyle().Italic(true).Foreground(styles.Muted)
+	mdQuoteBarStyle  = lipgloss.NewStyle().Foreground(styles.Secondary)
+	mdRuleStyle      = lipgloss.NewStyle().Foreground(styles.Border)
+)
+
+// mdPlaceholderChar delimits code spans while the rest of a line is formatted
+const mdPlaceholderChar = "\x00"
+
+var (
+	mdHeadingRegex  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdFenceRegex    = regexp.MustCompile("^\\s*(```|~~~)\\s*(\\S*)")
+	mdTaskRegex     = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	mdBulletRegex   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdOrderedRegex  = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	mdQuoteRegex    = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	mdRuleRegex     = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	mdCodeSpanRegex = regexp.MustCompile("`([^`]+)`")
+	mdLinkRegex     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBoldRegex     = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	mdStrikeRegex   = regexp.MustCompile(`~~([^~]+)~~`)
+	mdItalicRegex   = regexp.MustCompile(`(^|[^\w*])\*([^*\s][^*]*)\*|(^|[^\w_])_([^_\s][^_]*)_`)
+	mdPlaceholder   = regexp.MustCompile(mdPlaceholderChar + `(\d+)` + mdPlaceholderChar)
+)
+
+// RenderMarkdown renders markdown text for the terminal, wrapping it to the
+// given width. It supports headings, bullet and ordered lists, checkboxes,
+// fenced code blocks, blockquotes, horizontal rules and inline emphasis, code
+// and links. Line breaks are kept as written.
+func RenderMarkdown(src string, width int) string {
+	if width < 10 {
+		width = 10
+	}
+
+	var out []string
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+
+		// Fenced code block
+		if m := mdFenceRegex.FindStringSubmatch(line); m != nil {
+			fence := m[1]
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+					break
+				}
+				code = append(code, strings.ReplaceAll(lines[i], "\t", "    "))
+			}
+			out = append(out, renderCodeBlock(code, m[2], width))
+			continue
+		}
+
+		switch {
+		case line == "":
+			out = append(out, "")
+
+		case mdHeadingRegex.MatchString(line):
+			m := mdHeadingRegex.FindStringSubmatch(line)
+			out = append(out, renderHeading(len(m[1]), m[2], width))
+
+		case mdRuleRegex.MatchString(line):
+			out = append(out, mdRuleStyle.Render(strings.Repeat("─", width)))
+
+		case mdTaskRegex.MatchString(line):
+			m := mdTaskRegex.FindStringSubmatch(line)
+			indent := listIndent(m[1])
+			checked := m[2] != " "
+			box := mdUncheckedStyle.Render("☐")
+			text := renderInline(m[3])
+			if checked {
+				box = mdCheckedStyle.Render("☑")
+				text = mdDoneItemStyle.Render(m[3])
+			}
+			out = append(out, renderListItem(indent, box, text, width))
+
+		case mdBulletRegex.MatchString(line):
+			m := mdBulletRegex.FindStringSubmatch(line)
+			indent := listIndent(m[1])
+			bullets := []string{"•", "◦", "▪"}
+			bullet := mdBulletStyle.Render(bullets[(indent/2)%len(bullets)])
+			out = append(out, renderListItem(indent, bullet, renderInline(m[2]), width))
+
+		case mdOrderedRegex.MatchString(line):
+			m := mdOrderedRegex.FindStringSubmatch(line)
+			number := mdBulletStyle.Render(m[2] + ".")
+			out = append(out, renderListItem(listIndent(m[1]), number, renderInline(m[3]), width))
+
+		case mdQuoteRegex.MatchString(line):
+			m := mdQuoteRegex.FindStringSubmatch(line)
+			text := lipgloss.NewStyle().Width(width - 2).Render(mdQuoteStyle.Render(renderInline(m[1])))
+			var quoted []string
+			for _, l := range strings.Split(text, "\n") {
+				quoted = append(quoted, mdQuoteBarStyle.Render("│ ")+l)
+			}
+			out = append(out, strings.Join(quoted, "\n"))
+
+		default:
+			out = append(out, lipgloss.NewStyle().Width(width).Render(renderInline(strings.TrimSpace(line))))
+		}
+	}
+
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+// renderHeading renders a heading of the given level
+func renderHeading(level int, text string, width int) string {
+	var style lipgloss.Style
+	switch level {
+	case 1:
+		style = mdH1Style
+	case 2:
+		style = mdH2Style
+	default:
+		style = mdH3Style
+	}
+
+	prefix := ""
+	if level > 2 {
+		prefix = strings.Repeat("#", level) + " "
+	}
+	return lipgloss.NewStyle().Width(width).Render(style.Render(prefix + stripInline(text)))
+}
+
+// renderListItem renders a list item with a hanging indent
+func renderListItem(indent int, marker, text string, width int) string {
+	prefix := strings.Repeat(" ", indent) + marker + " "
+	prefixWidth := lipgloss.Width(prefix)
+
+	wrapped := lipgloss.NewStyle().Width(max(10, width-prefixWidth)).Render(text)
+	lines := strings.Split(wrapped, "\n")
+	for i := range lines {
+		if i == 0 {
+			lines[i] = prefix + lines[i]
+		} else {
+			lines[i] = strings.Repeat(" ", prefixWidth) + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderCodeBlock renders a fenced code block on a shaded background
+func renderCodeBlock(code []string, lang string, width int) string {
+	var lines []string
+	if lang != "" {
+		lines = append(lines, mdCodeLangStyle.Render(lang))
+	}
+
+	// Long lines are cut instead of wrapped to keep the code layout
+	for _, l := range code {
+		runes := []rune(l)
+		if len(runes) > width-2 {
+			l = string(runes[:max(0, width-3)]) + "…"
+		}
+		lines = append(lines, mdCodeBlockStyle.Width(width).Render(" "+l))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderInline renders inline code, links, bold, strikethrough and italic text
+func renderInline(text string) string {
+	// Protect code spans from further formatting
+	var spans []string
+	text = mdCodeSpanRegex.ReplaceAllStringFunc(text, func(s string) string {
+		spans = append(spans, mdCodeStyle.Render(mdCodeSpanRegex.FindStringSubmatch(s)[1]))
+		return fmt.Sprintf("%s%d%s", mdPlaceholderChar, len(spans)-1, mdPlaceholderChar)
+	})
+
+	text = mdLinkRegex.ReplaceAllStringFunc(text, func(s string) string {
+		m := mdLinkRegex.FindStringSubmatch(s)
+		return mdLinkStyle.Render(m[1]) + mdURLStyle.Render(" ("+m[2]+")")
+	})
+
+	text = mdBoldRegex.ReplaceAllStringFunc(text, func(s string) string {
+		m := mdBoldRegex.FindStringSubmatch(s)
+		return mdBoldStyle.Render(m[1] + m[2])
+	})
+
+	text = mdStrikeRegex.ReplaceAllStringFunc(text, func(s string) string {
+		return mdStrikeStyle.Render(mdStrikeRegex.FindStringSubmatch(s)[1])
+	})
+
+	text = mdItalicRegex.ReplaceAllStringFunc(text, func(s string) string {
+		m := mdItalicRegex.FindStringSubmatch(s)
+		if m[2] != "" {
+			return m[1] + mdItalicStyle.Render(m[2])
+		}
+		return m[3] + mdItalicStyle.Render(m[4])
+	})
+
+	return mdPlaceholder.ReplaceAllStringFunc(text, func(s string) string {
+		index, _ := strconv.Atoi(mdPlaceholder.FindStringSubmatch(s)[1])
+		return spans[index]
+	})
+}
+
+// stripInline removes inline markers, used where the whole line has a single style
+func stripInline(text string) string {
+	text = mdCodeSpanRegex.ReplaceAllString(text, "$1")
+	text = mdLinkRegex.ReplaceAllString(text, "$1")
+	text = mdBoldRegex.ReplaceAllString(text, "$1$2")
+	text = mdStrikeRegex.ReplaceAllString(text, "$1")
+	return text
+}
+
+// listIndent normalizes list indentation to two spaces per level
+func listIndent(whitespace string) int {
+	n := len(strings.ReplaceAll(whitespace, "\t", "  "))
+	return (n / 2) * 2
+}
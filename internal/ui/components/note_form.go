@@ -0,0 +1,346 @@
+package components
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// NoteForm is a form for creating/editing standalone and course notes
+type NoteForm struct {
+	isNew        bool
+	titleInput   Input
+	tagsInput    Input
+	contentInput TextArea
+
+	// Course selector, index 0 is a standalone note
+	courses        []models.Course
+	selectedCourse int
+
+	// Focus tracking
+	focusedField int
+	submitted    bool
+	cancelled    bool
+	err          string
+
+	width int
+}
+
+const (
+	noteFieldTitle = iota
+	noteFieldCourse
+	noteFieldTags
+	noteFieldContent
+	noteFieldButtons
+	noteFieldCount
+)
+
+// NewNoteForm creates a new note form. An empty courseID means a standalone note.
+func NewNoteForm(courses []models.Course, courseID, title, content string, tags []string, isNew bool) NoteForm {
+	form := NoteForm{
+		isNew:        isNew,
+		titleInput:   NewInput("Title:", "e.g. Lecture 3 - Limits"),
+		tagsInput:    NewInput("Tags (comma-separated):", "e.g. exam, summary"),
+		contentInput: NewTextArea("Content (Markdown):", "Write your note..."),
+		courses:      courses,
+		focusedField: noteFieldTitle,
+		width:        80,
+	}
+	form.contentInput.SetSize(72, 10)
+	form.contentInput.SetCharLimit(0)
+
+	form.titleInput.SetValue(title)
+	form.tagsInput.SetValue(strings.Join(tags, ", "))
+	form.contentInput.SetValue(content)
+	for i, course := range courses {
+		if course.ID == courseID {
+			form.selectedCourse = i + 1
+			break
+		}
+	}
+
+	form.titleInput.Focus()
+
+	return form
+}
+
+// Init initializes the form
+func (f NoteForm) Init() tea.Cmd {
+	return nil
+}
+
+func (f NoteForm) Update(msg tea.Msg) (NoteForm, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case EditorFinishedMsg:
+		if msg.Err != nil {
+			f.err = msg.Err.Error()
+		} else {
+			f.err = ""
+			f.contentInput.SetValue(msg.Content)
+		}
+		return f, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			f.cancelled = true
+			return f, nil
+
+		case "ctrl+e":
+			// Edit the content in the external editor
+			f.blurAll()
+			f.focusedField = noteFieldContent
+			cmd = f.focusField(f.focusedField)
+			return f, tea.Batch(cmd, OpenEditor(f.contentInput.Value()))
+
+		case "ctrl+s":
+			f.submit()
+			return f, nil
+
+		case "tab":
+			f.blurAll()
+			f.focusedField = (f.focusedField + 1) % noteFieldCount
+			cmd = f.focusField(f.focusedField)
+			return f, cmd
+
+		case "shift+tab":
+			f.blurAll()
+			f.focusedField = (f.focusedField + noteFieldCount - 1) % noteFieldCount
+			cmd = f.focusField(f.focusedField)
+			return f, cmd
+
+		case "down", "up":
+			// Arrow keys move between lines inside the content
+			if f.focusedField != noteFieldContent {
+				f.blurAll()
+				if msg.String() == "down" {
+					f.focusedField = (f.focusedField + 1) % noteFieldCount
+				} else {
+					f.focusedField = (f.focusedField + noteFieldCount - 1) % noteFieldCount
+				}
+				cmd = f.focusField(f.focusedField)
+				return f, cmd
+			}
+
+		case "left":
+			if f.focusedField == noteFieldCourse {
+				if f.selectedCourse > 0 {
+					f.selectedCourse--
+				}
+				return f, nil
+			}
+
+		case "right":
+			if f.focusedField == noteFieldCourse {
+				if f.selectedCourse < len(f.courses) {
+					f.selectedCourse++
+				}
+				return f, nil
+			}
+
+		case "enter":
+			if f.focusedField == noteFieldButtons {
+				f.submit()
+				return f, nil
+			}
+		}
+	}
+
+	switch f.focusedField {
+	case noteFieldTitle:
+		cmd = f.titleInput.Update(msg)
+	case noteFieldTags:
+		cmd = f.tagsInput.Update(msg)
+	case noteFieldContent:
+		cmd = f.contentInput.Update(msg)
+	}
+
+	return f, cmd
+}
+
+func (f NoteForm) View() string {
+	var sections []string
+
+	titleText := " New Note"
+	if !f.isNew {
+		titleText = " Edit Note"
+	}
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Primary).
+		Align(lipgloss.Center).
+		Width(f.width).
+		Render(titleText)
+	sections = append(sections, title, "")
+
+	sections = append(sections, f.titleInput.View(), "")
+	sections = append(sections, f.renderCourseSelector(), "")
+	sections = append(sections, f.tagsInput.View(), "")
+	sections = append(sections, f.contentInput.View(), "")
+	sections = append(sections, f.renderButtons())
+
+	if f.err != "" {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(styles.Warning).
+			Render("⚠ "+f.err))
+	}
+	sections = append(sections, "")
+
+	help := lipgloss.NewStyle().
+		Foreground(styles.Muted).
+		Italic(true).
+		Render("Tab: next field  |  Ctrl+E: $EDITOR  |  Ctrl+S: save  |  Esc: cancel")
+	sections = append(sections, help)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Width(f.width)
+
+	return modalStyle.Render(content)
+}
+
+// renderCourseSelector renders the course selection
+func (f NoteForm) renderCourseSelector() string {
+	label := lipgloss.NewStyle().
+		Foreground(styles.Primary).
+		Bold(true).
+		Render("Course:")
+
+	name := "None (standalone note)"
+	if f.selectedCourse > 0 {
+		course := f.courses[f.selectedCourse-1]
+		name = course.Name
+		if course.Code != "" {
+			name = course.Code + " - " + course.Name
+		}
+	}
+
+	style := lipgloss.NewStyle().Padding(0, 1)
+	if f.focusedField == noteFieldCourse {
+		style = style.
+			Background(styles.Primary).
+			Foreground(styles.Background).
+			Bold(true)
+	} else {
+		style = style.Foreground(styles.Primary)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		label,
+		"◀ "+style.Render(name)+" ▶",
+	)
+}
+
+// renderButtons renders the action buttons
+func (f NoteForm) renderButtons() string {
+	submitText := "[ Create ]"
+	if !f.isNew {
+		submitText = "[ Save ]"
+	}
+
+	submitStyle := lipgloss.NewStyle().
+		Padding(0, 2).
+		Foreground(styles.Success)
+
+	cancelStyle := lipgloss.NewStyle().
+		Padding(0, 2).
+		Foreground(styles.Muted)
+
+	if f.focusedField == noteFieldButtons {
+		submitStyle = submitStyle.
+			Background(styles.Success).
+			Foreground(styles.Background).
+			Bold(true)
+	}
+
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		submitStyle.Render(submitText),
+		"  ",
+		cancelStyle.Render("[ Cancel (Esc) ]"),
+	)
+}
+
+// submit marks the form as submitted if the title is present
+func (f *NoteForm) submit() {
+	if strings.TrimSpace(f.titleInput.Value()) == "" {
+		f.err = "title is required"
+		return
+	}
+	f.err = ""
+	f.submitted = true
+}
+
+// blurAll removes focus from all fields
+func (f *NoteForm) blurAll() {
+	f.titleInput.Blur()
+	f.tagsInput.Blur()
+	f.contentInput.Blur()
+}
+
+// focusField focuses a specific field
+func (f *NoteForm) focusField(field int) tea.Cmd {
+	switch field {
+	case noteFieldTitle:
+		return f.titleInput.Focus()
+	case noteFieldTags:
+		return f.tagsInput.Focus()
+	case noteFieldContent:
+		return f.contentInput.Focus()
+	}
+	return nil
+}
+
+// GetTitle returns the note title
+func (f NoteForm) GetTitle() string {
+	return strings.TrimSpace(f.titleInput.Value())
+}
+
+// GetContent returns the note content
+func (f NoteForm) GetContent() string {
+	return f.contentInput.Value()
+}
+
+// GetTags returns the note tags
+func (f NoteForm) GetTags() []string {
+	tags := []string{}
+	for _, tag := range strings.Split(f.tagsInput.Value(), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// GetCourseID returns the selected course ID, empty for a standalone note
+func (f NoteForm) GetCourseID() string {
+	if f.selectedCourse == 0 {
+		return ""
+	}
+	return f.courses[f.selectedCourse-1].ID
+}
+
+// IsSubmitted returns true if form was submitted
+func (f NoteForm) IsSubmitted() bool {
+	return f.submitted
+}
+
+// IsCancelled returns true if form was cancelled
+func (f NoteForm) IsCancelled() bool {
+	return f.cancelled
+}
+
+// IsNewNote returns true if this is a new note (not editing existing)
+func (f NoteForm) IsNewNote() bool {
+	return f.isNew
+}
@@ -0,0 +1,187 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// PomodoroTickMsg advances the countdown of a running Pomodoro
+type PomodoroTickMsg struct {
+	id int
+}
+
+// PomodoroPhaseEndedMsg is sent when a phase ran out and the next one started
+type PomodoroPhaseEndedMsg struct {
+	Ended     models.PomodoroPhase
+	Next      models.PomodoroPhase
+	TaskID    string // Empty if the Pomodoro is not attached to a task
+	TaskTitle string
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// Pomodoro is a Pomodoro timer cycling through work and break phases. The
+// countdown is driven by tea.Tick once a second.
+type Pomodoro struct {
+	settings models.PomodoroSettings
+
+	// id identifies the running tick loop, ticks of an older loop are ignored
+	id        int
+	running   bool
+	paused    bool
+	phase     models.PomodoroPhase
+	completed int // Work sessions completed since the timer was started
+	startedAt time.Time
+	endsAt    time.Time
+	remaining time.Duration // Remaining time while paused
+
+	taskID    string
+	taskTitle string
+}
+
+// NewPomodoro creates a new stopped Pomodoro timer
+func NewPomodoro(settings models.PomodoroSettings) *Pomodoro {
+	return &Pomodoro{settings: settings}
+}
+
+// Start starts a new cycle with a work phase, attached to a task if taskID is
+// not empty. A running cycle is replaced.
+func (p *Pomodoro) Start(taskID, taskTitle string) tea.Cmd {
+	p.running = true
+	p.paused = false
+	p.completed = 0
+	p.taskID = taskID
+	p.taskTitle = taskTitle
+	return p.startPhase(models.PomodoroWork, time.Now())
+}
+
+// Stop stops the timer, an unfinished work phase is not logged
+func (p *Pomodoro) Stop() {
+	p.running = false
+	p.paused = false
+	p.id++
+}
+
+// TogglePause pauses or resumes the countdown
+func (p *Pomodoro) TogglePause() tea.Cmd {
+	if !p.running {
+		return nil
+	}
+	if p.paused {
+		p.paused = false
+		p.endsAt = time.Now().Add(p.remaining)
+		return p.tick()
+	}
+	p.paused = true
+	p.remaining = time.Until(p.endsAt)
+	p.id++
+	return nil
+}
+
+// Skip ends the current phase early and starts the next one. A skipped work
+// phase does not count as a completed Pomodoro.
+func (p *Pomodoro) Skip() tea.Cmd {
+	if !p.running {
+		return nil
+	}
+	p.paused = false
+	return p.startPhase(p.settings.NextPhase(p.phase, p.completed), time.Now())
+}
+
+// startPhase starts the countdown of a phase and a new tick loop
+func (p *Pomodoro) startPhase(phase models.PomodoroPhase, now time.Time) tea.Cmd {
+	p.phase = phase
+	p.startedAt = now
+	p.endsAt = now.Add(p.settings.Duration(phase))
+	p.id++
+	return p.tick()
+}
+
+// tick schedules the next tick of the current loop
+func (p *Pomodoro) tick() tea.Cmd {
+	id := p.id
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return PomodoroTickMsg{id: id}
+	})
+}
+
+// Update advances the countdown and moves to the next phase once the current
+// one ran out
+func (p *Pomodoro) Update(msg PomodoroTickMsg) tea.Cmd {
+	if msg.id != p.id || !p.running || p.paused {
+		return nil
+	}
+
+	now := time.Now()
+	if now.Before(p.endsAt) {
+		return p.tick()
+	}
+
+	ended := PomodoroPhaseEndedMsg{
+		Ended:     p.phase,
+		TaskID:    p.taskID,
+		TaskTitle: p.taskTitle,
+		StartedAt: p.startedAt,
+		EndedAt:   now,
+	}
+	if p.phase == models.PomodoroWork {
+		p.completed++
+	}
+	ended.Next = p.settings.NextPhase(p.phase, p.completed)
+
+	return tea.Batch(
+		p.startPhase(ended.Next, now),
+		func() tea.Msg { return ended },
+	)
+}
+
+// IsRunning returns true if a cycle was started and not stopped
+func (p *Pomodoro) IsRunning() bool {
+	return p.running
+}
+
+// TaskID returns the task the timer is attached to, empty if none
+func (p *Pomodoro) TaskID() string {
+	return p.taskID
+}
+
+// Remaining returns the time left in the current phase
+func (p *Pomodoro) Remaining() time.Duration {
+	if p.paused {
+		return p.remaining
+	}
+	return max(0, time.Until(p.endsAt))
+}
+
+// View renders the countdown for the status bar, empty when stopped
+func (p *Pomodoro) View() string {
+	if !p.running {
+		return ""
+	}
+
+	remaining := p.Remaining().Round(time.Second)
+	countdown := fmt.Sprintf("%02d:%02d", int(remaining.Minutes()), int(remaining.Seconds())%60)
+
+	color := styles.Danger
+	if p.phase != models.PomodoroWork {
+		color = styles.Success
+	}
+
+	text := fmt.Sprintf("🍅 %s %s", p.phase.Label(), countdown)
+	if p.paused {
+		text += " (paused)"
+	}
+	if p.taskTitle != "" {
+		text += " · " + truncate(p.taskTitle, 24)
+	}
+	if p.completed > 0 {
+		text += fmt.Sprintf(" · %d done", p.completed)
+	}
+
+	return lipgloss.NewStyle().Foreground(color).Bold(true).Render(text)
+}
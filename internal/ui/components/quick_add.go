@@ -0,0 +1,223 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/database"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// QuickAddSavedMsg is sent when a quick-add line was saved
+type QuickAddSavedMsg struct {
+	Item models.QuickAdd
+	Err  error
+}
+
+// QuickAddOverlay is a one-line prompt creating a task or an event, with a
+// preview of the parsed line
+type QuickAddOverlay struct {
+	db     *database.DB
+	input  Input
+	parsed models.QuickAdd
+	err    error // Parse error of the current line
+	saved  string
+	saving bool
+	closed bool
+
+	savedCount int // Items created since the prompt was opened
+
+	width  int
+	height int
+}
+
+// NewQuickAddOverlay creates a new quick-add prompt filled with text
+func NewQuickAddOverlay(db *database.DB, text string) *QuickAddOverlay {
+	input := NewInput("", "Finish lab report #cs !high due fri 23:59")
+	input.SetValue(text)
+	q := &QuickAddOverlay{
+		db:    db,
+		input: input,
+	}
+	q.parse()
+	return q
+}
+
+// Init focuses the prompt
+func (q *QuickAddOverlay) Init() tea.Cmd {
+	return q.input.Focus()
+}
+
+// SetSize sets the area the overlay is drawn in
+func (q *QuickAddOverlay) SetSize(width, height int) {
+	q.width = width
+	q.height = height
+}
+
+func (q *QuickAddOverlay) Update(msg tea.Msg) (*QuickAddOverlay, tea.Cmd) {
+	switch msg := msg.(type) {
+	case QuickAddSavedMsg:
+		q.saving = false
+		if msg.Err != nil {
+			q.err = msg.Err
+			return q, nil
+		}
+		q.savedCount++
+		q.saved = "✓ Created " + describeQuickAdd(msg.Item)
+		// Keep the prompt open for the next item
+		q.input.SetValue("")
+		q.parse()
+		return q, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			q.closed = true
+			return q, nil
+		case "enter":
+			if q.err != nil || q.saving || strings.TrimSpace(q.input.Value()) == "" {
+				return q, nil
+			}
+			q.saving = true
+			return q, q.save(q.parsed)
+		}
+
+		before := q.input.Value()
+		cmd := q.input.Update(msg)
+		if q.input.Value() != before {
+			q.saved = ""
+			q.parse()
+		}
+		return q, cmd
+	}
+
+	return q, nil
+}
+
+// parse parses the current line for the preview
+func (q *QuickAddOverlay) parse() {
+	q.parsed, q.err = models.QuickAdd{}, nil
+	if strings.TrimSpace(q.input.Value()) == "" {
+		return
+	}
+	q.parsed, q.err = models.ParseQuickAdd(q.input.Value(), time.Now())
+}
+
+// save creates the parsed task or event
+func (q *QuickAddOverlay) save(item models.QuickAdd) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if item.Task != nil {
+			err = q.db.Tasks().Create(item.Task)
+		} else if item.Event != nil {
+			err = q.db.Events().Create(item.Event)
+		}
+		return QuickAddSavedMsg{Item: item, Err: err}
+	}
+}
+
+func (q *QuickAddOverlay) View() string {
+	width := min(max(40, q.width-8), 80)
+
+	var sections []string
+	sections = append(sections, styles.Title.Render(" Quick Add"), "")
+	sections = append(sections, q.input.View(), "")
+
+	switch {
+	case q.err != nil:
+		sections = append(sections, lipgloss.NewStyle().Foreground(styles.Danger).Render("⚠ "+q.err.Error()))
+	case q.parsed.Task != nil || q.parsed.Event != nil:
+		sections = append(sections, q.renderPreview()...)
+	default:
+		sections = append(sections,
+			styles.Dimmed.Render("Tasks:  title #tag !priority due fri 23:59"),
+			styles.Dimmed.Render("Events: title tomorrow 15:00-17:00 @place"),
+		)
+	}
+
+	if q.saved != "" {
+		sections = append(sections, "", lipgloss.NewStyle().Foreground(styles.Success).Render(q.saved))
+	}
+
+	sections = append(sections, "", lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		styles.Shortcut.Render("enter")+styles.ShortcutText.Render(" create"),
+		"  ",
+		styles.Shortcut.Render("esc")+styles.ShortcutText.Render(" close"),
+	))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+
+	return lipgloss.Place(q.width, q.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// renderPreview renders the fields of the parsed item
+func (q *QuickAddOverlay) renderPreview() []string {
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary).Width(10)
+	line := func(label, value string) string {
+		return labelStyle.Render(label) + value
+	}
+
+	if task := q.parsed.Task; task != nil {
+		lines := []string{
+			line("Task", lipgloss.NewStyle().Bold(true).Render(task.Title)),
+			line("Priority", string(task.Priority)),
+		}
+		if len(task.Tags) > 0 {
+			lines = append(lines, line("Tags", "#"+strings.Join(task.Tags, " #")))
+		}
+		if task.DueDate != nil {
+			lines = append(lines, line("Due", task.DueDate.Format("Mon Jan 2 2006 15:04")))
+		}
+		return lines
+	}
+
+	event := q.parsed.Event
+	when := event.StartDatetime.Format("Mon Jan 2 2006")
+	switch {
+	case event.IsAllDay():
+		when += ", all day"
+	case event.EndDatetime != nil:
+		when += fmt.Sprintf(", %s-%s", event.StartDatetime.Format("15:04"), event.EndDatetime.Format("15:04"))
+	default:
+		when += ", " + event.StartDatetime.Format("15:04")
+	}
+	lines := []string{
+		line("Event", lipgloss.NewStyle().Bold(true).Render(event.Title)),
+		line("When", when),
+	}
+	if event.Location != "" {
+		lines = append(lines, line("Where", event.Location))
+	}
+	return lines
+}
+
+// describeQuickAdd names a created item for the confirmation line
+func describeQuickAdd(item models.QuickAdd) string {
+	if item.Task != nil {
+		return fmt.Sprintf("task \"%s\"", item.Task.Title)
+	}
+	if item.Event != nil {
+		return fmt.Sprintf("event \"%s\"", item.Event.Title)
+	}
+	return ""
+}
+
+// SavedCount returns the number of items created since the prompt was opened
+func (q *QuickAddOverlay) SavedCount() int {
+	return q.savedCount
+}
+
+// IsClosed returns true once the prompt was dismissed
+func (q *QuickAddOverlay) IsClosed() bool {
+	return q.closed
+}
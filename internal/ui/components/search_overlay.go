@@ -0,0 +1,286 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/database"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// searchResultLimit is the maximum number of results fetched per query
+const searchResultLimit = 30
+
+// SearchResultsMsg carries the results of a search query
+type SearchResultsMsg struct {
+	Query   string
+	Results []models.SearchResult
+	Err     error
+}
+
+// SearchOverlay is the global full-text search box
+type SearchOverlay struct {
+	db       *database.DB
+	input    Input
+	query    string // Query of the results shown
+	results  []models.SearchResult
+	cursor   int
+	err      error
+	selected *models.SearchResult
+	closed   bool
+
+	width  int
+	height int
+}
+
+// NewSearchOverlay creates a new search overlay
+func NewSearchOverlay(db *database.DB) *SearchOverlay {
+	input := NewInput("", "Search tasks, events, courses and notes...")
+	return &SearchOverlay{
+		db:    db,
+		input: input,
+	}
+}
+
+// Init focuses the search input
+func (s *SearchOverlay) Init() tea.Cmd {
+	return s.input.Focus()
+}
+
+// SetSize sets the area the overlay is drawn in
+func (s *SearchOverlay) SetSize(width, height int) {
+	s.width = width
+	s.height = height
+}
+
+func (s *SearchOverlay) Update(msg tea.Msg) (*SearchOverlay, tea.Cmd) {
+	switch msg := msg.(type) {
+	case SearchResultsMsg:
+		// Ignore results of queries typed over since
+		if msg.Query != s.input.Value() {
+			return s, nil
+		}
+		s.query = msg.Query
+		s.results = msg.Results
+		s.err = msg.Err
+		s.cursor = 0
+		return s, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			s.closed = true
+			return s, nil
+		case "enter":
+			if s.cursor < len(s.results) {
+				result := s.results[s.cursor]
+				s.selected = &result
+				s.closed = true
+			}
+			return s, nil
+		case "down", "ctrl+n", "ctrl+j":
+			if s.cursor < len(s.results)-1 {
+				s.cursor++
+			}
+			return s, nil
+		case "up", "ctrl+p", "ctrl+k":
+			if s.cursor > 0 {
+				s.cursor--
+			}
+			return s, nil
+		}
+
+		before := s.input.Value()
+		cmd := s.input.Update(msg)
+		if s.input.Value() != before {
+			return s, tea.Batch(cmd, s.search(s.input.Value()))
+		}
+		return s, cmd
+	}
+
+	return s, nil
+}
+
+// search runs the query in the background
+func (s *SearchOverlay) search(query string) tea.Cmd {
+	return func() tea.Msg {
+		results, err := s.db.Search().Search(query, searchResultLimit)
+		return SearchResultsMsg{Query: query, Results: results, Err: err}
+	}
+}
+
+func (s *SearchOverlay) View() string {
+	width := min(max(40, s.width-8), 90)
+
+	var sections []string
+	sections = append(sections, styles.Title.Render(" Search"), "")
+	sections = append(sections, s.input.View(), "")
+
+	switch {
+	case s.err != nil:
+		sections = append(sections, lipgloss.NewStyle().Foreground(styles.Danger).Render("⚠ "+s.err.Error()))
+	case strings.TrimSpace(s.query) == "":
+		sections = append(sections, styles.Dimmed.Render("Type to search"))
+	case len(s.results) == 0:
+		sections = append(sections, styles.Dimmed.Render("No matches for \""+s.query+"\""))
+	default:
+		sections = append(sections, s.renderResults(width-6)...)
+	}
+
+	sections = append(sections, "", lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		styles.Shortcut.Render("↑/↓")+styles.ShortcutText.Render(" select"),
+		"  ",
+		styles.Shortcut.Render("enter")+styles.ShortcutText.Render(" open"),
+		"  ",
+		styles.Shortcut.Render("esc")+styles.ShortcutText.Render(" close"),
+	))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+
+	return lipgloss.Place(s.width, s.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// renderResults renders the visible results, two lines each
+func (s *SearchOverlay) renderResults(width int) []string {
+	visible := max(1, (s.height-16)/2)
+	start := 0
+	if s.cursor >= visible {
+		start = s.cursor - visible + 1
+	}
+	end := min(len(s.results), start+visible)
+
+	var lines []string
+	for i := start; i < end; i++ {
+		result := s.results[i]
+
+		label := searchKindIcon(result.Kind) + " " + result.Kind.Label()
+		if result.Archived {
+			label += " (archived)"
+		}
+		kind := lipgloss.NewStyle().
+			Foreground(searchKindColor(result.Kind)).
+			Render(label)
+		title := truncate(result.Title, width-lipgloss.Width(kind)-3)
+		// A title match needs no snippet, the title already shows it
+		snippet := ""
+		if plainSnippet(result.Snippet) != result.Title {
+			snippet = "   " + renderSnippet(result.Snippet, width-3)
+		}
+
+		if i == s.cursor {
+			title = lipgloss.NewStyle().
+				Background(styles.SelectedBackground).
+				Foreground(styles.SelectedForeground).
+				Bold(true).
+				Render(" " + title + " ")
+		} else {
+			title = lipgloss.NewStyle().Bold(true).Render(" " + title + " ")
+		}
+
+		lines = append(lines, kind+" "+title, snippet)
+	}
+
+	if len(s.results) > visible {
+		lines = append(lines, styles.Dimmed.Render(fmt.Sprintf("   %d/%d", s.cursor+1, len(s.results))))
+	}
+
+	return lines
+}
+
+// renderSnippet renders a snippet on a single line with the matches highlighted
+func renderSnippet(snippet string, width int) string {
+	snippet = strings.Join(strings.Fields(snippet), " ")
+
+	// Cut on the plain text so the highlight markers are never split
+	if len([]rune(plainSnippet(snippet))) > width {
+		runes := []rune(snippet)
+		visible := 0
+		for i, r := range runes {
+			if string(r) != models.SearchHighlightStart && string(r) != models.SearchHighlightEnd {
+				visible++
+			}
+			if visible >= width {
+				snippet = string(runes[:i]) + "…"
+				break
+			}
+		}
+	}
+
+	dim := styles.Dimmed
+	match := lipgloss.NewStyle().Foreground(styles.AutumnYellow).Bold(true)
+
+	var b strings.Builder
+	for _, part := range strings.Split(snippet, models.SearchHighlightStart) {
+		if text, rest, found := strings.Cut(part, models.SearchHighlightEnd); found {
+			b.WriteString(match.Render(text))
+			b.WriteString(dim.Render(rest))
+		} else {
+			b.WriteString(dim.Render(part))
+		}
+	}
+	return b.String()
+}
+
+// plainSnippet removes the highlight markers from a snippet
+func plainSnippet(snippet string) string {
+	return strings.NewReplacer(models.SearchHighlightStart, "", models.SearchHighlightEnd, "").Replace(snippet)
+}
+
+// truncate shortens a string to the given width, adding an ellipsis
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(runes[:max(0, width)])
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// searchKindIcon returns the icon shown next to a result
+func searchKindIcon(kind models.SearchKind) string {
+	switch kind {
+	case models.SearchKindTask:
+		return ""
+	case models.SearchKindEvent:
+		return "󰃭"
+	case models.SearchKindCourse:
+		return "󱉟"
+	default:
+		return "󰷈"
+	}
+}
+
+// searchKindColor returns the color of a result kind
+func searchKindColor(kind models.SearchKind) lipgloss.Color {
+	switch kind {
+	case models.SearchKindTask:
+		return styles.Info
+	case models.SearchKindEvent:
+		return styles.SakuraPink
+	case models.SearchKindCourse:
+		return styles.Secondary
+	default:
+		return styles.AutumnYellow
+	}
+}
+
+// Selected returns the chosen result, nil if the search was dismissed
+func (s *SearchOverlay) Selected() *models.SearchResult {
+	return s.selected
+}
+
+// IsClosed returns true once a result was chosen or the search was dismissed
+func (s *SearchOverlay) IsClosed() bool {
+	return s.closed
+}
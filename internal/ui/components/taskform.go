@@ -7,6 +7,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/dateparse"
 	"github.com/stiffis/UniCLI/internal/models"
 	"github.com/stiffis/UniCLI/internal/ui/styles"
 )
@@ -17,12 +18,23 @@ type TaskForm struct {
 	titleInput       Input
 	descriptionInput TextArea
 	dueDateInput     Input
+	repeatInput      Input
+	estimateInput    Input
 	tagsInput        Input
 
 	// Priority selector
 	priorities       []models.TaskPriority
 	selectedPriority int
 
+	// Course selector, index 0 is no course
+	courses        []models.Course
+	selectedCourse int
+
+	// Prerequisite picker
+	candidates   []models.TaskDependency
+	selectedDeps map[string]bool
+	depCursor    int
+
 	// Original task status (only for editing)
 	originalStatus models.TaskStatus
 
@@ -30,6 +42,7 @@ type TaskForm struct {
 	focusedField int
 	submitted    bool
 	cancelled    bool
+	err          string
 
 	width  int
 	height int
@@ -39,16 +52,25 @@ const (
 	fieldTitle = iota
 	fieldDescription
 	fieldDueDate
+	fieldRepeat
+	fieldEstimate
 	fieldTags
+	fieldCourse
 	fieldPriority
+	fieldDependencies
 	fieldButtons
+	fieldCount
 )
 
-// NewTaskForm creates a new task form, optionally pre-filling with existing task data
-func NewTaskForm(task *models.Task) TaskForm {
+// NewTaskForm creates a new task form, optionally pre-filling with existing task data.
+// Open tasks among tasks can be picked as prerequisites.
+func NewTaskForm(task *models.Task, tasks []models.Task, courses []models.Course) TaskForm {
 	titleInput := NewInput("Title:", "Enter task title...")
 	descriptionInput := NewTextArea("Description:", "Enter task description...")
-	dueDateInput := NewInput("Due Date (optional):", "YYYY-MM-DD or leave empty")
+	descriptionInput.SetCharLimit(0)
+	dueDateInput := NewInput("Due Date (optional):", "e.g. fri, tomorrow 17:00, +3d or 2025-06-30")
+	repeatInput := NewInput("Repeat (optional):", "daily, weekly mon,thu, monthly, every 3d")
+	estimateInput := NewInput("Estimate (optional):", "e.g. 45m, 2h or 1h30m")
 	tagsInput := NewInput("Tags (comma-separated):", "e.g. uni, project, urgent")
 
 	priorities := []models.TaskPriority{
@@ -62,9 +84,13 @@ func NewTaskForm(task *models.Task) TaskForm {
 		titleInput:       titleInput,
 		descriptionInput: descriptionInput,
 		dueDateInput:     dueDateInput,
+		repeatInput:      repeatInput,
+		estimateInput:    estimateInput,
 		tagsInput:        tagsInput,
 		priorities:       priorities,
 		selectedPriority: 1, // Default to Medium
+		courses:          courses,
+		selectedDeps:     make(map[string]bool),
 		focusedField:     fieldTitle,
 		width:            60,
 		height:           20,
@@ -77,7 +103,15 @@ func NewTaskForm(task *models.Task) TaskForm {
 		form.titleInput.SetValue(task.Title)
 		form.descriptionInput.SetValue(task.Description)
 		if task.DueDate != nil {
-			form.dueDateInput.SetValue(task.DueDate.Format("2006-01-02"))
+			if task.DueDate.Hour() == 0 && task.DueDate.Minute() == 0 {
+				form.dueDateInput.SetValue(task.DueDate.Format("2006-01-02"))
+			} else {
+				form.dueDateInput.SetValue(task.DueDate.Format("2006-01-02 15:04"))
+			}
+		}
+		form.repeatInput.SetValue(task.RecurrenceRule)
+		if task.EstimatedMinutes > 0 {
+			form.estimateInput.SetValue(models.FormatMinutes(task.EstimatedMinutes))
 		}
 		if len(task.Tags) > 0 {
 			form.tagsInput.SetValue(strings.Join(task.Tags, ", "))
@@ -88,10 +122,29 @@ func NewTaskForm(task *models.Task) TaskForm {
 				break
 			}
 		}
+		for i, course := range courses {
+			if course.ID == task.CourseID {
+				form.selectedCourse = i + 1
+				break
+			}
+		}
+		for _, dep := range task.Dependencies {
+			form.selectedDeps[dep.ID] = true
+			form.candidates = append(form.candidates, dep)
+		}
 		// Change button text to "Save" for existing tasks
 		// This will be handled in renderButtons
 	}
 
+	// Open tasks can become prerequisites, current ones were added above
+	for _, t := range tasks {
+		if t.ID == form.taskID || form.selectedDeps[t.ID] ||
+			t.Status == models.TaskStatusCompleted || t.Status == models.TaskStatusCancelled {
+			continue
+		}
+		form.candidates = append(form.candidates, models.TaskDependency{ID: t.ID, Title: t.Title, Status: t.Status})
+	}
+
 	// Focus first field
 	form.titleInput.Focus()
 
@@ -107,6 +160,15 @@ func (f TaskForm) Update(msg tea.Msg) (TaskForm, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case EditorFinishedMsg:
+		if msg.Err != nil {
+			f.err = msg.Err.Error()
+		} else {
+			f.err = ""
+			f.descriptionInput.SetValue(msg.Content)
+		}
+		return f, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc":
@@ -114,17 +176,24 @@ func (f TaskForm) Update(msg tea.Msg) (TaskForm, tea.Cmd) {
 			f.cancelled = true
 			return f, nil
 
+		case "ctrl+e":
+			// Edit the description in the external editor
+			f.blurAll()
+			f.focusedField = fieldDescription
+			cmd = f.focusField(f.focusedField)
+			return f, tea.Batch(cmd, OpenEditor(f.descriptionInput.Value()))
+
 		case "tab", "down":
 			// Move to next field
 			f.blurAll()
-			f.focusedField = (f.focusedField + 1) % 6
+			f.focusedField = (f.focusedField + 1) % fieldCount
 			cmd = f.focusField(f.focusedField)
 			return f, cmd
 
 		case "shift+tab", "up":
 			// Move to previous field
 			f.blurAll()
-			f.focusedField = (f.focusedField + 5) % 6
+			f.focusedField = (f.focusedField + fieldCount - 1) % fieldCount
 			cmd = f.focusField(f.focusedField)
 			return f, cmd
 
@@ -135,6 +204,18 @@ func (f TaskForm) Update(msg tea.Msg) (TaskForm, tea.Cmd) {
 				}
 				return f, nil
 			}
+			if f.focusedField == fieldCourse {
+				if f.selectedCourse > 0 {
+					f.selectedCourse--
+				}
+				return f, nil
+			}
+			if f.focusedField == fieldDependencies {
+				if f.depCursor > 0 {
+					f.depCursor--
+				}
+				return f, nil
+			}
 
 		case "right":
 			if f.focusedField == fieldPriority {
@@ -143,14 +224,44 @@ func (f TaskForm) Update(msg tea.Msg) (TaskForm, tea.Cmd) {
 				}
 				return f, nil
 			}
+			if f.focusedField == fieldCourse {
+				if f.selectedCourse < len(f.courses) {
+					f.selectedCourse++
+				}
+				return f, nil
+			}
+			if f.focusedField == fieldDependencies {
+				if f.depCursor < len(f.candidates)-1 {
+					f.depCursor++
+				}
+				return f, nil
+			}
+
+		case " ":
+			if f.focusedField == fieldDependencies {
+				if f.depCursor < len(f.candidates) {
+					id := f.candidates[f.depCursor].ID
+					f.selectedDeps[id] = !f.selectedDeps[id]
+				}
+				return f, nil
+			}
 
 		case "enter":
 			if f.focusedField == fieldButtons {
 				// Submit form
 				titleVal := f.titleInput.Value()
 				if titleVal != "" {
+					if field, ok := f.validate(); !ok {
+						// Take the user to the first invalid field
+						f.err = "Please fix the highlighted field"
+						f.blurAll()
+						f.focusedField = field
+						return f, f.focusField(field)
+					}
+					f.err = ""
 					f.submitted = true
 				} else {
+					f.err = "Title is required"
 				}
 				return f, nil
 			}
@@ -164,6 +275,13 @@ func (f TaskForm) Update(msg tea.Msg) (TaskForm, tea.Cmd) {
 		cmd = f.descriptionInput.Update(msg)
 	case fieldDueDate:
 		cmd = f.dueDateInput.Update(msg)
+		f.validateField(fieldDueDate)
+	case fieldRepeat:
+		cmd = f.repeatInput.Update(msg)
+		f.validateField(fieldRepeat)
+	case fieldEstimate:
+		cmd = f.estimateInput.Update(msg)
+		f.validateField(fieldEstimate)
 	case fieldTags:
 		cmd = f.tagsInput.Update(msg)
 	}
@@ -171,6 +289,51 @@ func (f TaskForm) Update(msg tea.Msg) (TaskForm, tea.Cmd) {
 	return f, cmd
 }
 
+// validate checks every field that is parsed, returning the first invalid one
+func (f *TaskForm) validate() (int, bool) {
+	for _, field := range []int{fieldDueDate, fieldRepeat, fieldEstimate} {
+		if !f.validateField(field) {
+			return field, false
+		}
+	}
+	return 0, true
+}
+
+// validateField checks the value of a field as it is typed, showing the error
+// or how the value was understood below it
+func (f *TaskForm) validateField(field int) bool {
+	switch field {
+	case fieldDueDate:
+		_, ok := validateDateInput(&f.dueDateInput, false)
+		return ok
+
+	case fieldRepeat:
+		f.repeatInput.SetError("")
+		f.repeatInput.SetHint("")
+		recurrence, err := models.ParseTaskRecurrence(f.repeatInput.Value())
+		if err != nil {
+			f.repeatInput.SetError(err.Error())
+			return false
+		}
+		if !recurrence.IsEmpty() {
+			f.repeatInput.SetHint(recurrence.Describe())
+		}
+
+	case fieldEstimate:
+		f.estimateInput.SetError("")
+		f.estimateInput.SetHint("")
+		minutes, err := models.ParseMinutes(f.estimateInput.Value())
+		if err != nil {
+			f.estimateInput.SetError(err.Error())
+			return false
+		}
+		if minutes > 0 {
+			f.estimateInput.SetHint(models.FormatMinutes(minutes))
+		}
+	}
+	return true
+}
+
 func (f TaskForm) View() string {
 	var sections []string
 
@@ -196,16 +359,37 @@ func (f TaskForm) View() string {
 	sections = append(sections, f.dueDateInput.View())
 	sections = append(sections, "")
 
+	// Recurrence input
+	sections = append(sections, f.repeatInput.View())
+	sections = append(sections, "")
+
+	// Estimate input
+	sections = append(sections, f.estimateInput.View())
+	sections = append(sections, "")
+
 	// Tags input
 	sections = append(sections, f.tagsInput.View())
 	sections = append(sections, "")
 
+	// Course selector
+	sections = append(sections, f.renderCourseSelector())
+	sections = append(sections, "")
+
 	// Priority selector
 	sections = append(sections, f.renderPrioritySelector())
 	sections = append(sections, "")
 
+	// Prerequisite picker
+	sections = append(sections, f.renderDependencyPicker())
+	sections = append(sections, "")
+
 	// Buttons
 	sections = append(sections, f.renderButtons())
+	if f.err != "" {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(styles.Warning).
+			Render("⚠ "+f.err))
+	}
 	sections = append(sections, "")
 
 	// Help text
@@ -213,7 +397,7 @@ func (f TaskForm) View() string {
 		Foreground(styles.Muted).
 		Italic(true)
 
-	help := helpStyle.Render("Tab: next field  |  Esc: cancel  |  Enter: submit")
+	help := helpStyle.Render("Tab: next field  |  Ctrl+E: $EDITOR  |  Esc: cancel  |  Enter: submit")
 	sections = append(sections, help)
 
 	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
@@ -274,6 +458,83 @@ func (f TaskForm) renderPrioritySelector() string {
 	)
 }
 
+// renderCourseSelector renders the course selection
+func (f TaskForm) renderCourseSelector() string {
+	label := lipgloss.NewStyle().
+		Foreground(styles.Primary).
+		Bold(true).
+		Render("Course:")
+
+	name := "None"
+	if f.selectedCourse > 0 {
+		course := f.courses[f.selectedCourse-1]
+		name = course.Name
+		if course.Code != "" {
+			name = course.Code + " - " + course.Name
+		}
+	}
+
+	style := lipgloss.NewStyle().Padding(0, 1)
+	if f.focusedField == fieldCourse {
+		style = style.
+			Background(styles.Primary).
+			Foreground(styles.Background).
+			Bold(true)
+	} else {
+		style = style.Foreground(styles.Primary)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		label,
+		"◀ "+style.Render(truncate(name, f.width-14))+" ▶",
+	)
+}
+
+// renderDependencyPicker renders the prerequisite selection
+func (f TaskForm) renderDependencyPicker() string {
+	label := lipgloss.NewStyle().
+		Foreground(styles.Primary).
+		Bold(true).
+		Render("Blocked by (optional):")
+
+	if len(f.candidates) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, label, styles.Dimmed.Render("No other open tasks"))
+	}
+
+	candidate := f.candidates[f.depCursor]
+	checkbox := "[ ]"
+	if f.selectedDeps[candidate.ID] {
+		checkbox = "[x]"
+	}
+
+	style := lipgloss.NewStyle().Padding(0, 1)
+	if f.focusedField == fieldDependencies {
+		style = style.
+			Background(styles.Primary).
+			Foreground(styles.Background).
+			Bold(true)
+	} else {
+		style = style.Foreground(styles.Primary)
+	}
+
+	picker := "◀ " + style.Render(checkbox+" "+truncate(candidate.Title, f.width-24)) + " ▶" +
+		styles.Dimmed.Render(fmt.Sprintf("  %d/%d", f.depCursor+1, len(f.candidates)))
+
+	var selected []string
+	for _, dep := range f.candidates {
+		if f.selectedDeps[dep.ID] {
+			selected = append(selected, dep.Title)
+		}
+	}
+	summary := "None selected  •  ←/→ browse, space toggles"
+	if len(selected) > 0 {
+		summary = truncate(strings.Join(selected, ", "), f.width-6)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, label, picker, styles.Dimmed.Render(summary))
+}
+
 // renderButtons renders the action buttons
 func (f TaskForm) renderButtons() string {
 	var submitText string
@@ -314,6 +575,8 @@ func (f *TaskForm) blurAll() {
 	f.titleInput.Blur()
 	f.descriptionInput.Blur()
 	f.dueDateInput.Blur()
+	f.repeatInput.Blur()
+	f.estimateInput.Blur()
 	f.tagsInput.Blur()
 }
 
@@ -326,6 +589,10 @@ func (f *TaskForm) focusField(field int) tea.Cmd {
 		return f.descriptionInput.Focus()
 	case fieldDueDate:
 		return f.dueDateInput.Focus()
+	case fieldRepeat:
+		return f.repeatInput.Focus()
+	case fieldEstimate:
+		return f.estimateInput.Focus()
 	case fieldTags:
 		return f.tagsInput.Focus()
 	}
@@ -357,8 +624,26 @@ func (f TaskForm) GetTask() *models.Task {
 
 	dueDateStr := strings.TrimSpace(f.dueDateInput.Value())
 	if dueDateStr != "" {
-		if dueDate, err := time.ParseInLocation("2006-01-02", dueDateStr, time.Local); err == nil {
-			task.DueDate = &dueDate
+		if result, err := dateparse.Parse(dueDateStr, time.Now()); err == nil {
+			task.DueDate = &result.Time
+		}
+	}
+
+	if recurrence, err := models.ParseTaskRecurrence(f.repeatInput.Value()); err == nil {
+		task.RecurrenceRule = recurrence.String()
+	}
+
+	if minutes, err := models.ParseMinutes(f.estimateInput.Value()); err == nil {
+		task.EstimatedMinutes = minutes
+	}
+
+	if f.selectedCourse > 0 {
+		task.CourseID = f.courses[f.selectedCourse-1].ID
+	}
+
+	for _, dep := range f.candidates {
+		if f.selectedDeps[dep.ID] {
+			task.Dependencies = append(task.Dependencies, dep)
 		}
 	}
 
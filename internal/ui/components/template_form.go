@@ -0,0 +1,358 @@
+package components
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// TemplateForm is a form for creating/editing task templates
+type TemplateForm struct {
+	template      *models.TaskTemplate // Template being edited, nil for a new one
+	nameInput     Input
+	titleInput    Input
+	tagsInput     Input
+	dueInput      Input
+	subtasksInput TextArea
+
+	// Priority selector
+	priorities       []models.TaskPriority
+	selectedPriority int
+
+	// Focus tracking
+	focusedField int
+	submitted    bool
+	cancelled    bool
+	err          string
+
+	width int
+}
+
+const (
+	templateFieldName = iota
+	templateFieldTitle
+	templateFieldPriority
+	templateFieldTags
+	templateFieldDue
+	templateFieldSubtasks
+	templateFieldButtons
+	templateFieldCount
+)
+
+// NewTemplateForm creates a new template form, for a new template if template is nil
+func NewTemplateForm(template *models.TaskTemplate) TemplateForm {
+	form := TemplateForm{
+		template:      template,
+		nameInput:     NewInput("Name:", "e.g. Lab report"),
+		titleInput:    NewInput("Task title ({date}, {week} and {due} are filled in):", "e.g. Lab report week {week}"),
+		tagsInput:     NewInput("Tags (comma-separated):", "e.g. lab, physics"),
+		dueInput:      NewInput("Due (relative to creation, optional):", "e.g. +7d, fri 23:59, next week"),
+		subtasksInput: NewTextArea("Checklist (one subtask per line):", "collect data\nwrite analysis\nformat\nsubmit"),
+		priorities: []models.TaskPriority{
+			models.TaskPriorityLow,
+			models.TaskPriorityMedium,
+			models.TaskPriorityHigh,
+			models.TaskPriorityUrgent,
+		},
+		selectedPriority: 1, // Default to Medium
+		focusedField:     templateFieldName,
+		width:            80,
+	}
+	form.subtasksInput.SetSize(72, 8)
+	form.subtasksInput.SetCharLimit(0)
+
+	if template != nil {
+		form.nameInput.SetValue(template.Name)
+		form.titleInput.SetValue(template.TitlePattern)
+		form.tagsInput.SetValue(strings.Join(template.Tags, ", "))
+		form.dueInput.SetValue(template.DueIn)
+		form.subtasksInput.SetValue(strings.Join(template.Subtasks, "\n"))
+		for i, p := range form.priorities {
+			if p == template.Priority {
+				form.selectedPriority = i
+			}
+		}
+		validateDateInput(&form.dueInput, false)
+	}
+
+	form.nameInput.Focus()
+
+	return form
+}
+
+// Init initializes the form
+func (f TemplateForm) Init() tea.Cmd {
+	return nil
+}
+
+func (f TemplateForm) Update(msg tea.Msg) (TemplateForm, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			f.cancelled = true
+			return f, nil
+
+		case "ctrl+s":
+			f.submit()
+			return f, nil
+
+		case "tab":
+			f.blurAll()
+			f.focusedField = (f.focusedField + 1) % templateFieldCount
+			cmd = f.focusField(f.focusedField)
+			return f, cmd
+
+		case "shift+tab":
+			f.blurAll()
+			f.focusedField = (f.focusedField + templateFieldCount - 1) % templateFieldCount
+			cmd = f.focusField(f.focusedField)
+			return f, cmd
+
+		case "down", "up":
+			// Arrow keys move between lines inside the checklist
+			if f.focusedField != templateFieldSubtasks {
+				f.blurAll()
+				if msg.String() == "down" {
+					f.focusedField = (f.focusedField + 1) % templateFieldCount
+				} else {
+					f.focusedField = (f.focusedField + templateFieldCount - 1) % templateFieldCount
+				}
+				cmd = f.focusField(f.focusedField)
+				return f, cmd
+			}
+
+		case "left":
+			if f.focusedField == templateFieldPriority {
+				if f.selectedPriority > 0 {
+					f.selectedPriority--
+				}
+				return f, nil
+			}
+
+		case "right":
+			if f.focusedField == templateFieldPriority {
+				if f.selectedPriority < len(f.priorities)-1 {
+					f.selectedPriority++
+				}
+				return f, nil
+			}
+
+		case "enter":
+			if f.focusedField == templateFieldButtons {
+				f.submit()
+				return f, nil
+			}
+		}
+	}
+
+	switch f.focusedField {
+	case templateFieldName:
+		cmd = f.nameInput.Update(msg)
+	case templateFieldTitle:
+		cmd = f.titleInput.Update(msg)
+	case templateFieldTags:
+		cmd = f.tagsInput.Update(msg)
+	case templateFieldDue:
+		cmd = f.dueInput.Update(msg)
+		validateDateInput(&f.dueInput, false)
+	case templateFieldSubtasks:
+		cmd = f.subtasksInput.Update(msg)
+	}
+
+	return f, cmd
+}
+
+func (f TemplateForm) View() string {
+	var sections []string
+
+	titleText := " New Template"
+	if f.template != nil {
+		titleText = " Edit Template"
+	}
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Primary).
+		Align(lipgloss.Center).
+		Width(f.width).
+		Render(titleText)
+	sections = append(sections, title, "")
+
+	sections = append(sections, f.nameInput.View(), "")
+	sections = append(sections, f.titleInput.View(), "")
+	sections = append(sections, f.renderPrioritySelector(), "")
+	sections = append(sections, f.tagsInput.View(), "")
+	sections = append(sections, f.dueInput.View(), "")
+	sections = append(sections, f.subtasksInput.View(), "")
+	sections = append(sections, f.renderButtons())
+
+	if f.err != "" {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(styles.Warning).
+			Render("⚠ "+f.err))
+	}
+	sections = append(sections, "")
+
+	help := lipgloss.NewStyle().
+		Foreground(styles.Muted).
+		Italic(true).
+		Render("Tab: next field  |  Ctrl+S: save  |  Esc: cancel")
+	sections = append(sections, help)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Width(f.width)
+
+	return modalStyle.Render(content)
+}
+
+// renderPrioritySelector renders the priority selection
+func (f TemplateForm) renderPrioritySelector() string {
+	label := lipgloss.NewStyle().
+		Foreground(styles.Primary).
+		Bold(true).
+		Render("Priority:")
+
+	style := lipgloss.NewStyle().Padding(0, 1)
+	if f.focusedField == templateFieldPriority {
+		style = style.
+			Background(styles.Primary).
+			Foreground(styles.Background).
+			Bold(true)
+	} else {
+		style = style.Foreground(styles.Primary)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		label,
+		"◀ "+style.Render(f.priorities[f.selectedPriority].String())+" ▶",
+	)
+}
+
+// renderButtons renders the action buttons
+func (f TemplateForm) renderButtons() string {
+	submitText := "[ Create ]"
+	if f.template != nil {
+		submitText = "[ Save ]"
+	}
+
+	submitStyle := lipgloss.NewStyle().
+		Padding(0, 2).
+		Foreground(styles.Success)
+
+	cancelStyle := lipgloss.NewStyle().
+		Padding(0, 2).
+		Foreground(styles.Muted)
+
+	if f.focusedField == templateFieldButtons {
+		submitStyle = submitStyle.
+			Background(styles.Success).
+			Foreground(styles.Background).
+			Bold(true)
+	}
+
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		submitStyle.Render(submitText),
+		"  ",
+		cancelStyle.Render("[ Cancel (Esc) ]"),
+	)
+}
+
+// submit marks the form as submitted if the template is valid
+func (f *TemplateForm) submit() {
+	if _, ok := validateDateInput(&f.dueInput, false); !ok {
+		f.err = "Please fix the due date"
+		f.blurAll()
+		f.focusedField = templateFieldDue
+		f.focusField(f.focusedField)
+		return
+	}
+	if err := f.GetTemplate().Validate(); err != nil {
+		f.err = err.Error()
+		return
+	}
+	f.err = ""
+	f.submitted = true
+}
+
+// blurAll removes focus from all fields
+func (f *TemplateForm) blurAll() {
+	f.nameInput.Blur()
+	f.titleInput.Blur()
+	f.tagsInput.Blur()
+	f.dueInput.Blur()
+	f.subtasksInput.Blur()
+}
+
+// focusField focuses a specific field
+func (f *TemplateForm) focusField(field int) tea.Cmd {
+	switch field {
+	case templateFieldName:
+		return f.nameInput.Focus()
+	case templateFieldTitle:
+		return f.titleInput.Focus()
+	case templateFieldTags:
+		return f.tagsInput.Focus()
+	case templateFieldDue:
+		return f.dueInput.Focus()
+	case templateFieldSubtasks:
+		return f.subtasksInput.Focus()
+	}
+	return nil
+}
+
+// GetTemplate returns the template built from the form
+func (f TemplateForm) GetTemplate() *models.TaskTemplate {
+	template := models.NewTaskTemplate("", "")
+	if f.template != nil {
+		copied := *f.template
+		template = &copied
+	}
+
+	template.Name = strings.TrimSpace(f.nameInput.Value())
+	template.TitlePattern = strings.TrimSpace(f.titleInput.Value())
+	template.Priority = f.priorities[f.selectedPriority]
+	template.DueIn = strings.TrimSpace(f.dueInput.Value())
+
+	template.Tags = []string{}
+	for _, tag := range strings.Split(f.tagsInput.Value(), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			template.Tags = append(template.Tags, tag)
+		}
+	}
+
+	template.Subtasks = []string{}
+	for _, line := range strings.Split(f.subtasksInput.Value(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			template.Subtasks = append(template.Subtasks, line)
+		}
+	}
+
+	return template
+}
+
+// IsSubmitted returns true if form was submitted
+func (f TemplateForm) IsSubmitted() bool {
+	return f.submitted
+}
+
+// IsCancelled returns true if form was cancelled
+func (f TemplateForm) IsCancelled() bool {
+	return f.cancelled
+}
+
+// IsNewTemplate returns true if this is a new template (not editing existing)
+func (f TemplateForm) IsNewTemplate() bool {
+	return f.template == nil
+}
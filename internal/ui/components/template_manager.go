@@ -0,0 +1,276 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/database"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// TemplateManager lists the task templates, edits them and picks one to
+// create a task from
+type TemplateManager struct {
+	db        *database.DB
+	templates []models.TaskTemplate
+	cursor    int
+	mode      managerMode
+	form      TemplateForm
+	confirm   bool // Confirming the deletion of the template under the cursor
+	chosen    *models.TaskTemplate
+	closed    bool
+	err       string
+}
+
+// TemplatesMsg carries the task templates loaded by the template manager
+type TemplatesMsg struct {
+	Templates []models.TaskTemplate
+	Err       error
+}
+
+// NewTemplateManager creates a new template manager
+func NewTemplateManager(db *database.DB) *TemplateManager {
+	return &TemplateManager{
+		db:   db,
+		mode: modeList,
+	}
+}
+
+// Init loads the templates
+func (m *TemplateManager) Init() tea.Cmd {
+	return m.fetchTemplates
+}
+
+func (m *TemplateManager) fetchTemplates() tea.Msg {
+	templates, err := m.db.TaskTemplates().FindAll()
+	return TemplatesMsg{Templates: templates, Err: err}
+}
+
+// saveTemplate creates or updates a template and loads the templates again
+func (m *TemplateManager) saveTemplate(template *models.TaskTemplate, isNew bool) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if isNew {
+			err = m.db.TaskTemplates().Create(template)
+		} else {
+			err = m.db.TaskTemplates().Update(template)
+		}
+		if err != nil {
+			return TemplatesMsg{Templates: m.templates, Err: fmt.Errorf("could not save template: %w", err)}
+		}
+		return m.fetchTemplates()
+	}
+}
+
+// deleteTemplate deletes a template and loads the templates again
+func (m *TemplateManager) deleteTemplate(id string) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.db.TaskTemplates().Delete(id); err != nil {
+			return TemplatesMsg{Templates: m.templates, Err: fmt.Errorf("could not delete template: %w", err)}
+		}
+		return m.fetchTemplates()
+	}
+}
+
+func (m *TemplateManager) Update(msg tea.Msg) (*TemplateManager, tea.Cmd) {
+	if msg, ok := msg.(TemplatesMsg); ok {
+		m.templates = msg.Templates
+		m.err = ""
+		if msg.Err != nil {
+			m.err = msg.Err.Error()
+		}
+		if m.cursor >= len(m.templates) {
+			m.cursor = max(len(m.templates)-1, 0)
+		}
+		return m, nil
+	}
+
+	if m.mode == modeForm {
+		return m.updateForm(msg)
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.confirm {
+		switch keyMsg.String() {
+		case "y", "Y":
+			m.confirm = false
+			if template := m.current(); template != nil {
+				return m, m.deleteTemplate(template.ID)
+			}
+		case "n", "N", "esc":
+			m.confirm = false
+		}
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "q":
+		m.closed = true
+	case "j", "down":
+		if m.cursor < len(m.templates)-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "enter":
+		if template := m.current(); template != nil {
+			chosen := *template
+			m.chosen = &chosen
+			m.closed = true
+		}
+	case "n":
+		m.mode = modeForm
+		m.form = NewTemplateForm(nil)
+		return m, m.form.Init()
+	case "e":
+		if template := m.current(); template != nil {
+			edited := *template
+			m.mode = modeForm
+			m.form = NewTemplateForm(&edited)
+			return m, m.form.Init()
+		}
+	case "d", "delete":
+		if m.current() != nil {
+			m.confirm = true
+		}
+	}
+
+	return m, nil
+}
+
+func (m *TemplateManager) updateForm(msg tea.Msg) (*TemplateManager, tea.Cmd) {
+	var cmd tea.Cmd
+	m.form, cmd = m.form.Update(msg)
+
+	if m.form.IsCancelled() {
+		m.mode = modeList
+		return m, nil
+	}
+
+	if m.form.IsSubmitted() {
+		m.mode = modeList
+		return m, m.saveTemplate(m.form.GetTemplate(), m.form.IsNewTemplate())
+	}
+
+	return m, cmd
+}
+
+// current returns the template under the cursor
+func (m *TemplateManager) current() *models.TaskTemplate {
+	if m.cursor < len(m.templates) {
+		return &m.templates[m.cursor]
+	}
+	return nil
+}
+
+func (m *TemplateManager) View() string {
+	if m.mode == modeForm {
+		return m.form.View()
+	}
+
+	var sections []string
+
+	title := styles.Title.
+		Align(lipgloss.Center).
+		Render("󰈙 Task Templates")
+	sections = append(sections, title, "")
+
+	if len(m.templates) == 0 {
+		sections = append(sections, styles.Dimmed.Render("No templates yet. Press n to create one."))
+	}
+
+	now := time.Now()
+	for i, template := range m.templates {
+		name := template.Name
+		if i == m.cursor {
+			name = lipgloss.NewStyle().
+				Background(styles.SelectedBackground).
+				Foreground(styles.SelectedForeground).
+				Render(name)
+		}
+		sections = append(sections, name+"  "+styles.Dimmed.Render(fmt.Sprintf("%d subtasks", len(template.Subtasks))))
+	}
+
+	if template := m.current(); template != nil {
+		sections = append(sections, "", m.renderPreview(*template, now))
+	}
+
+	if m.confirm {
+		sections = append(sections, "", lipgloss.NewStyle().
+			Foreground(styles.Danger).
+			Render(fmt.Sprintf("Delete template \"%s\"? y/n", m.current().Name)))
+	}
+	if m.err != "" {
+		sections = append(sections, "", lipgloss.NewStyle().
+			Foreground(styles.Danger).
+			Render("⚠ "+m.err))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Width(70)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
+	return lipgloss.JoinVertical(lipgloss.Left, boxStyle.Render(content), m.renderShortcuts())
+}
+
+// renderPreview shows the task the template would create now
+func (m *TemplateManager) renderPreview(template models.TaskTemplate, now time.Time) string {
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Muted)
+
+	due, err := template.DueDate(now)
+	if err != nil {
+		return lipgloss.NewStyle().Foreground(styles.Danger).Render("⚠ " + err.Error())
+	}
+
+	lines := []string{
+		labelStyle.Render("Creates") + ": " + template.ExpandTitle(now, due),
+		labelStyle.Render("Priority") + ": " + template.Priority.String(),
+	}
+	if due != nil {
+		lines = append(lines, labelStyle.Render("Due")+": "+due.Format("Mon, 02 Jan 15:04")+styles.Dimmed.Render(" ("+template.DueIn+")"))
+	}
+	if len(template.Tags) > 0 {
+		lines = append(lines, labelStyle.Render("Tags")+": "+strings.Join(template.Tags, ", "))
+	}
+	for _, subtask := range template.Subtasks {
+		lines = append(lines, "  ☐ "+subtask)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (m *TemplateManager) renderShortcuts() string {
+	shortcuts := []string{
+		styles.Shortcut.Render("enter") + styles.ShortcutText.Render(" create task"),
+		styles.Shortcut.Render("j/k") + styles.ShortcutText.Render(" navigate"),
+		styles.Shortcut.Render("n") + styles.ShortcutText.Render(" new"),
+		styles.Shortcut.Render("e") + styles.ShortcutText.Render(" edit"),
+		styles.Shortcut.Render("d") + styles.ShortcutText.Render(" delete"),
+		styles.Shortcut.Render("esc") + styles.ShortcutText.Render(" close"),
+	}
+	return strings.Join(shortcuts, "  ")
+}
+
+// Chosen returns the template picked to create a task from, nil if the
+// manager was closed without picking one
+func (m *TemplateManager) Chosen() *models.TaskTemplate {
+	return m.chosen
+}
+
+// IsClosed returns true once the manager was closed
+func (m *TemplateManager) IsClosed() bool {
+	return m.closed
+}
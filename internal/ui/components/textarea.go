@@ -48,6 +48,17 @@ func (t *TextArea) Value() string {
 	return t.textarea.Value()
 }
 
+// SetSize sets the width and height of the textarea
+func (t *TextArea) SetSize(width, height int) {
+	t.textarea.SetWidth(width)
+	t.textarea.SetHeight(height)
+}
+
+// SetCharLimit sets the maximum number of characters, 0 means no limit
+func (t *TextArea) SetCharLimit(limit int) {
+	t.textarea.CharLimit = limit
+}
+
 func (t *TextArea) Update(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
 	t.textarea, cmd = t.textarea.Update(msg)
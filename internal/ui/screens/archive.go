@@ -0,0 +1,463 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/database"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/components"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// ArchiveScreen lists archived tasks, which can be searched with a filter
+// query and moved back to the board
+type ArchiveScreen struct {
+	db            *database.DB
+	tasks         []models.Task
+	courses       []models.Course
+	selectedIndex int
+	width         int
+	height        int
+	loading       bool
+	err           error
+	feedbackMsg   string
+	jumpTaskID    string // Task to select once the archive is loaded
+
+	// Filter state
+	filter      models.TaskFilter
+	isFiltering bool
+	filterInput components.Input
+	filterErr   string
+
+	showDeleteConfirm bool
+}
+
+// NewArchiveScreen creates a new archive screen
+func NewArchiveScreen(db *database.DB) *ArchiveScreen {
+	return &ArchiveScreen{
+		db:      db,
+		loading: true,
+	}
+}
+
+// Init loads the archive
+func (s *ArchiveScreen) Init() tea.Cmd {
+	return s.loadArchive()
+}
+
+func (s *ArchiveScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		s.height = msg.Height
+		return s, nil
+
+	case archiveLoadedMsg:
+		s.loading = false
+		s.err = msg.err
+		if msg.err == nil {
+			s.tasks = msg.tasks
+			s.courses = msg.courses
+		}
+		if s.jumpTaskID != "" {
+			for i, task := range s.tasks {
+				if task.ID == s.jumpTaskID {
+					s.selectedIndex = i
+				}
+			}
+			s.jumpTaskID = ""
+		}
+		if s.selectedIndex >= len(s.tasks) {
+			s.selectedIndex = max(0, len(s.tasks)-1)
+		}
+		return s, nil
+
+	case archiveChangedMsg:
+		if msg.err != nil {
+			return s, s.showFeedback(fmt.Sprintf("Could not %s: %v", msg.text, msg.err), styles.Danger)
+		}
+		return s, tea.Batch(s.showFeedback(msg.text, styles.Success), s.loadArchive())
+
+	case JumpToMsg:
+		// Search results may be hidden by the filter
+		s.showDeleteConfirm = false
+		s.isFiltering = false
+		s.filter = models.TaskFilter{}
+		s.jumpTaskID = msg.ID
+		return s, s.loadArchive()
+
+	case clearFeedbackMsg:
+		s.feedbackMsg = ""
+		return s, nil
+
+	case tea.KeyMsg:
+		if s.showDeleteConfirm {
+			switch msg.String() {
+			case "y", "Y":
+				s.showDeleteConfirm = false
+				if task := s.selectedTask(); task != nil {
+					return s, s.deleteTask(*task)
+				}
+			case "n", "N", "esc":
+				s.showDeleteConfirm = false
+			}
+			return s, nil
+		}
+
+		if s.isFiltering {
+			switch msg.String() {
+			case "enter":
+				filter, err := models.ParseTaskFilter(s.filterInput.Value(), time.Now())
+				if err != nil {
+					s.filterErr = err.Error()
+					return s, nil
+				}
+				s.isFiltering = false
+				s.filter = filter
+				s.selectedIndex = 0
+				return s, s.loadArchive()
+			case "esc":
+				s.isFiltering = false
+				return s, nil
+			}
+			s.filterErr = ""
+			return s, s.filterInput.Update(msg)
+		}
+
+		switch msg.String() {
+		case "j", "down":
+			if s.selectedIndex < len(s.tasks)-1 {
+				s.selectedIndex++
+			}
+		case "k", "up":
+			if s.selectedIndex > 0 {
+				s.selectedIndex--
+			}
+		case "g":
+			s.selectedIndex = 0
+		case "G":
+			s.selectedIndex = max(0, len(s.tasks)-1)
+		case "f":
+			s.isFiltering = true
+			s.filterErr = ""
+			s.filterInput = components.NewInput("Search:", `e.g. lab report course:CS101 due:>2025-01-01`)
+			s.filterInput.SetValue(s.filter.Query)
+			return s, s.filterInput.Focus()
+		case "F":
+			if !s.filter.IsEmpty() {
+				s.filter = models.TaskFilter{}
+				return s, s.loadArchive()
+			}
+		case "a", "enter":
+			if task := s.selectedTask(); task != nil {
+				return s, s.unarchiveTask(*task)
+			}
+		case "d", "delete":
+			if s.selectedTask() != nil {
+				s.showDeleteConfirm = true
+			}
+		case "r":
+			return s, s.loadArchive()
+		}
+	}
+
+	return s, nil
+}
+
+func (s *ArchiveScreen) View() string {
+	if s.width == 0 || s.height == 0 || s.loading {
+		return lipgloss.NewStyle().
+			Padding(2).
+			Foreground(styles.Info).
+			Render("Loading archive...")
+	}
+
+	if s.err != nil {
+		return lipgloss.NewStyle().
+			Padding(2).
+			Foreground(styles.Danger).
+			Render(fmt.Sprintf("Error: %v\n\nPress 'r' to retry", s.err))
+	}
+
+	if s.showDeleteConfirm {
+		return s.renderDeleteConfirmDialog()
+	}
+
+	subtitle := fmt.Sprintf("%d tasks", len(s.tasks))
+	if !s.filter.IsEmpty() {
+		subtitle = fmt.Sprintf("%d matching %s", len(s.tasks), s.filter.Query)
+	}
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Primary).
+		Padding(1, 0).
+		Render(" Archive " + styles.Dimmed.Render("· "+subtitle))
+
+	listWidth := max(30, s.width*2/5)
+	detailsWidth := max(30, s.width-listWidth-6)
+	paneHeight := s.height - 9
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			s.renderListPane(listWidth, paneHeight),
+			s.renderDetailsPane(detailsWidth, paneHeight),
+		),
+		"",
+		s.renderShortcuts(),
+	)
+}
+
+// renderListPane renders the archived tasks
+func (s *ArchiveScreen) renderListPane(width, height int) string {
+	var lines []string
+	if len(s.tasks) == 0 {
+		if s.filter.IsEmpty() {
+			lines = append(lines, styles.Dimmed.Render("No archived tasks. Press 'a' on the board to archive one."))
+		} else {
+			lines = append(lines, styles.Dimmed.Render("No archived tasks match the search."))
+		}
+	}
+
+	// Keep the selected task visible
+	visible := max(1, height/2)
+	start := 0
+	if s.selectedIndex >= visible {
+		start = s.selectedIndex - visible + 1
+	}
+
+	for i := start; i < len(s.tasks) && i < start+visible; i++ {
+		task := s.tasks[i]
+
+		icon := "○"
+		if task.Status == models.TaskStatusCompleted {
+			icon = "✓"
+		}
+		title := truncate(icon+" "+task.Title, width-4)
+
+		meta := "archived " + task.ArchivedAt.Format("Jan 02, 2006")
+		if course := s.courseByID(task.CourseID); course != nil {
+			meta = courseLabel(course) + " · " + meta
+		}
+
+		titleStyle := lipgloss.NewStyle().Padding(0, 1).Width(width - 2)
+		metaStyle := lipgloss.NewStyle().Padding(0, 1).Width(width - 2).Foreground(styles.Muted)
+		if i == s.selectedIndex {
+			titleStyle = titleStyle.Background(styles.Primary).Foreground(styles.Background).Bold(true)
+			metaStyle = metaStyle.Background(styles.Primary).Foreground(styles.BackgroundLight)
+		}
+
+		lines = append(lines, titleStyle.Render(title), metaStyle.Render(truncate(meta, width-4)))
+	}
+
+	return styles.Panel.Width(width).Height(height).BorderForeground(styles.Primary).Render(strings.Join(lines, "\n"))
+}
+
+// renderDetailsPane renders the fields and description of the selected task
+func (s *ArchiveScreen) renderDetailsPane(width, height int) string {
+	paneStyle := styles.Panel.Width(width).Height(height)
+
+	task := s.selectedTask()
+	if task == nil {
+		return paneStyle.Render(styles.Dimmed.Render("Select a task to see its details."))
+	}
+
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary).Width(11)
+	field := func(label, value string) string {
+		return labelStyle.Render(label) + value
+	}
+
+	lines := []string{
+		styles.Title.Render(task.Title),
+		"",
+		field("Status", task.Status.String()),
+		field("Priority", task.Priority.String()),
+	}
+	if course := s.courseByID(task.CourseID); course != nil {
+		lines = append(lines, field("Course", course.Name))
+	}
+	if task.DueDate != nil {
+		lines = append(lines, field("Due", task.DueDate.Format("Jan 02, 2006 15:04")))
+	}
+	if task.CompletedAt != nil {
+		lines = append(lines, field("Completed", task.CompletedAt.Format("Jan 02, 2006 15:04")))
+	}
+	lines = append(lines, field("Archived", task.ArchivedAt.Format("Jan 02, 2006 15:04")))
+	if tracked := task.TrackedTime(nil); tracked > 0 {
+		lines = append(lines, field("Tracked", models.FormatMinutes(int(tracked.Minutes()))))
+	}
+	if len(task.Subtasks) > 0 {
+		lines = append(lines, field("Subtasks", task.CompletionRatio()))
+	}
+	if len(task.Tags) > 0 {
+		var tags []string
+		for _, tag := range task.Tags {
+			tags = append(tags, styles.Tag.Render("#"+tag))
+		}
+		lines = append(lines, strings.Join(tags, " "))
+	}
+
+	if strings.TrimSpace(task.Description) != "" {
+		lines = append(lines, "", components.RenderMarkdown(task.Description, width-4))
+	}
+
+	// The description is cut at the bottom of the pane
+	content := strings.Split(strings.Join(lines, "\n"), "\n")
+	if len(content) > height-2 {
+		content = content[:max(0, height-2)]
+	}
+
+	return paneStyle.Render(strings.Join(content, "\n"))
+}
+
+// renderDeleteConfirmDialog renders the delete confirmation dialog
+func (s *ArchiveScreen) renderDeleteConfirmDialog() string {
+	task := s.selectedTask()
+	if task == nil {
+		return ""
+	}
+
+	dialog := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Danger).
+		Padding(1, 2).
+		Render(lipgloss.JoinVertical(
+			lipgloss.Center,
+			styles.Title.Render(fmt.Sprintf("Delete task \"%s\"?", task.Title)),
+			"",
+			styles.Dimmed.Render("It can be restored from the trash."),
+			"",
+			lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				styles.Shortcut.Render("y")+styles.ShortcutText.Render(" delete"),
+				"  ",
+				styles.Shortcut.Render("n")+styles.ShortcutText.Render(" cancel"),
+			),
+		))
+
+	return lipgloss.Place(
+		s.width,
+		s.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
+
+// renderShortcuts renders keyboard shortcuts, the search prompt or a feedback
+// message
+func (s *ArchiveScreen) renderShortcuts() string {
+	if s.feedbackMsg != "" {
+		return s.feedbackMsg
+	}
+
+	if s.isFiltering {
+		status := styles.Dimmed.Render("words match title and description  •  fields: priority tag category course status due  •  enter search  esc cancel")
+		if s.filterErr != "" {
+			status = lipgloss.NewStyle().Foreground(styles.Danger).Render("⚠ " + s.filterErr)
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, s.filterInput.ViewInline(), status)
+	}
+
+	shortcuts := []string{
+		styles.Shortcut.Render("j/k") + styles.ShortcutText.Render(" navigate"),
+		styles.Shortcut.Render("f") + styles.ShortcutText.Render(" search"),
+		styles.Shortcut.Render("a") + styles.ShortcutText.Render(" back to board"),
+		styles.Shortcut.Render("d") + styles.ShortcutText.Render(" delete"),
+		styles.Shortcut.Render("r") + styles.ShortcutText.Render(" refresh"),
+	}
+	if !s.filter.IsEmpty() {
+		shortcuts = append(shortcuts, styles.Shortcut.Render("F")+styles.ShortcutText.Render(" clear search"))
+	}
+
+	return strings.Join(shortcuts, "  ")
+}
+
+// showFeedback shows a temporary feedback message in the shortcuts bar
+func (s *ArchiveScreen) showFeedback(text string, color lipgloss.Color) tea.Cmd {
+	s.feedbackMsg = lipgloss.NewStyle().Foreground(color).Render(text)
+	return tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return clearFeedbackMsg{} })
+}
+
+// selectedTask returns the task under the cursor
+func (s *ArchiveScreen) selectedTask() *models.Task {
+	if s.selectedIndex < 0 || s.selectedIndex >= len(s.tasks) {
+		return nil
+	}
+	return &s.tasks[s.selectedIndex]
+}
+
+// courseByID returns the course with the given ID, nil if there is none
+func (s *ArchiveScreen) courseByID(id string) *models.Course {
+	if id == "" {
+		return nil
+	}
+	for i := range s.courses {
+		if s.courses[i].ID == id {
+			return &s.courses[i]
+		}
+	}
+	return nil
+}
+
+// IsFilterActive returns true while the search prompt has focus
+func (s *ArchiveScreen) IsFilterActive() bool {
+	return s.isFiltering
+}
+
+// loadArchive loads the archived tasks matching the filter
+func (s *ArchiveScreen) loadArchive() tea.Cmd {
+	filter := s.filter
+	return func() tea.Msg {
+		tasks, err := s.db.Tasks().FindArchived(filter)
+		if err != nil {
+			return archiveLoadedMsg{err: err}
+		}
+		courses, err := s.db.Courses().GetAll()
+		return archiveLoadedMsg{tasks: tasks, courses: courses, err: err}
+	}
+}
+
+// unarchiveTask moves a task back to the board
+func (s *ArchiveScreen) unarchiveTask(task models.Task) tea.Cmd {
+	return func() tea.Msg {
+		if err := s.db.Tasks().Unarchive(task.ID); err != nil {
+			return archiveChangedMsg{text: "unarchive the task", err: err}
+		}
+		recordArchive(s.db, task.ID, task.Title, false)
+		return archiveChangedMsg{text: fmt.Sprintf("Moved \"%s\" back to the board", task.Title)}
+	}
+}
+
+// deleteTask moves an archived task to the trash
+func (s *ArchiveScreen) deleteTask(task models.Task) tea.Cmd {
+	return func() tea.Msg {
+		if err := s.db.Tasks().Delete(task.ID); err != nil {
+			return archiveChangedMsg{text: "delete the task", err: err}
+		}
+		recordDelete(s.db, models.SearchKindTask, task.ID, task.Title, func() error {
+			return s.db.Tasks().Delete(task.ID)
+		})
+		return archiveChangedMsg{text: fmt.Sprintf("Moved \"%s\" to the trash", task.Title)}
+	}
+}
+
+// Messages
+type archiveLoadedMsg struct {
+	tasks   []models.Task
+	courses []models.Course
+	err     error
+}
+
+// archiveChangedMsg reports an unarchive or delete. text is the
+// confirmation, or the failed action if err is set.
+type archiveChangedMsg struct {
+	text string
+	err  error
+}
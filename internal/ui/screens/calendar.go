@@ -33,6 +33,7 @@ type CalendarScreen struct {
 	weekView            *WeekView
 	showDayView         bool
 	dayView             *DayView
+	jumpEventID         string // Event to select once the month is loaded
 }
 
 func NewCalendarScreen(db *database.DB) tea.Model {
@@ -96,6 +97,19 @@ func (m CalendarScreen) fetchCategoriesCmd() tea.Cmd {
 type calendarItemsFetchedMsg []models.CalendarItem
 type categoriesFetchedMsg []models.Category
 
+// calendarJumpMsg carries the event to reveal after a JumpToMsg
+type calendarJumpMsg struct {
+	event *models.Event
+	err   error
+}
+
+func (m CalendarScreen) fetchJumpEventCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		event, err := m.db.Events().FindByID(id)
+		return calendarJumpMsg{event: event, err: err}
+	}
+}
+
 type errMsg struct {
 	err error
 }
@@ -104,7 +118,7 @@ func (e errMsg) Error() string { return e.err.Error() }
 
 func (m CalendarScreen) deleteEvent(eventID string) tea.Cmd {
 	return func() tea.Msg {
-		err := m.db.Events().Delete(eventID)
+		err := deleteEventRecorded(m.db, eventID)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -148,9 +162,43 @@ func (m CalendarScreen) IsDayViewEventFormActive() bool {
 	return false
 }
 
+// IsCategoryManagerActive returns true if the category manager is open in the
+// month, week or day view
+func (m CalendarScreen) IsCategoryManagerActive() bool {
+	if m.showCategoryManager {
+		return true
+	}
+	if m.showWeekView && m.weekView != nil && m.weekView.showCategoryManager {
+		return true
+	}
+	return m.showDayView && m.dayView != nil && m.dayView.showCategoryManager
+}
+
 func (m CalendarScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	switch msg := msg.(type) {
+	case JumpToMsg:
+		return m, m.fetchJumpEventCmd(msg.ID)
+	case calendarJumpMsg:
+		if msg.err != nil || msg.event == nil {
+			return m, nil
+		}
+		// Open the day of the event with the event selected
+		start := msg.event.StartDatetime
+		m.showCategoryManager = false
+		m.showWeekView = false
+		m.showDayView = false
+		m.showEventForm = false
+		m.showDeleteConfirm = false
+		m.currentDate = start
+		m.selectedDay = start.Day()
+		m.showDayDetails = true
+		m.selectedItemIndex = 0
+		m.jumpEventID = msg.event.ID
+		return m, tea.Batch(m.fetchCalendarItemsCmd(), m.fetchCategoriesCmd())
+	}
+
 	if m.showCategoryManager {
 		newModel, newCmd := m.categoryManager.Update(msg)
 		m.categoryManager = newModel.(*components.CategoryManager)
@@ -199,6 +247,11 @@ func (m CalendarScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+	case components.EditorFinishedMsg:
+		if m.showEventForm {
+			m.eventForm, cmd = m.eventForm.Update(msg)
+			return m, cmd
+		}
 	case tea.KeyMsg:
 		if m.showDeleteConfirm {
 			switch msg.String() {
@@ -329,6 +382,15 @@ func (m CalendarScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
+		if m.jumpEventID != "" {
+			for i, item := range m.getItemsForSelectedDay() {
+				if item.GetID() == m.jumpEventID {
+					m.selectedItemIndex = i
+					break
+				}
+			}
+			m.jumpEventID = ""
+		}
 		return m, nil
 	case categoriesFetchedMsg:
 		m.categories = msg
@@ -351,7 +413,7 @@ func (m CalendarScreen) createEvent(event *models.Event) tea.Cmd {
 
 func (m CalendarScreen) updateEvent(event *models.Event) tea.Cmd {
 	return func() tea.Msg {
-		err := m.db.Events().Update(event)
+		err := updateEventRecorded(m.db, event)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -411,7 +473,7 @@ func (m CalendarScreen) renderDeleteConfirmDialog(baseView string) string {
 			lipgloss.Center,
 			styles.Title.Render(question),
 			"",
-			styles.Dimmed.Render("This action cannot be undone."),
+			styles.Dimmed.Render("It can be restored from the trash."),
 			"",
 			lipgloss.JoinHorizontal(
 				lipgloss.Top,
@@ -586,6 +648,9 @@ func (m CalendarScreen) renderDayDetails() string {
 				}
 				icon = lipgloss.NewStyle().Foreground(color).Render("")
 				itemString = fmt.Sprintf("%s %s (%s)", icon, item.GetTitle(), item.GetStartTime().Format("15:04"))
+				if event.Location != "" {
+					itemString += " @ " + event.Location
+				}
 			}
 
 			if i == m.selectedItemIndex {
@@ -594,6 +659,25 @@ func (m CalendarScreen) renderDayDetails() string {
 			itemStrings = append(itemStrings, itemString)
 		}
 		detailsContent = lipgloss.JoinVertical(lipgloss.Left, itemStrings...)
+
+		// Description of the selected item
+		if m.selectedItemIndex >= 0 && m.selectedItemIndex < len(itemsForSelectedDay) {
+			var description string
+			switch item := itemsForSelectedDay[m.selectedItemIndex].(type) {
+			case *models.Event:
+				description = item.Description
+			case *models.Task:
+				description = item.Description
+			}
+			if strings.TrimSpace(description) != "" {
+				detailsContent = lipgloss.JoinVertical(
+					lipgloss.Left,
+					detailsContent,
+					"",
+					components.RenderMarkdown(description, m.width/2-6),
+				)
+			}
+		}
 	} else {
 		detailsContent = "No items for this day."
 	}
@@ -23,6 +23,8 @@ type CoursesScreen struct {
 	showDeleteConfirm bool
 	courseForm        *components.CourseForm
 	err               error
+	jumpCourseID      string                   // Course to select once courses are loaded
+	courseTasks       map[string][]models.Task // Outstanding tasks per course ID
 }
 
 // NewCoursesScreen creates a new courses screen
@@ -42,6 +44,13 @@ func (m CoursesScreen) Init() tea.Cmd {
 func (m CoursesScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if jump, ok := msg.(JumpToMsg); ok {
+		m.showForm = false
+		m.showDeleteConfirm = false
+		m.jumpCourseID = jump.ID
+		return m, m.fetchCoursesCmd()
+	}
+
 	if m.showForm {
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
@@ -52,7 +61,7 @@ func (m CoursesScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case components.FormSubmitMsg:
 			m.showForm = false
-			return m, m.fetchCoursesCmd()
+			return m, m.saveCourseCmd(msg.Course, msg.IsEdit)
 		}
 
 		var newForm *components.CourseForm
@@ -99,13 +108,13 @@ func (m CoursesScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "n":
 			// New course
 			m.showForm = true
-			m.courseForm = components.NewCourseForm(m.db, nil)
+			m.courseForm = components.NewCourseForm(nil)
 			cmd = m.courseForm.Init()
 		case "e":
 			// Edit course
 			if m.selectedIndex >= 0 && m.selectedIndex < len(m.courses) {
 				m.showForm = true
-				m.courseForm = components.NewCourseForm(m.db, &m.courses[m.selectedIndex])
+				m.courseForm = components.NewCourseForm(&m.courses[m.selectedIndex])
 				cmd = m.courseForm.Init()
 			}
 		case "d":
@@ -115,13 +124,14 @@ func (m CoursesScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			if m.selectedIndex >= 0 && m.selectedIndex < len(m.courses) {
 				m.showForm = true
-				m.courseForm = components.NewCourseForm(m.db, &m.courses[m.selectedIndex])
+				m.courseForm = components.NewCourseForm(&m.courses[m.selectedIndex])
 				cmd = m.courseForm.Init()
 			}
 		}
 
 	case fetchCoursesMsg:
 		m.courses = msg.courses
+		m.courseTasks = msg.tasks
 		m.err = msg.err
 		if m.selectedIndex >= len(m.courses) {
 			m.selectedIndex = len(m.courses) - 1
@@ -129,6 +139,15 @@ func (m CoursesScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.selectedIndex = 0
 			}
 		}
+		if m.jumpCourseID != "" {
+			for i, course := range m.courses {
+				if course.ID == m.jumpCourseID {
+					m.selectedIndex = i
+					break
+				}
+			}
+			m.jumpCourseID = ""
+		}
 	}
 
 	return m, cmd
@@ -153,6 +172,16 @@ func (m CoursesScreen) View() string {
 	list := m.renderCourseList()
 	shortcuts := m.renderShortcuts()
 
+	// Outstanding tasks of the selected course next to the list
+	if m.width >= 90 && len(m.courses) > 0 {
+		listWidth := m.width / 2
+		list = lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			lipgloss.NewStyle().Width(listWidth).Render(list),
+			m.renderCourseTasks(m.width-listWidth-4, m.height-10),
+		)
+	}
+
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		title,
@@ -234,6 +263,9 @@ func (m CoursesScreen) renderCourseItem(course models.Course, isSelected bool) s
 	if course.Credits > 0 {
 		details = append(details, fmt.Sprintf("%d credits", course.Credits))
 	}
+	if open := len(m.courseTasks[course.ID]); open > 0 {
+		details = append(details, fmt.Sprintf("%d open tasks", open))
+	}
 	detailsStr := strings.Join(details, " • ")
 
 	// Schedule summary
@@ -276,6 +308,50 @@ func (m CoursesScreen) renderCourseItem(course models.Course, isSelected bool) s
 		Render(strings.Join(lines, "\n"))
 }
 
+// renderCourseTasks renders the outstanding tasks of the selected course
+func (m CoursesScreen) renderCourseTasks(width, height int) string {
+	course := m.courses[m.selectedIndex]
+	tasks := m.courseTasks[course.ID]
+
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Primary).
+		Render(fmt.Sprintf(" Outstanding tasks (%d)", len(tasks)))
+
+	lines := []string{header, ""}
+	if len(tasks) == 0 {
+		lines = append(lines, styles.Dimmed.Render("Nothing due for this course"))
+	}
+	for i, task := range tasks {
+		if len(lines) >= height-4 {
+			lines = append(lines, styles.Dimmed.Render(fmt.Sprintf("… %d more", len(tasks)-i)))
+			break
+		}
+
+		due := styles.Dimmed.Render("no due date")
+		if task.DueDate != nil {
+			dueStyle := lipgloss.NewStyle().Foreground(styles.Success)
+			if task.IsOverdue() {
+				dueStyle = dueStyle.Foreground(styles.Danger)
+			} else if task.IsDueToday() {
+				dueStyle = dueStyle.Foreground(styles.AutumnYellow)
+			}
+			due = dueStyle.Render(task.DueDate.Format("Jan 02"))
+		}
+
+		marker := "○"
+		if task.Status == models.TaskStatusInProgress {
+			marker = lipgloss.NewStyle().Foreground(styles.Warning).Render("◐")
+		}
+
+		lines = append(lines, fmt.Sprintf("%s %s  %s", marker, truncate(task.Title, max(2, width-16)), due))
+	}
+
+	return styles.Panel.
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
+
 // renderShortcuts renders the keyboard shortcuts
 func (m CoursesScreen) renderShortcuts() string {
 	shortcuts := []string{
@@ -308,7 +384,7 @@ func (m CoursesScreen) renderDeleteConfirmation() string {
 			lipgloss.Left,
 			styles.Title.Render(question),
 			"",
-			styles.Dimmed.Render("This action cannot be undone."),
+			styles.Dimmed.Render("It can be restored from the trash."),
 			"",
 			lipgloss.JoinHorizontal(
 				lipgloss.Top,
@@ -328,29 +404,62 @@ func (m CoursesScreen) renderDeleteConfirmation() string {
 
 type fetchCoursesMsg struct {
 	courses []models.Course
+	tasks   map[string][]models.Task
 	err     error
 }
 
 func (m CoursesScreen) fetchCoursesCmd() tea.Cmd {
 	return func() tea.Msg {
 		courses, err := m.db.Courses().GetAll()
+		if err != nil {
+			return fetchCoursesMsg{err: err}
+		}
+
+		tasks := make(map[string][]models.Task)
+		for _, course := range courses {
+			courseTasks, err := m.db.Tasks().FindOutstandingByCourse(course.ID)
+			if err != nil {
+				return fetchCoursesMsg{courses: courses, err: err}
+			}
+			tasks[course.ID] = courseTasks
+		}
+
 		return fetchCoursesMsg{
 			courses: courses,
+			tasks:   tasks,
 			err:     err,
 		}
 	}
 }
 
+func (m CoursesScreen) saveCourseCmd(course *models.Course, isEdit bool) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if isEdit {
+			err = updateCourseRecorded(m.db, course)
+		} else {
+			err = m.db.Courses().Create(course)
+		}
+		if err != nil {
+			return fetchCoursesMsg{err: err}
+		}
+		return m.fetchCoursesCmd()()
+	}
+}
+
 func (m CoursesScreen) deleteCourseCmd(id string) tea.Cmd {
 	return func() tea.Msg {
-		if err := m.db.Courses().Delete(id); err != nil {
+		course, err := m.db.Courses().GetByID(id)
+		if err != nil {
 			return fetchCoursesMsg{err: err}
 		}
-		courses, err := m.db.Courses().GetAll()
-		return fetchCoursesMsg{
-			courses: courses,
-			err:     err,
+		if err := m.db.Courses().Delete(id); err != nil {
+			return fetchCoursesMsg{err: err}
 		}
+		recordDelete(m.db, models.SearchKindCourse, id, course.Name, func() error {
+			return m.db.Courses().Delete(id)
+		})
+		return m.fetchCoursesCmd()()
 	}
 }
 
@@ -283,7 +283,7 @@ func (d *DayView) createEvent(event *models.Event) tea.Cmd {
 
 func (d *DayView) updateEvent(event *models.Event) tea.Cmd {
 	return func() tea.Msg {
-		err := d.db.Events().Update(event)
+		err := updateEventRecorded(d.db, event)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -293,7 +293,7 @@ func (d *DayView) updateEvent(event *models.Event) tea.Cmd {
 
 func (d *DayView) deleteEvent(eventID string) tea.Cmd {
 	return func() tea.Msg {
-		err := d.db.Events().Delete(eventID)
+		err := deleteEventRecorded(d.db, eventID)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -500,16 +500,40 @@ func (d *DayView) renderSummaryAndTasks(width int) string {
 	// Tasks section
 	tasksSection := d.renderTasks(width)
 
-	content := lipgloss.JoinVertical(
-		lipgloss.Left,
-		summary,
-		"",
-		tasksSection,
-	)
+	sections := []string{summary, "", tasksSection}
+
+	// Selected event description
+	if details := d.renderSelectedEvent(width); details != "" {
+		sections = append(sections, "", details)
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
 	return content
 }
 
+// renderSelectedEvent renders the description of the selected event
+func (d *DayView) renderSelectedEvent(width int) string {
+	for _, event := range d.events {
+		if event.ID != d.selectedEventID || strings.TrimSpace(event.Description) == "" {
+			continue
+		}
+
+		title := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(styles.Accent).
+			Render("📝 " + strings.ToUpper(event.Title))
+
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			title,
+			strings.Repeat("─", width-4),
+			components.RenderMarkdown(event.Description, width-4),
+		)
+	}
+	return ""
+}
+
 // renderSummary renders the day summary stats
 func (d *DayView) renderSummary(width int) string {
 	// Calculate stats
@@ -0,0 +1,926 @@
+package screens
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/database"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/components"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// gradesPane identifies the focused pane of the grades screen
+type gradesPane int
+
+const (
+	paneCourses gradesPane = iota
+	paneAssessments
+)
+
+// gradesPrompt identifies the value being typed in the what-if prompt
+type gradesPrompt int
+
+const (
+	promptNone gradesPrompt = iota
+	promptScore
+	promptTarget
+)
+
+// GradesScreen lists the assessments of each course with its weighted average
+type GradesScreen struct {
+	db          *database.DB
+	courses     []models.Course
+	grades      map[string][]models.Grade // Grades by course ID
+	scale       models.GradingScale
+	scaleErr    error // Why the configured scale was not used, until another is picked
+	gpa         models.GPAReport
+	showGPA     bool
+	courseIndex int
+	gradeIndex  int
+	focusedPane gradesPane
+	width       int
+	height      int
+	loading     bool
+	err         error
+	feedbackMsg string
+
+	// Form state
+	showForm          bool
+	showDeleteConfirm bool
+	gradeForm         components.GradeForm
+
+	// What-if state, never persisted
+	whatIf       bool
+	hypothetical map[string]float64 // Hypothetical scores by grade ID
+	targets      map[string]string  // Target grade by course ID, as typed
+	prompt       gradesPrompt
+	promptInput  components.Input
+}
+
+// NewGradesScreen creates a new grades screen using the given grading scale.
+// scaleErr reports a configured scale that could not be used.
+func NewGradesScreen(db *database.DB, scale models.GradingScale, scaleErr error) *GradesScreen {
+	return &GradesScreen{
+		db:           db,
+		grades:       map[string][]models.Grade{},
+		scale:        scale,
+		scaleErr:     scaleErr,
+		hypothetical: map[string]float64{},
+		targets:      map[string]string{},
+		focusedPane:  paneCourses,
+		loading:      true,
+	}
+}
+
+// Init initializes the grades screen
+func (s *GradesScreen) Init() tea.Cmd {
+	return s.loadGrades()
+}
+
+func (s *GradesScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		s.height = msg.Height
+		return s, nil
+
+	case gradesLoadedMsg:
+		s.loading = false
+		s.err = msg.err
+		if msg.err == nil {
+			s.courses = msg.courses
+			s.grades = msg.grades
+		}
+		s.computeGPA()
+		s.clampCursors()
+		return s, nil
+
+	case gradeSavedMsg:
+		if msg.err != nil {
+			return s, s.showFeedback(fmt.Sprintf("Could not save assessment: %v", msg.err), styles.Danger)
+		}
+		return s, s.loadGrades()
+
+	case gradeDeletedMsg:
+		if msg.err != nil {
+			return s, s.showFeedback(fmt.Sprintf("Could not delete assessment: %v", msg.err), styles.Danger)
+		}
+		return s, s.loadGrades()
+
+	case clearFeedbackMsg:
+		s.feedbackMsg = ""
+		return s, nil
+
+	case tea.KeyMsg:
+		if s.showForm {
+			s.gradeForm, cmd = s.gradeForm.Update(msg)
+			if s.gradeForm.IsSubmitted() {
+				s.showForm = false
+				return s, s.saveGrade(s.gradeForm.GetGrade(), s.gradeForm.IsNewGrade())
+			} else if s.gradeForm.IsCancelled() {
+				s.showForm = false
+			}
+			return s, cmd
+		}
+
+		if s.prompt != promptNone {
+			return s, s.updatePrompt(msg)
+		}
+
+		if s.showDeleteConfirm {
+			switch msg.String() {
+			case "y", "Y":
+				s.showDeleteConfirm = false
+				if grade := s.selectedGrade(); grade != nil {
+					return s, s.deleteGrade(grade.ID)
+				}
+			case "n", "N", "esc":
+				s.showDeleteConfirm = false
+			}
+			return s, nil
+		}
+
+		switch msg.String() {
+		case "tab", "shift+tab":
+			if s.focusedPane == paneCourses {
+				s.focusedPane = paneAssessments
+			} else {
+				s.focusedPane = paneCourses
+			}
+		case "l", "right":
+			s.focusedPane = paneAssessments
+		case "h", "left":
+			s.focusedPane = paneCourses
+		case "j", "down":
+			if s.focusedPane == paneCourses {
+				if s.courseIndex < len(s.courses)-1 {
+					s.courseIndex++
+					s.gradeIndex = 0
+				}
+			} else if s.gradeIndex < len(s.currentGrades())-1 {
+				s.gradeIndex++
+			}
+		case "k", "up":
+			if s.focusedPane == paneCourses {
+				if s.courseIndex > 0 {
+					s.courseIndex--
+					s.gradeIndex = 0
+				}
+			} else if s.gradeIndex > 0 {
+				s.gradeIndex--
+			}
+		case "g":
+			if s.focusedPane == paneCourses {
+				s.courseIndex = 0
+				s.gradeIndex = 0
+			} else {
+				s.gradeIndex = 0
+			}
+		case "G":
+			if s.focusedPane == paneCourses {
+				s.courseIndex = max(0, len(s.courses)-1)
+				s.gradeIndex = 0
+			} else {
+				s.gradeIndex = max(0, len(s.currentGrades())-1)
+			}
+		case "w":
+			s.whatIf = !s.whatIf
+			if !s.whatIf {
+				s.hypothetical = map[string]float64{}
+				return s, s.showFeedback("What-if mode off, hypothetical scores discarded", styles.Info)
+			}
+			s.focusedPane = paneAssessments
+			s.showGPA = false
+			return s, nil
+		case "esc":
+			if s.whatIf {
+				s.whatIf = false
+				s.hypothetical = map[string]float64{}
+			}
+		case "t":
+			if s.whatIf && s.selectedCourse() != nil {
+				return s, s.openPrompt(promptTarget)
+			}
+		case "x":
+			if s.whatIf {
+				if grade := s.selectedGrade(); grade != nil {
+					delete(s.hypothetical, grade.ID)
+				}
+			}
+		case "n":
+			if s.whatIf {
+				break
+			}
+			if course := s.selectedCourse(); course != nil {
+				s.showForm = true
+				s.gradeForm = components.NewGradeForm(course.ID, nil)
+			} else {
+				return s, s.showFeedback("Create a course first to add assessments", styles.Warning)
+			}
+		case "e", "enter":
+			if s.focusedPane == paneCourses && msg.String() == "enter" {
+				s.focusedPane = paneAssessments
+				return s, nil
+			}
+			if grade := s.selectedGrade(); grade != nil {
+				if s.whatIf {
+					if grade.Graded {
+						return s, s.showFeedback("Only ungraded assessments can be simulated", styles.Warning)
+					}
+					return s, s.openPrompt(promptScore)
+				}
+				s.showForm = true
+				s.gradeForm = components.NewGradeForm(grade.CourseID, grade)
+			}
+		case "d", "delete":
+			if !s.whatIf && s.focusedPane == paneAssessments && s.selectedGrade() != nil {
+				s.showDeleteConfirm = true
+			}
+		case "p":
+			s.showGPA = !s.showGPA
+		case "s":
+			s.cycleScale()
+			return s, s.showFeedback("Grading scale: "+s.scale.Title, styles.Info)
+		case "r":
+			return s, s.loadGrades()
+		}
+	}
+
+	return s, nil
+}
+
+func (s *GradesScreen) View() string {
+	if s.width == 0 || s.height == 0 || s.loading {
+		return lipgloss.NewStyle().
+			Padding(2).
+			Foreground(styles.Info).
+			Render("Loading grades...")
+	}
+
+	if s.err != nil {
+		return lipgloss.NewStyle().
+			Padding(2).
+			Foreground(styles.Danger).
+			Render(fmt.Sprintf("Error: %v\n\nPress 'r' to retry", s.err))
+	}
+
+	if s.showForm {
+		return lipgloss.Place(
+			s.width,
+			s.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			s.gradeForm.View(),
+		)
+	}
+
+	if s.showDeleteConfirm {
+		return s.renderDeleteConfirmDialog()
+	}
+
+	titleText := " Grades"
+	titleColor := styles.Primary
+	if s.whatIf {
+		titleText = " Grades · What-if"
+		titleColor = styles.Accent
+	}
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(titleColor).
+		Padding(1, 0).
+		Render(titleText)
+
+	if len(s.courses) == 0 {
+		empty := lipgloss.NewStyle().
+			Foreground(styles.Muted).
+			Padding(2, 4).
+			Render("No courses yet. Create one in the Courses view to start tracking grades.")
+		return lipgloss.JoinVertical(lipgloss.Left, title, empty)
+	}
+
+	courseWidth := s.width / 3
+	if courseWidth < 24 {
+		courseWidth = 24
+	}
+	assessmentWidth := s.width - courseWidth - 6
+	if assessmentWidth < 30 {
+		assessmentWidth = 30
+	}
+	paneHeight := s.height - 11
+
+	courses := s.renderCoursePane(courseWidth, paneHeight)
+	var details string
+	if s.showGPA {
+		details = s.renderGPAPane(assessmentWidth, paneHeight)
+	} else {
+		details = s.renderAssessmentPane(assessmentWidth, paneHeight)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		lipgloss.JoinHorizontal(lipgloss.Top, courses, details),
+		"",
+		s.renderGPASummary(),
+		"",
+		s.renderShortcuts(),
+	)
+}
+
+// renderCoursePane renders the list of courses grouped by semester with their running average
+func (s *GradesScreen) renderCoursePane(width, height int) string {
+	var lines []string
+	semester := ""
+	for i, course := range s.courses {
+		if i == 0 || course.Semester != semester {
+			semester = course.Semester
+			header := semester
+			if header == "" {
+				header = "No semester"
+			}
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary).Render(header))
+		}
+
+		name := course.Name
+		if course.Code != "" {
+			name = course.Code
+		}
+
+		avgText := "  --  "
+		labelText := ""
+		if avg, ok := models.WeightedAverage(s.grades[course.ID]); ok {
+			avgText = fmt.Sprintf("%5.1f%%", avg)
+			_, labelText = s.scale.Convert(avg)
+		}
+
+		nameWidth := width - 19
+		if nameWidth < 4 {
+			nameWidth = 4
+		}
+		line := fmt.Sprintf("%-*s %s %6s", nameWidth, truncate(name, nameWidth), avgText, labelText)
+
+		style := lipgloss.NewStyle().Padding(0, 1)
+		if i == s.courseIndex {
+			if s.focusedPane == paneCourses {
+				style = style.Background(styles.Primary).Foreground(styles.Background).Bold(true)
+			} else {
+				style = style.Foreground(styles.Primary).Bold(true)
+			}
+		}
+		lines = append(lines, style.Render(line))
+	}
+
+	paneStyle := styles.Panel.Width(width).Height(height)
+	if s.focusedPane == paneCourses {
+		paneStyle = paneStyle.BorderForeground(styles.Primary)
+	}
+
+	return paneStyle.Render(strings.Join(lines, "\n"))
+}
+
+// renderAssessmentPane renders the assessments of the selected course
+func (s *GradesScreen) renderAssessmentPane(width, height int) string {
+	course := s.selectedCourse()
+	grades := s.currentGrades()
+
+	var b strings.Builder
+
+	header := course.Name
+	if course.Code != "" {
+		header = course.Code + " - " + course.Name
+	}
+	b.WriteString(styles.Title.Render(header))
+	b.WriteString("\n\n")
+
+	if len(grades) == 0 {
+		b.WriteString(styles.Dimmed.Render("  No assessments yet. Press 'n' to add one."))
+	} else {
+		nameWidth := width - 36
+		if nameWidth < 8 {
+			nameWidth = 8
+		}
+		columns := fmt.Sprintf("  %-*s %-9s %11s %7s %6s", nameWidth, "Assessment", "Type", "Score", "Weight", "Date")
+		b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(styles.Muted).Render(columns))
+		b.WriteString("\n")
+
+		for i, g := range grades {
+			score := styles.Dimmed.Render(fmt.Sprintf("%11s", "pending"))
+			if hypo, ok := s.hypothetical[g.ID]; ok && s.whatIf && !g.Graded {
+				scoreText := fmt.Sprintf("~%s/%s", formatScore(hypo), formatScore(g.MaxScore))
+				score = lipgloss.NewStyle().Foreground(styles.Accent).Italic(true).Render(fmt.Sprintf("%11s", scoreText))
+			} else if g.Graded {
+				scoreText := fmt.Sprintf("%s/%s", formatScore(g.Score), formatScore(g.MaxScore))
+				score = lipgloss.NewStyle().Foreground(percentageColor(g.Percentage())).Render(fmt.Sprintf("%11s", scoreText))
+			}
+
+			date := ""
+			if g.Date != nil {
+				date = g.Date.Format("Jan 02")
+			}
+
+			line := fmt.Sprintf("%-*s %-9s ", nameWidth, truncate(g.Name, nameWidth), g.Type)
+			rest := fmt.Sprintf(" %7s %6s", formatScore(g.Weight), date)
+
+			style := lipgloss.NewStyle()
+			if i == s.gradeIndex && s.focusedPane == paneAssessments {
+				style = style.Background(styles.BackgroundLight).Bold(true)
+				line = "► " + line
+			} else {
+				line = "  " + line
+			}
+			b.WriteString(style.Render(line) + score + style.Render(rest))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(s.renderAverageSummary(grades))
+	if s.whatIf {
+		b.WriteString("\n\n")
+		b.WriteString(s.renderWhatIfSummary(course, grades))
+	}
+
+	paneStyle := styles.Panel.Width(width).Height(height)
+	if s.whatIf {
+		paneStyle = paneStyle.BorderForeground(styles.Accent)
+	} else if s.focusedPane == paneAssessments {
+		paneStyle = paneStyle.BorderForeground(styles.Primary)
+	}
+
+	return paneStyle.Render(b.String())
+}
+
+// renderAverageSummary renders the weighted average and graded share of a course
+func (s *GradesScreen) renderAverageSummary(grades []models.Grade) string {
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Muted)
+
+	avgText := styles.Dimmed.Render("no graded assessments yet")
+	if avg, ok := models.WeightedAverage(grades); ok {
+		avgText = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(percentageColor(avg)).
+			Render(fmt.Sprintf("%.1f%%", avg))
+	}
+
+	graded := int(models.GradedWeight(grades) * 100)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		labelStyle.Render("Weighted average")+": "+avgText,
+		labelStyle.Render("Graded weight")+":    "+renderProgressBar(graded, 15)+fmt.Sprintf(" %d%%", graded),
+	)
+}
+
+// renderWhatIfSummary renders the simulated average and GPA of a course and
+// the score needed on the remaining assessments to reach its target
+func (s *GradesScreen) renderWhatIfSummary(course *models.Course, grades []models.Grade) string {
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Accent)
+	simulated := models.SimulateGrades(grades, s.hypothetical)
+
+	lines := []string{}
+
+	avgText := styles.Dimmed.Render("enter hypothetical scores with 'e'")
+	if avg, ok := models.WeightedAverage(simulated); ok {
+		_, label := s.scale.Convert(avg)
+		avgText = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(percentageColor(avg)).
+			Render(fmt.Sprintf("%.1f%% (%s)", avg, label))
+	}
+	lines = append(lines, labelStyle.Render("What-if average")+": "+avgText)
+
+	// Semester GPA with the simulated grades of this course
+	all := make(map[string][]models.Grade, len(s.grades))
+	for id, g := range s.grades {
+		all[id] = g
+	}
+	all[course.ID] = simulated
+	report := models.ComputeGPA(s.courses, all, s.scale)
+	if sem, ok := report.Semester(course.Semester); ok {
+		lines = append(lines, labelStyle.Render("What-if semester GPA")+": "+
+			lipgloss.NewStyle().Bold(true).Foreground(styles.Primary).Render(s.scale.Format(sem.GPA)))
+	}
+
+	target, hasTarget := s.targets[course.ID]
+	if !hasTarget {
+		lines = append(lines, styles.Dimmed.Render("Press 't' to set a target grade"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	pct, err := s.scale.ParseTarget(target)
+	if err != nil {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.Warning).Render("⚠ "+err.Error()))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	targetText := fmt.Sprintf("%s (%.1f%%)", target, pct)
+	req, ok := models.RequiredScore(simulated, pct)
+	var result string
+	switch {
+	case !ok:
+		result = styles.Dimmed.Render("no remaining assessments to simulate")
+	case req.Secured():
+		result = lipgloss.NewStyle().Foreground(styles.Success).Render("already secured")
+	case !req.Achievable():
+		result = lipgloss.NewStyle().Foreground(styles.Danger).
+			Render(fmt.Sprintf("out of reach (needs %.1f%%)", req.Needed))
+	default:
+		result = lipgloss.NewStyle().Bold(true).Foreground(percentageColor(100-req.Needed+60)).
+			Render(fmt.Sprintf("%.1f%%", req.Needed)) +
+			fmt.Sprintf(" needed on the remaining %.0f%% of the weight", req.Remaining*100)
+	}
+	lines = append(lines, labelStyle.Render("Target "+targetText)+": "+result)
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// renderGPAPane renders the GPA breakdown of every semester
+func (s *GradesScreen) renderGPAPane(width, height int) string {
+	var b strings.Builder
+
+	b.WriteString(styles.Title.Render("GPA by semester"))
+	b.WriteString(styles.Dimmed.Render(" (" + s.scale.Title + ")"))
+	b.WriteString("\n")
+	if s.scaleErr != nil {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.Warning).Render(fmt.Sprintf("⚠ %v, using %s", s.scaleErr, s.scale.Title)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if !s.gpa.HasGPA() {
+		b.WriteString(styles.Dimmed.Render("  No graded courses with credits yet."))
+	}
+
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary)
+	nameWidth := width - 30
+	if nameWidth < 8 {
+		nameWidth = 8
+	}
+
+	for _, sem := range s.gpa.Semesters {
+		name := sem.Semester
+		if name == "" {
+			name = "No semester"
+		}
+		b.WriteString(labelStyle.Render(name))
+		b.WriteString(fmt.Sprintf("  GPA %s · %d cr\n", s.scale.Format(sem.GPA), sem.Credits))
+
+		for _, result := range sem.Courses {
+			courseName := result.Course.Name
+			if result.Course.Code != "" {
+				courseName = result.Course.Code + " " + courseName
+			}
+			line := fmt.Sprintf("  %-*s %6.1f%% %6s %3d cr", nameWidth, truncate(courseName, nameWidth), result.Average, result.Label, result.Course.Credits)
+			b.WriteString(lipgloss.NewStyle().Foreground(percentageColor(result.Average)).Render(line))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	paneStyle := styles.Panel.Width(width).Height(height)
+	if s.focusedPane == paneAssessments {
+		paneStyle = paneStyle.BorderForeground(styles.Primary)
+	}
+
+	return paneStyle.Render(b.String())
+}
+
+// renderGPASummary renders the semester GPA of the selected course and the cumulative GPA
+func (s *GradesScreen) renderGPASummary() string {
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Muted)
+	valueStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+
+	if !s.gpa.HasGPA() {
+		return labelStyle.Render(" GPA") + ": " + styles.Dimmed.Render("no graded courses with credits yet")
+	}
+
+	var parts []string
+	if course := s.selectedCourse(); course != nil {
+		if sem, ok := s.gpa.Semester(course.Semester); ok {
+			name := sem.Semester
+			if name == "" {
+				name = "No semester"
+			}
+			parts = append(parts, labelStyle.Render(" "+name)+": "+valueStyle.Render(s.scale.Format(sem.GPA))+
+				styles.Dimmed.Render(fmt.Sprintf(" (%d cr)", sem.Credits)))
+		}
+	}
+	parts = append(parts, labelStyle.Render(" Cumulative GPA")+": "+valueStyle.Render(s.scale.Format(s.gpa.Cumulative))+
+		styles.Dimmed.Render(fmt.Sprintf(" (%d cr, %s)", s.gpa.Credits, s.scale.Title)))
+
+	return strings.Join(parts, "   ")
+}
+
+// renderDeleteConfirmDialog renders the delete confirmation dialog
+func (s *GradesScreen) renderDeleteConfirmDialog() string {
+	grade := s.selectedGrade()
+	if grade == nil {
+		return ""
+	}
+
+	dialog := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Danger).
+		Padding(1, 2).
+		Render(lipgloss.JoinVertical(
+			lipgloss.Center,
+			styles.Title.Render(fmt.Sprintf("Delete assessment \"%s\"?", grade.Name)),
+			"",
+			styles.Dimmed.Render("This action cannot be undone."),
+			"",
+			lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				styles.Shortcut.Render("y")+styles.ShortcutText.Render(" delete"),
+				"  ",
+				styles.Shortcut.Render("n")+styles.ShortcutText.Render(" cancel"),
+			),
+		))
+
+	return lipgloss.Place(
+		s.width,
+		s.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
+
+// renderShortcuts renders keyboard shortcuts or a feedback message
+func (s *GradesScreen) renderShortcuts() string {
+	if s.prompt != promptNone {
+		return s.promptInput.View()
+	}
+
+	if s.feedbackMsg != "" {
+		return s.feedbackMsg
+	}
+
+	if s.whatIf {
+		shortcuts := []string{
+			styles.Shortcut.Render("j/k") + styles.ShortcutText.Render(" navigate"),
+			styles.Shortcut.Render("e") + styles.ShortcutText.Render(" hypothetical score"),
+			styles.Shortcut.Render("x") + styles.ShortcutText.Render(" clear score"),
+			styles.Shortcut.Render("t") + styles.ShortcutText.Render(" target"),
+			styles.Shortcut.Render("w/esc") + styles.ShortcutText.Render(" exit what-if"),
+		}
+		return strings.Join(shortcuts, "  ")
+	}
+
+	shortcuts := []string{
+		styles.Shortcut.Render("tab") + styles.ShortcutText.Render(" switch pane"),
+		styles.Shortcut.Render("j/k") + styles.ShortcutText.Render(" navigate"),
+		styles.Shortcut.Render("n") + styles.ShortcutText.Render(" new"),
+		styles.Shortcut.Render("e") + styles.ShortcutText.Render(" edit"),
+		styles.Shortcut.Render("d") + styles.ShortcutText.Render(" delete"),
+		styles.Shortcut.Render("w") + styles.ShortcutText.Render(" what-if"),
+		styles.Shortcut.Render("p") + styles.ShortcutText.Render(" gpa"),
+		styles.Shortcut.Render("s") + styles.ShortcutText.Render(" scale"),
+		styles.Shortcut.Render("r") + styles.ShortcutText.Render(" refresh"),
+	}
+
+	return strings.Join(shortcuts, "  ")
+}
+
+// showFeedback shows a temporary feedback message in the shortcuts bar
+func (s *GradesScreen) showFeedback(text string, color lipgloss.Color) tea.Cmd {
+	s.feedbackMsg = lipgloss.NewStyle().Foreground(color).Render(text)
+	return tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return clearFeedbackMsg{} })
+}
+
+// openPrompt opens the what-if prompt for a hypothetical score or a target grade
+func (s *GradesScreen) openPrompt(prompt gradesPrompt) tea.Cmd {
+	s.prompt = prompt
+
+	switch prompt {
+	case promptScore:
+		grade := s.selectedGrade()
+		s.promptInput = components.NewInput(
+			fmt.Sprintf("Hypothetical score for %s (max %s):", grade.Name, formatScore(grade.MaxScore)),
+			"e.g. 15",
+		)
+		if hypo, ok := s.hypothetical[grade.ID]; ok {
+			s.promptInput.SetValue(formatScore(hypo))
+		}
+	case promptTarget:
+		course := s.selectedCourse()
+		placeholder := "e.g. B, 3.0 or 85%"
+		if len(s.scale.Bands) == 0 {
+			placeholder = fmt.Sprintf("e.g. %s or 85%%", formatScore(s.scale.Max*0.7))
+		}
+		s.promptInput = components.NewInput("Target grade:", placeholder)
+		s.promptInput.SetValue(s.targets[course.ID])
+	}
+
+	return s.promptInput.Focus()
+}
+
+// updatePrompt handles input while the what-if prompt is open
+func (s *GradesScreen) updatePrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		s.prompt = promptNone
+		return nil
+	case "enter":
+		value := strings.TrimSpace(s.promptInput.Value())
+		prompt := s.prompt
+		s.prompt = promptNone
+
+		switch prompt {
+		case promptScore:
+			grade := s.selectedGrade()
+			if grade == nil {
+				return nil
+			}
+			if value == "" {
+				delete(s.hypothetical, grade.ID)
+				return nil
+			}
+			score, err := components.ParseNumber(value)
+			if err != nil || score < 0 || score > grade.MaxScore {
+				return s.showFeedback(fmt.Sprintf("Score must be a number between 0 and %s", formatScore(grade.MaxScore)), styles.Warning)
+			}
+			s.hypothetical[grade.ID] = score
+		case promptTarget:
+			course := s.selectedCourse()
+			if course == nil {
+				return nil
+			}
+			if value == "" {
+				delete(s.targets, course.ID)
+				return nil
+			}
+			if _, err := s.scale.ParseTarget(value); err != nil {
+				return s.showFeedback(err.Error(), styles.Warning)
+			}
+			s.targets[course.ID] = value
+		}
+		return nil
+	}
+
+	return s.promptInput.Update(msg)
+}
+
+// cycleScale switches to the next built-in grading scale
+func (s *GradesScreen) cycleScale() {
+	s.scaleErr = nil
+	for i, scale := range models.GradingScales {
+		if scale.Name == s.scale.Name {
+			s.scale = models.GradingScales[(i+1)%len(models.GradingScales)]
+			s.computeGPA()
+			return
+		}
+	}
+	s.scale = models.GradingScales[0]
+	s.computeGPA()
+}
+
+// computeGPA recomputes the GPA report from the loaded courses and grades
+func (s *GradesScreen) computeGPA() {
+	s.gpa = models.ComputeGPA(s.courses, s.grades, s.scale)
+}
+
+// selectedCourse returns the course under the cursor
+func (s *GradesScreen) selectedCourse() *models.Course {
+	if s.courseIndex < 0 || s.courseIndex >= len(s.courses) {
+		return nil
+	}
+	return &s.courses[s.courseIndex]
+}
+
+// currentGrades returns the grades of the selected course
+func (s *GradesScreen) currentGrades() []models.Grade {
+	course := s.selectedCourse()
+	if course == nil {
+		return nil
+	}
+	return s.grades[course.ID]
+}
+
+// selectedGrade returns the grade under the cursor
+func (s *GradesScreen) selectedGrade() *models.Grade {
+	grades := s.currentGrades()
+	if s.gradeIndex < 0 || s.gradeIndex >= len(grades) {
+		return nil
+	}
+	return &grades[s.gradeIndex]
+}
+
+// clampCursors keeps the cursors inside the loaded data
+func (s *GradesScreen) clampCursors() {
+	if s.courseIndex >= len(s.courses) {
+		s.courseIndex = max(0, len(s.courses)-1)
+	}
+	if s.gradeIndex >= len(s.currentGrades()) {
+		s.gradeIndex = max(0, len(s.currentGrades())-1)
+	}
+}
+
+// IsGradeFormActive returns true if the grade form or the what-if prompt is currently active
+func (s *GradesScreen) IsGradeFormActive() bool {
+	return s.showForm || s.prompt != promptNone
+}
+
+// loadGrades loads courses and their grades from the database
+func (s *GradesScreen) loadGrades() tea.Cmd {
+	return func() tea.Msg {
+		courses, err := s.db.Courses().GetAll()
+		if err != nil {
+			return gradesLoadedMsg{err: err}
+		}
+
+		all, err := s.db.Grades().FindAll()
+		if err != nil {
+			return gradesLoadedMsg{err: err}
+		}
+
+		// Group courses by semester, oldest first
+		sort.SliceStable(courses, func(i, j int) bool {
+			return models.SemesterLess(courses[i].Semester, courses[j].Semester)
+		})
+
+		return gradesLoadedMsg{courses: courses, grades: models.GroupGradesByCourse(all)}
+	}
+}
+
+// saveGrade creates or updates a grade
+func (s *GradesScreen) saveGrade(grade *models.Grade, isNew bool) tea.Cmd {
+	return func() tea.Msg {
+		if isNew {
+			return gradeSavedMsg{err: s.db.Grades().Create(grade)}
+		}
+		return gradeSavedMsg{err: s.db.Grades().Update(grade)}
+	}
+}
+
+// deleteGrade deletes a grade by ID
+func (s *GradesScreen) deleteGrade(id string) tea.Cmd {
+	return func() tea.Msg {
+		return gradeDeletedMsg{err: s.db.Grades().Delete(id)}
+	}
+}
+
+// Messages
+type gradesLoadedMsg struct {
+	courses []models.Course
+	grades  map[string][]models.Grade
+	err     error
+}
+
+type gradeSavedMsg struct {
+	err error
+}
+
+type gradeDeletedMsg struct {
+	err error
+}
+
+// percentageColor returns the color used to render a percentage
+func percentageColor(pct float64) lipgloss.Color {
+	switch {
+	case pct >= 85:
+		return styles.Success
+	case pct >= 60:
+		return styles.AutumnYellow
+	default:
+		return styles.Danger
+	}
+}
+
+// requirementColor returns the color used to render a required percentage
+func requirementColor(needed float64) lipgloss.Color {
+	switch {
+	case needed <= 60:
+		return styles.Success
+	case needed <= 85:
+		return styles.AutumnYellow
+	default:
+		return styles.Danger
+	}
+}
+
+// formatScore formats a score without unnecessary decimals
+func formatScore(n float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", n), "0"), ".")
+}
+
+// truncate shortens a string to the given width, adding an ellipsis
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-1]) + "…"
+}
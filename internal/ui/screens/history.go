@@ -0,0 +1,275 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stiffis/UniCLI/internal/database"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/undo"
+)
+
+// recordTaskChange records an update of a task from before to after. created
+// is a task made by the change, such as the next instance of a completed
+// recurring task, and is removed again on undo.
+func recordTaskChange(db *database.DB, description string, before, after models.Task, created *models.Task) {
+	var createdTasks []models.Task
+	if created != nil {
+		createdTasks = []models.Task{*created}
+	}
+	recordTaskChanges(db, description, []models.Task{before}, []models.Task{after}, createdTasks)
+}
+
+// recordTaskChanges records an update of several tasks at once, see
+// recordTaskChange. Undo and redo save all the tasks in one transaction.
+func recordTaskChanges(db *database.DB, description string, before, after, created []models.Task) {
+	db.History().Push(undo.Action{
+		Description: description,
+		Undo: func() error {
+			// The created tasks are made again on redo, they are not kept in the trash
+			for _, task := range created {
+				if err := db.Tasks().DeleteCreated(task); err != nil {
+					return err
+				}
+			}
+			return db.Tasks().UpdateMany(append([]models.Task{}, before...))
+		},
+		Redo: func() error {
+			if err := db.Tasks().UpdateMany(append([]models.Task{}, after...)); err != nil {
+				return err
+			}
+			for _, task := range created {
+				if err := recreateTask(db, task); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// recordDelete records moving an item to the trash. Undoing it restores the
+// item, redo moves it to the trash again.
+func recordDelete(db *database.DB, kind models.SearchKind, id, title string, redo func() error) {
+	db.History().Push(undo.Action{
+		Description: fmt.Sprintf("delete %s %q", strings.ToLower(kind.Label()), title),
+		Undo:        func() error { return db.Trash().Restore(kind, id) },
+		Redo:        redo,
+	})
+}
+
+// recordArchive records archiving a task, or taking it out of the archive if
+// archived is false
+func recordArchive(db *database.DB, id, title string, archived bool) {
+	archive := func() error { return db.Tasks().Archive(id) }
+	unarchive := func() error { return db.Tasks().Unarchive(id) }
+	if archived {
+		db.History().Push(undo.Action{
+			Description: fmt.Sprintf("archive task %q", title),
+			Undo:        unarchive,
+			Redo:        archive,
+		})
+		return
+	}
+	db.History().Push(undo.Action{
+		Description: fmt.Sprintf("unarchive task %q", title),
+		Undo:        archive,
+		Redo:        unarchive,
+	})
+}
+
+// recordBulkDelete records moving several tasks to the trash at once
+func recordBulkDelete(db *database.DB, ids []string) {
+	db.History().Push(undo.Action{
+		Description: fmt.Sprintf("delete %d tasks", len(ids)),
+		Undo: func() error {
+			for _, id := range ids {
+				if err := db.Trash().Restore(models.SearchKindTask, id); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Redo: func() error { return db.Tasks().DeleteMany(ids) },
+	})
+}
+
+// recordBulkArchive records archiving several tasks at once
+func recordBulkArchive(db *database.DB, ids []string) {
+	db.History().Push(undo.Action{
+		Description: fmt.Sprintf("archive %d tasks", len(ids)),
+		Undo: func() error {
+			for _, id := range ids {
+				if err := db.Tasks().Unarchive(id); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Redo: func() error { return db.Tasks().ArchiveMany(ids) },
+	})
+}
+
+// recreateTask inserts a purged task again, with its checklist
+func recreateTask(db *database.DB, task models.Task) error {
+	return db.Tasks().CreateWithSubtasks(&task)
+}
+
+// recordSubtaskToggle records checking or unchecking a subtask
+func recordSubtaskToggle(db *database.DB, subtask models.Subtask) {
+	verb := "check"
+	if !subtask.IsCompleted {
+		verb = "uncheck"
+	}
+	setCompleted := func(completed bool) func() error {
+		return func() error {
+			s := subtask
+			s.IsCompleted = completed
+			return db.Tasks().UpdateSubtask(&s)
+		}
+	}
+	db.History().Push(undo.Action{
+		Description: fmt.Sprintf("%s subtask %q", verb, subtask.Title),
+		Undo:        setCompleted(!subtask.IsCompleted),
+		Redo:        setCompleted(subtask.IsCompleted),
+	})
+}
+
+// recordSubtaskDelete records the deletion of a subtask with the subtasks
+// nested under it, the branch starting with the deleted subtask, from the
+// checklist it was in. The subtasks get new IDs when they are created again.
+func recordSubtaskDelete(db *database.DB, branch, checklist []models.Subtask) {
+	root := branch[0]
+	id := root.ID
+
+	// The siblings as they were, to undo any renumbering since the delete
+	var siblings []models.Subtask
+	for _, st := range checklist {
+		if st.ID != root.ID && sameParent(st.ParentID, root.ParentID) {
+			siblings = append(siblings, st)
+		}
+	}
+
+	db.History().Push(undo.Action{
+		Description: fmt.Sprintf("delete subtask %q", root.Title),
+		Undo: func() error {
+			restored := append([]models.Subtask{}, branch...)
+			if err := db.Tasks().CreateSubtasks(restored); err != nil {
+				return err
+			}
+			// Put the subtask back in its place among its siblings
+			id = restored[0].ID
+			restored[0].Position = root.Position
+			return db.Tasks().UpdateSubtaskLayout(append(append([]models.Subtask{}, siblings...), restored[0]))
+		},
+		Redo: func() error { return db.Tasks().DeleteSubtask(id) },
+	})
+}
+
+// sameParent returns true if two subtask parent IDs are the same, nil for the
+// top level
+func sameParent(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// recordSubtaskRename records renaming a subtask from before to after
+func recordSubtaskRename(db *database.DB, id int, before, after string) {
+	db.History().Push(undo.Action{
+		Description: fmt.Sprintf("rename subtask %q", after),
+		Undo:        func() error { return db.Tasks().RenameSubtask(id, before) },
+		Redo:        func() error { return db.Tasks().RenameSubtask(id, after) },
+	})
+}
+
+// recordSubtaskLayout records moving, indenting or outdenting a subtask,
+// with the checklist before and after the change
+func recordSubtaskLayout(db *database.DB, description string, before, after []models.Subtask) {
+	db.History().Push(undo.Action{
+		Description: description,
+		Undo:        func() error { return db.Tasks().UpdateSubtaskLayout(before) },
+		Redo:        func() error { return db.Tasks().UpdateSubtaskLayout(after) },
+	})
+}
+
+// recordTaskSwap records swapping two tasks of a manually sorted column,
+// which swapping them again undoes
+func recordTaskSwap(db *database.DB, column, a, b string) {
+	swap := func() error { return db.Tasks().SwapPositions(a, b) }
+	db.History().Push(undo.Action{
+		Description: fmt.Sprintf("reorder tasks in %s", column),
+		Undo:        swap,
+		Redo:        swap,
+	})
+}
+
+// recordEventUpdate records the edit of an event from before to after
+func recordEventUpdate(db *database.DB, before, after models.Event) {
+	db.History().Push(undo.Action{
+		Description: fmt.Sprintf("edit event %q", after.Title),
+		Undo: func() error {
+			event := before
+			return db.Events().Update(&event)
+		},
+		Redo: func() error {
+			event := after
+			return db.Events().Update(&event)
+		},
+	})
+}
+
+// deleteEventRecorded deletes an event, recording the deletion for undo
+func deleteEventRecorded(db *database.DB, eventID string) error {
+	// Course classes shown in the calendar are not stored as events
+	event, findErr := db.Events().FindByID(eventID)
+	if err := db.Events().Delete(eventID); err != nil {
+		return err
+	}
+	if findErr == nil {
+		recordDelete(db, models.SearchKindEvent, event.ID, event.Title, func() error {
+			return db.Events().Delete(event.ID)
+		})
+	}
+	return nil
+}
+
+// recordCourseUpdate records the edit of a course from before to after
+func recordCourseUpdate(db *database.DB, before, after models.Course) {
+	db.History().Push(undo.Action{
+		Description: fmt.Sprintf("edit course %q", after.Name),
+		Undo: func() error {
+			course := before
+			return db.Courses().Update(&course)
+		},
+		Redo: func() error {
+			course := after
+			return db.Courses().Update(&course)
+		},
+	})
+}
+
+// updateCourseRecorded saves an edited course, recording the edit for undo
+func updateCourseRecorded(db *database.DB, course *models.Course) error {
+	before, findErr := db.Courses().GetByID(course.ID)
+	if err := db.Courses().Update(course); err != nil {
+		return err
+	}
+	if findErr == nil {
+		recordCourseUpdate(db, *before, *course)
+	}
+	return nil
+}
+
+// updateEventRecorded saves an edited event, recording the edit for undo
+func updateEventRecorded(db *database.DB, event *models.Event) error {
+	before, findErr := db.Events().FindByID(event.ID)
+	if err := db.Events().Update(event); err != nil {
+		return err
+	}
+	if findErr == nil {
+		recordEventUpdate(db, *before, *event)
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package screens
+
+import "github.com/stiffis/UniCLI/internal/models"
+
+// JumpToMsg asks a screen to reveal an item, e.g. a search result. The screen
+// reloads its data and selects the item once it is loaded.
+type JumpToMsg struct {
+	Kind models.SearchKind
+	ID   string
+}
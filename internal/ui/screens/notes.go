@@ -0,0 +1,727 @@
+package screens
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/database"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/components"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// notesPane identifies the focused pane of the notes screen
+type notesPane int
+
+const (
+	notesPaneList notesPane = iota
+	notesPaneReader
+)
+
+// Note list filters before the per-course filters
+const (
+	notesFilterAll = iota
+	notesFilterStandalone
+	notesFilterCourses // First course filter, one per course
+)
+
+// noteEntry is a standalone note or a course note shown in the notes list
+type noteEntry struct {
+	ID        string
+	CourseID  string // Empty for standalone notes
+	Title     string
+	Content   string
+	Tags      []string
+	Date      time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// kind returns the kind of note, as used by search and the trash
+func (e noteEntry) kind() models.SearchKind {
+	if e.CourseID != "" {
+		return models.SearchKindCourseNote
+	}
+	return models.SearchKindNote
+}
+
+// NotesScreen lists standalone and course notes with a reader pane
+type NotesScreen struct {
+	db            *database.DB
+	entries       []noteEntry
+	courses       []models.Course
+	filter        int
+	selectedIndex int
+	readerOffset  int
+	focusedPane   notesPane
+	width         int
+	height        int
+	loading       bool
+	err           error
+	feedbackMsg   string
+	jumpNoteID    string // Note to select once notes are loaded
+
+	// Form state
+	showForm          bool
+	showDeleteConfirm bool
+	noteForm          components.NoteForm
+	editing           *noteEntry // Note being edited, nil when creating
+}
+
+// NewNotesScreen creates a new notes screen
+func NewNotesScreen(db *database.DB) *NotesScreen {
+	return &NotesScreen{
+		db:          db,
+		focusedPane: notesPaneList,
+		loading:     true,
+	}
+}
+
+// Init initializes the notes screen
+func (s *NotesScreen) Init() tea.Cmd {
+	return s.loadNotes()
+}
+
+func (s *NotesScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		s.height = msg.Height
+		return s, nil
+
+	case notesLoadedMsg:
+		s.loading = false
+		s.err = msg.err
+		if msg.err == nil {
+			s.entries = msg.entries
+			s.courses = msg.courses
+		}
+		if s.filter >= notesFilterCourses+len(s.courses) {
+			s.filter = notesFilterAll
+		}
+		if s.jumpNoteID != "" {
+			s.revealNote(s.jumpNoteID)
+			s.jumpNoteID = ""
+		}
+		s.clampSelection()
+		return s, nil
+
+	case JumpToMsg:
+		s.showForm = false
+		s.showDeleteConfirm = false
+		s.jumpNoteID = msg.ID
+		return s, s.loadNotes()
+
+	case noteSavedMsg:
+		if msg.err != nil {
+			return s, s.showFeedback(fmt.Sprintf("Could not save note: %v", msg.err), styles.Danger)
+		}
+		return s, s.loadNotes()
+
+	case noteDeletedMsg:
+		if msg.err != nil {
+			return s, s.showFeedback(fmt.Sprintf("Could not delete note: %v", msg.err), styles.Danger)
+		}
+		return s, s.loadNotes()
+
+	case clearFeedbackMsg:
+		s.feedbackMsg = ""
+		return s, nil
+
+	case components.EditorFinishedMsg:
+		if s.showForm {
+			s.noteForm, cmd = s.noteForm.Update(msg)
+		}
+		return s, cmd
+
+	case tea.KeyMsg:
+		if s.showForm {
+			s.noteForm, cmd = s.noteForm.Update(msg)
+			if s.noteForm.IsSubmitted() {
+				s.showForm = false
+				return s, s.saveNote(s.editing, s.noteForm)
+			} else if s.noteForm.IsCancelled() {
+				s.showForm = false
+			}
+			return s, cmd
+		}
+
+		if s.showDeleteConfirm {
+			switch msg.String() {
+			case "y", "Y":
+				s.showDeleteConfirm = false
+				if entry := s.selectedEntry(); entry != nil {
+					return s, s.deleteNote(*entry)
+				}
+			case "n", "N", "esc":
+				s.showDeleteConfirm = false
+			}
+			return s, nil
+		}
+
+		if s.focusedPane == notesPaneReader {
+			switch msg.String() {
+			case "j", "down":
+				s.readerOffset++
+				return s, nil
+			case "k", "up":
+				if s.readerOffset > 0 {
+					s.readerOffset--
+				}
+				return s, nil
+			case "ctrl+d":
+				s.readerOffset += s.readerHeight() / 2
+				return s, nil
+			case "ctrl+u":
+				s.readerOffset = max(0, s.readerOffset-s.readerHeight()/2)
+				return s, nil
+			case "g":
+				s.readerOffset = 0
+				return s, nil
+			case "esc", "h", "left":
+				s.focusedPane = notesPaneList
+				return s, nil
+			}
+		}
+
+		switch msg.String() {
+		case "tab", "shift+tab":
+			if s.focusedPane == notesPaneList {
+				s.focusedPane = notesPaneReader
+			} else {
+				s.focusedPane = notesPaneList
+			}
+		case "l", "right", "enter":
+			if s.selectedEntry() != nil {
+				s.focusedPane = notesPaneReader
+			}
+		case "j", "down":
+			if s.selectedIndex < len(s.visibleEntries())-1 {
+				s.selectedIndex++
+				s.readerOffset = 0
+			}
+		case "k", "up":
+			if s.selectedIndex > 0 {
+				s.selectedIndex--
+				s.readerOffset = 0
+			}
+		case "g":
+			s.selectedIndex = 0
+			s.readerOffset = 0
+		case "G":
+			s.selectedIndex = max(0, len(s.visibleEntries())-1)
+			s.readerOffset = 0
+		case "c":
+			s.filter = (s.filter + 1) % (notesFilterCourses + len(s.courses))
+			s.selectedIndex = 0
+			s.readerOffset = 0
+		case "n":
+			courseID := ""
+			if s.filter >= notesFilterCourses {
+				courseID = s.courses[s.filter-notesFilterCourses].ID
+			}
+			s.editing = nil
+			s.noteForm = components.NewNoteForm(s.courses, courseID, "", "", nil, true)
+			s.showForm = true
+		case "e":
+			if entry := s.selectedEntry(); entry != nil {
+				editing := *entry
+				s.editing = &editing
+				s.noteForm = components.NewNoteForm(s.courses, entry.CourseID, entry.Title, entry.Content, entry.Tags, false)
+				s.showForm = true
+			}
+		case "d", "delete":
+			if s.selectedEntry() != nil {
+				s.showDeleteConfirm = true
+			}
+		case "r":
+			return s, s.loadNotes()
+		}
+	}
+
+	return s, nil
+}
+
+func (s *NotesScreen) View() string {
+	if s.width == 0 || s.height == 0 || s.loading {
+		return lipgloss.NewStyle().
+			Padding(2).
+			Foreground(styles.Info).
+			Render("Loading notes...")
+	}
+
+	if s.err != nil {
+		return lipgloss.NewStyle().
+			Padding(2).
+			Foreground(styles.Danger).
+			Render(fmt.Sprintf("Error: %v\n\nPress 'r' to retry", s.err))
+	}
+
+	if s.showForm {
+		return lipgloss.Place(
+			s.width,
+			s.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			s.noteForm.View(),
+		)
+	}
+
+	if s.showDeleteConfirm {
+		return s.renderDeleteConfirmDialog()
+	}
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Primary).
+		Padding(1, 0).
+		Render(" Notes " + styles.Dimmed.Render("· "+s.filterName()))
+
+	listWidth := s.width / 3
+	if listWidth < 26 {
+		listWidth = 26
+	}
+	readerWidth := s.width - listWidth - 6
+	if readerWidth < 30 {
+		readerWidth = 30
+	}
+	paneHeight := s.height - 9
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			s.renderListPane(listWidth, paneHeight),
+			s.renderReaderPane(readerWidth, paneHeight),
+		),
+		"",
+		s.renderShortcuts(),
+	)
+}
+
+// renderListPane renders the list of notes
+func (s *NotesScreen) renderListPane(width, height int) string {
+	entries := s.visibleEntries()
+
+	var lines []string
+	if len(entries) == 0 {
+		lines = append(lines, styles.Dimmed.Render("No notes yet. Press 'n' to write one."))
+	}
+
+	// Keep the selected note visible
+	visible := max(1, height/2)
+	start := 0
+	if s.selectedIndex >= visible {
+		start = s.selectedIndex - visible + 1
+	}
+
+	for i := start; i < len(entries) && i < start+visible; i++ {
+		entry := entries[i]
+
+		title := truncate(entry.Title, width-4)
+		meta := entry.UpdatedAt.Format("Jan 02")
+		if course := s.courseByID(entry.CourseID); course != nil {
+			meta = courseLabel(course) + " · " + meta
+		}
+
+		titleStyle := lipgloss.NewStyle().Padding(0, 1).Width(width - 2)
+		metaStyle := lipgloss.NewStyle().Padding(0, 1).Width(width - 2).Foreground(styles.Muted)
+		if i == s.selectedIndex {
+			if s.focusedPane == notesPaneList {
+				titleStyle = titleStyle.Background(styles.Primary).Foreground(styles.Background).Bold(true)
+				metaStyle = metaStyle.Background(styles.Primary).Foreground(styles.BackgroundLight)
+			} else {
+				titleStyle = titleStyle.Foreground(styles.Primary).Bold(true)
+			}
+		}
+
+		lines = append(lines, titleStyle.Render(title), metaStyle.Render(truncate(meta, width-4)))
+	}
+
+	paneStyle := styles.Panel.Width(width).Height(height)
+	if s.focusedPane == notesPaneList {
+		paneStyle = paneStyle.BorderForeground(styles.Primary)
+	}
+
+	return paneStyle.Render(strings.Join(lines, "\n"))
+}
+
+// renderReaderPane renders the content of the selected note
+func (s *NotesScreen) renderReaderPane(width, height int) string {
+	paneStyle := styles.Panel.Width(width).Height(height)
+	if s.focusedPane == notesPaneReader {
+		paneStyle = paneStyle.BorderForeground(styles.Primary)
+	}
+
+	entry := s.selectedEntry()
+	if entry == nil {
+		return paneStyle.Render(styles.Dimmed.Render("Select a note to read it."))
+	}
+
+	var header []string
+	header = append(header, styles.Title.Render(entry.Title))
+
+	meta := "Standalone note"
+	if course := s.courseByID(entry.CourseID); course != nil {
+		meta = course.Name
+		if course.Code != "" {
+			meta = course.Code + " - " + course.Name
+		}
+	}
+	meta += " · updated " + entry.UpdatedAt.Format("Jan 02, 2006 15:04")
+	header = append(header, styles.Dimmed.Render(meta))
+
+	if len(entry.Tags) > 0 {
+		var tags []string
+		for _, tag := range entry.Tags {
+			tags = append(tags, styles.Tag.Render("#"+tag))
+		}
+		header = append(header, strings.Join(tags, " "))
+	}
+	header = append(header, "")
+
+	body := entry.Content
+	if strings.TrimSpace(body) == "" {
+		body = styles.Dimmed.Render("This note is empty. Press 'e' to edit it.")
+	} else {
+		body = components.RenderMarkdown(body, width-4)
+	}
+
+	// Scroll the body below the fixed header
+	lines := strings.Split(body, "\n")
+	bodyHeight := max(1, height-len(header)-2)
+	maxOffset := max(0, len(lines)-bodyHeight)
+	if s.readerOffset > maxOffset {
+		s.readerOffset = maxOffset
+	}
+	end := min(len(lines), s.readerOffset+bodyHeight)
+	visible := lines[s.readerOffset:end]
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header...) + "\n" + strings.Join(visible, "\n")
+	if maxOffset > 0 {
+		content += "\n" + styles.Dimmed.Render(fmt.Sprintf("── %d/%d ──", end, len(lines)))
+	}
+
+	return paneStyle.Render(content)
+}
+
+// renderDeleteConfirmDialog renders the delete confirmation dialog
+func (s *NotesScreen) renderDeleteConfirmDialog() string {
+	entry := s.selectedEntry()
+	if entry == nil {
+		return ""
+	}
+
+	dialog := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Danger).
+		Padding(1, 2).
+		Render(lipgloss.JoinVertical(
+			lipgloss.Center,
+			styles.Title.Render(fmt.Sprintf("Delete note \"%s\"?", entry.Title)),
+			"",
+			styles.Dimmed.Render("It can be restored from the trash."),
+			"",
+			lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				styles.Shortcut.Render("y")+styles.ShortcutText.Render(" delete"),
+				"  ",
+				styles.Shortcut.Render("n")+styles.ShortcutText.Render(" cancel"),
+			),
+		))
+
+	return lipgloss.Place(
+		s.width,
+		s.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
+
+// renderShortcuts renders keyboard shortcuts or a feedback message
+func (s *NotesScreen) renderShortcuts() string {
+	if s.feedbackMsg != "" {
+		return s.feedbackMsg
+	}
+
+	var shortcuts []string
+	if s.focusedPane == notesPaneReader {
+		shortcuts = []string{
+			styles.Shortcut.Render("j/k") + styles.ShortcutText.Render(" scroll"),
+			styles.Shortcut.Render("ctrl+d/u") + styles.ShortcutText.Render(" page"),
+			styles.Shortcut.Render("e") + styles.ShortcutText.Render(" edit"),
+			styles.Shortcut.Render("esc") + styles.ShortcutText.Render(" back to list"),
+		}
+	} else {
+		shortcuts = []string{
+			styles.Shortcut.Render("j/k") + styles.ShortcutText.Render(" navigate"),
+			styles.Shortcut.Render("enter") + styles.ShortcutText.Render(" read"),
+			styles.Shortcut.Render("n") + styles.ShortcutText.Render(" new"),
+			styles.Shortcut.Render("e") + styles.ShortcutText.Render(" edit"),
+			styles.Shortcut.Render("d") + styles.ShortcutText.Render(" delete"),
+			styles.Shortcut.Render("c") + styles.ShortcutText.Render(" filter course"),
+			styles.Shortcut.Render("r") + styles.ShortcutText.Render(" refresh"),
+		}
+	}
+
+	return strings.Join(shortcuts, "  ")
+}
+
+// showFeedback shows a temporary feedback message in the shortcuts bar
+func (s *NotesScreen) showFeedback(text string, color lipgloss.Color) tea.Cmd {
+	s.feedbackMsg = lipgloss.NewStyle().Foreground(color).Render(text)
+	return tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return clearFeedbackMsg{} })
+}
+
+// filterName returns the description of the active list filter
+func (s *NotesScreen) filterName() string {
+	switch {
+	case s.filter == notesFilterAll:
+		return "All notes"
+	case s.filter == notesFilterStandalone:
+		return "Standalone notes"
+	default:
+		return courseLabel(&s.courses[s.filter-notesFilterCourses])
+	}
+}
+
+// visibleEntries returns the notes matching the active filter
+func (s *NotesScreen) visibleEntries() []noteEntry {
+	if s.filter == notesFilterAll {
+		return s.entries
+	}
+
+	courseID := ""
+	if s.filter >= notesFilterCourses {
+		courseID = s.courses[s.filter-notesFilterCourses].ID
+	}
+
+	var entries []noteEntry
+	for _, entry := range s.entries {
+		if entry.CourseID == courseID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// selectedEntry returns the note under the cursor
+func (s *NotesScreen) selectedEntry() *noteEntry {
+	entries := s.visibleEntries()
+	if s.selectedIndex < 0 || s.selectedIndex >= len(entries) {
+		return nil
+	}
+	return &entries[s.selectedIndex]
+}
+
+// revealNote shows all notes and opens the note with the given ID in the reader
+func (s *NotesScreen) revealNote(id string) {
+	for i, entry := range s.entries {
+		if entry.ID == id {
+			s.filter = notesFilterAll
+			s.selectedIndex = i
+			s.readerOffset = 0
+			s.focusedPane = notesPaneReader
+			return
+		}
+	}
+}
+
+// courseByID returns the loaded course with the given ID
+func (s *NotesScreen) courseByID(id string) *models.Course {
+	if id == "" {
+		return nil
+	}
+	for i := range s.courses {
+		if s.courses[i].ID == id {
+			return &s.courses[i]
+		}
+	}
+	return nil
+}
+
+// clampSelection keeps the cursor inside the visible notes
+func (s *NotesScreen) clampSelection() {
+	if n := len(s.visibleEntries()); s.selectedIndex >= n {
+		s.selectedIndex = max(0, n-1)
+	}
+}
+
+// readerHeight returns the approximate number of content lines in the reader
+func (s *NotesScreen) readerHeight() int {
+	return max(1, s.height-14)
+}
+
+// IsNoteFormActive returns true if the note form is currently active
+func (s *NotesScreen) IsNoteFormActive() bool {
+	return s.showForm
+}
+
+// loadNotes loads standalone notes, course notes and courses from the database
+func (s *NotesScreen) loadNotes() tea.Cmd {
+	return func() tea.Msg {
+		courses, err := s.db.Courses().GetAll()
+		if err != nil {
+			return notesLoadedMsg{err: err}
+		}
+
+		notes, err := s.db.Notes().FindAll()
+		if err != nil {
+			return notesLoadedMsg{err: err}
+		}
+
+		courseNotes, err := s.db.Courses().GetAllNotes()
+		if err != nil {
+			return notesLoadedMsg{err: err}
+		}
+
+		var entries []noteEntry
+		for _, n := range notes {
+			entries = append(entries, noteEntry{
+				ID:        n.ID,
+				Title:     n.Title,
+				Content:   n.Content,
+				Tags:      n.Tags,
+				Date:      n.CreatedAt,
+				CreatedAt: n.CreatedAt,
+				UpdatedAt: n.UpdatedAt,
+			})
+		}
+		for _, n := range courseNotes {
+			entries = append(entries, noteEntry{
+				ID:        n.ID,
+				CourseID:  n.CourseID,
+				Title:     n.Title,
+				Content:   n.Content,
+				Tags:      n.Tags,
+				Date:      n.Date,
+				CreatedAt: n.CreatedAt,
+				UpdatedAt: n.UpdatedAt,
+			})
+		}
+
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].UpdatedAt.After(entries[j].UpdatedAt)
+		})
+
+		return notesLoadedMsg{entries: entries, courses: courses}
+	}
+}
+
+// saveNote creates or updates a note. Moving a note between standalone and
+// course notes recreates it in the other table.
+func (s *NotesScreen) saveNote(original *noteEntry, form components.NoteForm) tea.Cmd {
+	return func() tea.Msg {
+		courseID := form.GetCourseID()
+
+		if original == nil {
+			return noteSavedMsg{err: s.createNote(courseID, form.GetTitle(), form.GetContent(), form.GetTags(), nil)}
+		}
+
+		switch {
+		case original.CourseID == "" && courseID == "":
+			note := &models.Note{
+				ID:        original.ID,
+				Title:     form.GetTitle(),
+				Content:   form.GetContent(),
+				Tags:      form.GetTags(),
+				CreatedAt: original.CreatedAt,
+			}
+			return noteSavedMsg{err: s.db.Notes().Update(note)}
+
+		case original.CourseID != "" && courseID != "":
+			note := &models.CourseNote{
+				ID:        original.ID,
+				CourseID:  courseID,
+				Title:     form.GetTitle(),
+				Content:   form.GetContent(),
+				Date:      original.Date,
+				Tags:      form.GetTags(),
+				CreatedAt: original.CreatedAt,
+			}
+			return noteSavedMsg{err: s.db.Courses().UpdateNote(note)}
+
+		default:
+			if err := s.createNote(courseID, form.GetTitle(), form.GetContent(), form.GetTags(), original); err != nil {
+				return noteSavedMsg{err: err}
+			}
+			// The moved note replaces the original, which is not kept in the trash
+			if err := s.trashNote(*original); err != nil {
+				return noteSavedMsg{err: err}
+			}
+			return noteSavedMsg{err: s.db.Trash().Purge(original.kind(), original.ID)}
+		}
+	}
+}
+
+// createNote creates a standalone or course note, keeping the creation date of
+// the original note when it is being moved
+func (s *NotesScreen) createNote(courseID, title, content string, tags []string, original *noteEntry) error {
+	if courseID == "" {
+		note := models.NewNote(title, content)
+		note.Tags = tags
+		if original != nil {
+			note.CreatedAt = original.CreatedAt
+		}
+		return s.db.Notes().Create(note)
+	}
+
+	note := models.NewCourseNote(courseID, title, content)
+	note.Tags = tags
+	if original != nil {
+		note.Date = original.Date
+		note.CreatedAt = original.CreatedAt
+	}
+	return s.db.Courses().CreateNote(note)
+}
+
+// deleteNote moves a standalone or course note to the trash
+func (s *NotesScreen) deleteNote(entry noteEntry) tea.Cmd {
+	return func() tea.Msg {
+		remove := func() error { return s.trashNote(entry) }
+		if err := remove(); err != nil {
+			return noteDeletedMsg{err: err}
+		}
+		recordDelete(s.db, entry.kind(), entry.ID, entry.Title, remove)
+		return noteDeletedMsg{}
+	}
+}
+
+// trashNote moves a standalone or course note to the trash
+func (s *NotesScreen) trashNote(entry noteEntry) error {
+	if entry.CourseID != "" {
+		return s.db.Courses().DeleteNote(entry.ID)
+	}
+	return s.db.Notes().Delete(entry.ID)
+}
+
+// Messages
+type notesLoadedMsg struct {
+	entries []noteEntry
+	courses []models.Course
+	err     error
+}
+
+type noteSavedMsg struct {
+	err error
+}
+
+type noteDeletedMsg struct {
+	err error
+}
+
+// courseLabel returns the course code, or its name when it has no code
+func courseLabel(course *models.Course) string {
+	if course.Code != "" {
+		return course.Code
+	}
+	return course.Name
+}
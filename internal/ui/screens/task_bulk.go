@@ -0,0 +1,391 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/dateparse"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/components"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// bulkPrompt is the value asked for a bulk action on the marked tasks
+type bulkPrompt int
+
+const (
+	bulkPromptNone bulkPrompt = iota
+	bulkPromptPriority
+	bulkPromptAddTag
+	bulkPromptRemoveTag
+	bulkPromptDue
+)
+
+// handleSelectionKey handles the keys of the selection mode. It returns false
+// for the keys left to the board, such as navigation.
+func (s *TaskScreen) handleSelectionKey(key string) (tea.Cmd, bool) {
+	switch key {
+	case "esc":
+		s.selecting = false
+		s.marked = make(map[string]bool)
+	case " ", "v":
+		if task := s.currentTask(); task != nil {
+			s.toggleMark(task.ID)
+		}
+	case "V":
+		// Mark the whole column or quadrant, or unmark it if it is all marked already
+		tasks, _ := s.cursorTasks()
+		allMarked := true
+		for _, task := range tasks {
+			allMarked = allMarked && s.marked[task.ID]
+		}
+		for _, task := range tasks {
+			if allMarked {
+				delete(s.marked, task.ID)
+			} else {
+				s.marked[task.ID] = true
+			}
+		}
+	case "m":
+		if len(s.marked) > 0 {
+			s.moveMode = true
+			s.targetColumn = s.activeColumn
+		}
+	case "p":
+		return s.openBulkPrompt(bulkPromptPriority, "Priority:", "low, medium, high or urgent"), true
+	case "+":
+		return s.openBulkPrompt(bulkPromptAddTag, "Add tag:", "e.g. exam"), true
+	case "-":
+		return s.openBulkPrompt(bulkPromptRemoveTag, "Remove tag:", "e.g. exam"), true
+	case "D":
+		return s.openBulkPrompt(bulkPromptDue, "Due date:", "e.g. fri 23:59 or +3d, empty to clear"), true
+	case "delete", "backspace":
+		if len(s.marked) > 0 {
+			s.showDeleteConfirm = true
+		}
+	case "a":
+		if len(s.marked) > 0 {
+			return s.bulkArchive(), true
+		}
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// toggleMark marks or unmarks a task
+func (s *TaskScreen) toggleMark(id string) {
+	if s.marked[id] {
+		delete(s.marked, id)
+	} else {
+		s.marked[id] = true
+	}
+}
+
+// markedIDs returns the IDs of the marked tasks in board order
+func (s *TaskScreen) markedIDs() []string {
+	var ids []string
+	for _, task := range s.tasks {
+		if s.marked[task.ID] {
+			ids = append(ids, task.ID)
+		}
+	}
+	return ids
+}
+
+// openBulkPrompt asks for the value of a bulk action
+func (s *TaskScreen) openBulkPrompt(prompt bulkPrompt, label, placeholder string) tea.Cmd {
+	if len(s.marked) == 0 {
+		return nil
+	}
+	s.bulkPrompt = prompt
+	s.bulkErr = ""
+	s.bulkInput = components.NewInput(label, placeholder)
+	return s.bulkInput.Focus()
+}
+
+// submitBulkPrompt applies the bulk action with the value entered, or shows
+// why the value is not understood
+func (s *TaskScreen) submitBulkPrompt() tea.Cmd {
+	value := strings.TrimSpace(s.bulkInput.Value())
+
+	var action string
+	var edit func(task *models.Task) bool
+	switch s.bulkPrompt {
+	case bulkPromptPriority:
+		priority, ok := models.ParseTaskPriority(value)
+		if !ok {
+			s.bulkErr = fmt.Sprintf("unknown priority: %s", value)
+			return nil
+		}
+		action = fmt.Sprintf("set priority %s", priority)
+		edit = func(task *models.Task) bool {
+			changed := task.Priority != priority
+			task.Priority = priority
+			return changed
+		}
+
+	case bulkPromptAddTag, bulkPromptRemoveTag:
+		tag := strings.TrimPrefix(value, "#")
+		if tag == "" {
+			s.bulkErr = "enter a tag"
+			return nil
+		}
+		if s.bulkPrompt == bulkPromptAddTag {
+			action = fmt.Sprintf("tag #%s", tag)
+			edit = func(task *models.Task) bool {
+				if hasTag(task.Tags, tag) {
+					return false
+				}
+				task.Tags = append(append([]string{}, task.Tags...), tag)
+				return true
+			}
+		} else {
+			action = fmt.Sprintf("untag #%s", tag)
+			edit = func(task *models.Task) bool {
+				if !hasTag(task.Tags, tag) {
+					return false
+				}
+				var tags []string
+				for _, t := range task.Tags {
+					if !strings.EqualFold(t, tag) {
+						tags = append(tags, t)
+					}
+				}
+				task.Tags = tags
+				return true
+			}
+		}
+
+	case bulkPromptDue:
+		var due *time.Time
+		action = "clear due date"
+		if value != "" {
+			result, err := dateparse.Parse(value, time.Now())
+			if err != nil {
+				s.bulkErr = err.Error()
+				return nil
+			}
+			due = &result.Time
+			action = "set due " + result.Time.Format("Jan 02")
+		}
+		edit = func(task *models.Task) bool {
+			if task.DueDate == nil && due == nil || task.DueDate != nil && due != nil && task.DueDate.Equal(*due) {
+				return false
+			}
+			task.DueDate = due
+			return true
+		}
+	}
+
+	s.bulkPrompt = bulkPromptNone
+	return s.bulkEdit(action, edit)
+}
+
+// hasTag returns true if tags contain tag, ignoring case
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// bulkEdit applies edit to the marked tasks and saves the ones it changed in
+// a single transaction. edit returns false if it left a task unchanged.
+func (s *TaskScreen) bulkEdit(action string, edit func(task *models.Task) bool) tea.Cmd {
+	ids := s.markedIDs()
+	return func() tea.Msg {
+		var before, after []models.Task
+		for _, id := range ids {
+			task, err := s.db.Tasks().FindByID(id)
+			if err != nil {
+				return bulkAppliedMsg{err: err}
+			}
+			original := *task
+			if edit(task) {
+				before = append(before, original)
+				after = append(after, *task)
+			}
+		}
+		if len(after) == 0 {
+			return bulkAppliedMsg{warning: "The marked tasks already had that"}
+		}
+
+		if err := s.db.Tasks().UpdateMany(after); err != nil {
+			return bulkAppliedMsg{err: err}
+		}
+		description := fmt.Sprintf("%s on %d tasks", action, len(after))
+		recordTaskChanges(s.db, description, before, after, nil)
+		return bulkAppliedMsg{summary: description}
+	}
+}
+
+// bulkMove moves the marked tasks to a column in a single transaction.
+// Tasks already in the column are left alone and blocked tasks are kept out
+// of a Done column; nothing is moved if the tasks exceed its WIP limit.
+func (s *TaskScreen) bulkMove(target Column) tea.Cmd {
+	ids := s.markedIDs()
+	column := s.columns[target]
+	running := s.runningEntry
+	return func() tea.Msg {
+		completing := column.Status() == models.TaskStatusCompleted
+
+		var before, moving []models.Task
+		var blocked []string
+		for _, id := range ids {
+			task, err := s.db.Tasks().FindByID(id)
+			if err != nil {
+				return bulkAppliedMsg{err: err}
+			}
+			if column.Holds(task.Status) {
+				continue
+			}
+			if completing && len(task.BlockedBy()) > 0 {
+				blocked = append(blocked, task.Title)
+				continue
+			}
+			before = append(before, *task)
+			moving = append(moving, *task)
+		}
+
+		var skipped string
+		if len(blocked) > 0 {
+			skipped = fmt.Sprintf(", %d blocked tasks were left out", len(blocked))
+		}
+		if len(moving) == 0 {
+			return bulkAppliedMsg{warning: fmt.Sprintf("No marked tasks to move to %s%s", column.Name, skipped)}
+		}
+
+		if column.WIPLimit > 0 {
+			count, err := s.db.Tasks().CountByStatus(column.Statuses...)
+			if err != nil {
+				return bulkAppliedMsg{err: err}
+			}
+			if count+len(moving) > column.WIPLimit {
+				return bulkAppliedMsg{warning: fmt.Sprintf("%s has room for %d more tasks, %d are marked",
+					column.Name, max(column.WIPLimit-count, 0), len(moving))}
+			}
+		}
+
+		var created []models.Task
+		if completing {
+			// Finishing a task stops its timer
+			for _, task := range moving {
+				if running != nil && running.TaskID == task.ID {
+					if _, err := s.db.TimeEntries().Stop(); err != nil {
+						return bulkAppliedMsg{err: err}
+					}
+				}
+			}
+			var err error
+			if created, err = s.db.Tasks().CompleteMany(moving); err != nil {
+				return bulkAppliedMsg{err: err}
+			}
+		} else {
+			for i := range moving {
+				moving[i].Status = column.Status()
+			}
+			if err := s.db.Tasks().UpdateMany(moving); err != nil {
+				return bulkAppliedMsg{err: err}
+			}
+		}
+
+		description := fmt.Sprintf("move %d tasks to %s", len(moving), column.Name)
+		recordTaskChanges(s.db, description, before, moving, created)
+		if skipped != "" {
+			return bulkAppliedMsg{warning: description + skipped}
+		}
+		return bulkAppliedMsg{summary: description}
+	}
+}
+
+// bulkDelete moves the marked tasks to the trash in a single transaction
+func (s *TaskScreen) bulkDelete() tea.Cmd {
+	ids := s.markedIDs()
+	running := s.runningEntry
+	return func() tea.Msg {
+		// Deleting a task stops its timer
+		for _, id := range ids {
+			if running != nil && running.TaskID == id {
+				if _, err := s.db.TimeEntries().Stop(); err != nil {
+					return bulkAppliedMsg{err: err}
+				}
+			}
+		}
+		if err := s.db.Tasks().DeleteMany(ids); err != nil {
+			return bulkAppliedMsg{err: err}
+		}
+		recordBulkDelete(s.db, ids)
+		return bulkAppliedMsg{summary: fmt.Sprintf("delete %d tasks", len(ids))}
+	}
+}
+
+// bulkArchive moves the marked tasks to the archive in a single transaction
+func (s *TaskScreen) bulkArchive() tea.Cmd {
+	ids := s.markedIDs()
+	running := s.runningEntry
+	return func() tea.Msg {
+		// Archiving a task stops its timer
+		for _, id := range ids {
+			if running != nil && running.TaskID == id {
+				if _, err := s.db.TimeEntries().Stop(); err != nil {
+					return bulkAppliedMsg{err: err}
+				}
+			}
+		}
+		if err := s.db.Tasks().ArchiveMany(ids); err != nil {
+			return bulkAppliedMsg{err: err}
+		}
+		recordBulkArchive(s.db, ids)
+		return bulkAppliedMsg{summary: fmt.Sprintf("archive %d tasks", len(ids))}
+	}
+}
+
+// renderSelectionShortcuts renders the keys of the selection mode, or the
+// prompt of a bulk action
+func (s *TaskScreen) renderSelectionShortcuts() string {
+	if s.bulkPrompt != bulkPromptNone {
+		status := styles.Dimmed.Render(fmt.Sprintf("Applies to %d marked tasks  •  enter apply  esc cancel", len(s.marked)))
+		if s.bulkErr != "" {
+			status = lipgloss.NewStyle().Foreground(styles.Danger).Render("⚠ " + s.bulkErr)
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, s.bulkInput.ViewInline(), status)
+	}
+
+	shortcuts := []string{
+		styles.Shortcut.Render("space") + styles.ShortcutText.Render(" mark"),
+		styles.Shortcut.Render("V") + styles.ShortcutText.Render(" mark column"),
+		styles.Shortcut.Render("m") + styles.ShortcutText.Render(" move"),
+		styles.Shortcut.Render("p") + styles.ShortcutText.Render(" priority"),
+		styles.Shortcut.Render("+/-") + styles.ShortcutText.Render(" tag"),
+		styles.Shortcut.Render("D") + styles.ShortcutText.Render(" due"),
+		styles.Shortcut.Render("del") + styles.ShortcutText.Render(" delete"),
+		styles.Shortcut.Render("a") + styles.ShortcutText.Render(" archive"),
+		styles.Shortcut.Render("esc") + styles.ShortcutText.Render(" done"),
+	}
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		strings.Join(shortcuts, "  "),
+		lipgloss.NewStyle().Foreground(styles.AutumnYellow).Render(fmt.Sprintf("%d tasks marked", len(s.marked))),
+	)
+}
+
+// capitalize upper-cases the first letter of a bulk action summary
+func capitalize(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+// Messages
+type bulkAppliedMsg struct {
+	summary string // What was done, e.g. "move 3 tasks to Done"
+	warning string // Why nothing or not everything was done
+	err     error
+}
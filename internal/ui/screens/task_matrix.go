@@ -0,0 +1,225 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// getTasksForQuadrant returns the open tasks in a quadrant of the priority
+// matrix
+func (s *TaskScreen) getTasksForQuadrant(quadrant models.Quadrant) []models.Task {
+	now := time.Now()
+	var tasks []models.Task
+	for _, task := range s.tasks {
+		if task.Status == models.TaskStatusCompleted || task.Status == models.TaskStatusCancelled {
+			continue
+		}
+		if s.matrix.QuadrantOf(task, now) == quadrant {
+			tasks = append(tasks, task)
+		}
+	}
+	models.SortTasks(tasks, models.TaskSortPriority)
+	return tasks
+}
+
+// cursorTasks returns the tasks of the column, or the quadrant in the
+// priority matrix, with the cursor and the index of the cursor in them
+func (s *TaskScreen) cursorTasks() ([]models.Task, int) {
+	if s.showMatrix {
+		return s.getTasksForQuadrant(s.quadrant), s.matrixCursors[s.quadrant]
+	}
+	return s.getTasksForColumn(s.activeColumn), s.cursors[s.activeColumn]
+}
+
+// handleMatrixKey handles the navigation keys of the priority matrix. It
+// returns false for the keys left to the board, such as editing a task.
+func (s *TaskScreen) handleMatrixKey(key string) (tea.Cmd, bool) {
+	quadrant := s.quadrant
+	tasks := s.getTasksForQuadrant(quadrant)
+	cursor := s.matrixCursors[quadrant]
+
+	switch key {
+	case "tab":
+		s.quadrant = (quadrant + 1) % models.QuadrantCount
+	case "shift+tab":
+		s.quadrant = (quadrant + models.QuadrantCount - 1) % models.QuadrantCount
+	case "left", "h":
+		if quadrant%2 == 1 {
+			s.quadrant--
+		}
+	case "right", "l":
+		if quadrant%2 == 0 {
+			s.quadrant++
+		}
+	case "j", "down":
+		// Past the last task the cursor goes on to the quadrant below
+		if cursor < len(tasks)-1 {
+			s.matrixCursors[quadrant]++
+		} else if quadrant < 2 {
+			s.quadrant += 2
+			s.matrixCursors[s.quadrant] = 0
+		}
+	case "k", "up":
+		if cursor > 0 {
+			s.matrixCursors[quadrant]--
+		} else if quadrant >= 2 {
+			s.quadrant -= 2
+			s.matrixCursors[s.quadrant] = max(len(s.getTasksForQuadrant(s.quadrant))-1, 0)
+		}
+	case "g":
+		s.matrixCursors[quadrant] = 0
+	case "G":
+		s.matrixCursors[quadrant] = max(len(tasks)-1, 0)
+	case "enter":
+		if cursor < len(tasks) {
+			s.selectedTaskID = tasks[cursor].ID
+			s.showDetails = true
+		}
+	case " ":
+		if cursor < len(tasks) {
+			if s.selectedTaskID == tasks[cursor].ID {
+				s.selectedTaskID = ""
+			} else {
+				s.selectedTaskID = tasks[cursor].ID
+			}
+		}
+	case "m":
+		if task := s.getTaskByID(s.selectedTaskID); task != nil {
+			s.moveMode = true
+			s.targetColumn = Column(models.BoardColumnOf(s.columns, task.Status))
+		}
+	case "o", "J", "K":
+		return s.showFeedback("Quadrants are sorted by priority, switch to the board (M) to sort columns", styles.Warning), true
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// clampMatrixCursors keeps the cursor of each quadrant on a task after the
+// tasks are reloaded
+func (s *TaskScreen) clampMatrixCursors() {
+	for quadrant := models.Quadrant(0); quadrant < models.QuadrantCount; quadrant++ {
+		if count := len(s.getTasksForQuadrant(quadrant)); s.matrixCursors[quadrant] >= count {
+			s.matrixCursors[quadrant] = max(count-1, 0)
+		}
+	}
+}
+
+// renderMatrix renders the open tasks in the urgent/important quadrants
+func (s *TaskScreen) renderMatrix() string {
+	width := max((s.width-10)/2+3, 30)
+	height := max((s.height-10)/2, 3)
+
+	var quadrants []string
+	for quadrant := models.Quadrant(0); quadrant < models.QuadrantCount; quadrant++ {
+		quadrants = append(quadrants, s.renderQuadrant(quadrant, width, height))
+	}
+
+	matrix := lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Top, quadrants[models.QuadrantDoFirst], quadrants[models.QuadrantSchedule]),
+		lipgloss.JoinHorizontal(lipgloss.Top, quadrants[models.QuadrantDelegate], quadrants[models.QuadrantEliminate]),
+	)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		matrix,
+		"",
+		s.renderShortcuts(),
+	)
+}
+
+// renderQuadrant renders a quadrant of the priority matrix, scrolled to keep
+// the cursor in view
+func (s *TaskScreen) renderQuadrant(quadrant models.Quadrant, width, height int) string {
+	tasks := s.getTasksForQuadrant(quadrant)
+	active := s.quadrant == quadrant
+
+	headerColor := map[models.Quadrant]lipgloss.Color{
+		models.QuadrantDoFirst:   styles.Danger,
+		models.QuadrantSchedule:  styles.Primary,
+		models.QuadrantDelegate:  styles.Warning,
+		models.QuadrantEliminate: styles.Muted,
+	}[quadrant]
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(headerColor).
+		Width(width).
+		Align(lipgloss.Center).
+		Render(fmt.Sprintf("%s (%d)", quadrant.Title(), len(tasks)) + styles.Dimmed.Render(" · "+quadrant.Description()))
+
+	panelStyle := styles.Panel.
+		Width(width).
+		Height(height)
+	if active {
+		panelStyle = panelStyle.BorderForeground(styles.Primary)
+	}
+
+	var cards []string
+	for i, task := range tasks {
+		isSelected := task.ID == s.selectedTaskID || s.marked[task.ID]
+		isCursor := active && i == s.matrixCursors[quadrant]
+		column := s.columns[models.BoardColumnOf(s.columns, task.Status)]
+		cards = append(cards, s.renderKanbanTask(task, isSelected, isCursor)+styles.Dimmed.Render(" "+column.Name))
+	}
+
+	if len(cards) == 0 {
+		emptyMsg := lipgloss.NewStyle().
+			Foreground(styles.Muted).
+			Italic(true).
+			Render("No tasks")
+		return lipgloss.JoinVertical(lipgloss.Left, header, panelStyle.Render(emptyMsg))
+	}
+
+	// Show the tasks from the one that keeps the cursor in view
+	cursor := min(s.matrixCursors[quadrant], len(cards)-1)
+	cardWidth := width - panelStyle.GetHorizontalFrameSize()
+	start, used := cursor, 0
+	for start >= 0 {
+		lines := lipgloss.Height(lipgloss.NewStyle().Width(cardWidth).Render(cards[start])) + 1
+		if used+lines > height && start < cursor {
+			break
+		}
+		used += lines
+		start--
+	}
+	start++
+
+	divider := lipgloss.NewStyle().
+		Foreground(styles.Border).
+		Render("─────────────────────")
+	content := lipgloss.NewStyle().Width(cardWidth).Render(strings.Join(cards[start:], "\n"+divider+"\n"))
+	if lines := strings.Split(content, "\n"); len(lines) > height {
+		content = strings.Join(lines[:height], "\n")
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		panelStyle.Render(content),
+	)
+}
+
+// renderMoveTargets renders the columns a task can be moved to from the
+// priority matrix, with the target highlighted
+func (s *TaskScreen) renderMoveTargets() string {
+	var names []string
+	for i, column := range s.columns {
+		style := lipgloss.NewStyle().Padding(0, 1).Foreground(styles.Muted)
+		if Column(i) == s.targetColumn {
+			style = style.
+				Background(styles.AutumnYellow).
+				Foreground(styles.Background).
+				Bold(true)
+		}
+		names = append(names, style.Render(column.Name))
+	}
+	return styles.ShortcutText.Render("Move to: ") + strings.Join(names, " ")
+}
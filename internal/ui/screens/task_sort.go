@@ -0,0 +1,101 @@
+package screens
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// sortMode returns how the tasks of a column are ordered
+func (s *TaskScreen) sortMode(column Column) models.TaskSortMode {
+	if mode, ok := s.sortModes[column]; ok {
+		return mode
+	}
+	return models.TaskSortPriority
+}
+
+// cycleSortMode switches the active column to the next sort mode, keeping
+// the cursor on the same task, and saves the choice
+func (s *TaskScreen) cycleSortMode() tea.Cmd {
+	column := s.activeColumn
+	current := s.currentTask()
+
+	mode := s.sortMode(column).Next()
+	s.sortModes[column] = mode
+	if current != nil {
+		for i, task := range s.getTasksForColumn(column) {
+			if task.ID == current.ID {
+				s.cursors[column] = i
+			}
+		}
+	}
+
+	name := s.columns[column].Name
+	return tea.Batch(
+		s.saveSortMode(name, mode),
+		s.showFeedback(fmt.Sprintf("Sorting %s by %s", name, mode.Label()), styles.Info),
+	)
+}
+
+// moveTaskInColumn moves the task under the cursor up (delta -1) or down
+// (delta 1) in a manually sorted column and saves the new order
+func (s *TaskScreen) moveTaskInColumn(delta int) tea.Cmd {
+	column := s.activeColumn
+	name := s.columns[column].Name
+	if s.sortMode(column) != models.TaskSortManual {
+		return s.showFeedback(fmt.Sprintf("Sort %s manually (o) to rearrange its tasks", name), styles.Warning)
+	}
+
+	tasks := s.getTasksForColumn(column)
+	i := s.cursors[column]
+	j := i + delta
+	if i >= len(tasks) || j < 0 || j >= len(tasks) {
+		return nil
+	}
+
+	// Swap with the neighbour, tasks hidden by a filter keep their place.
+	// The new order is shown right away, the tasks are not reloaded.
+	a, b := tasks[i].ID, tasks[j].ID
+	taskA, taskB := s.getTaskByID(a), s.getTaskByID(b)
+	taskA.Position, taskB.Position = taskB.Position, taskA.Position
+	s.cursors[column] = j
+
+	return func() tea.Msg {
+		if err := s.db.Tasks().SwapPositions(a, b); err != nil {
+			return tasksReorderedMsg{err: err}
+		}
+		recordTaskSwap(s.db, name, a, b)
+		return tasksReorderedMsg{}
+	}
+}
+
+// loadSortModes loads the sort modes saved for the columns
+func (s *TaskScreen) loadSortModes() tea.Cmd {
+	return func() tea.Msg {
+		modes, err := s.db.BoardSorts().FindAll()
+		return sortModesLoadedMsg{modes: modes, err: err}
+	}
+}
+
+// saveSortMode remembers the sort mode of a column for the next sessions
+func (s *TaskScreen) saveSortMode(column string, mode models.TaskSortMode) tea.Cmd {
+	return func() tea.Msg {
+		return sortModeSavedMsg{err: s.db.BoardSorts().Save(column, mode)}
+	}
+}
+
+// Messages
+type sortModesLoadedMsg struct {
+	modes map[string]models.TaskSortMode // By column name
+	err   error
+}
+
+type sortModeSavedMsg struct {
+	err error
+}
+
+type tasksReorderedMsg struct {
+	err error
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"sort"
 	"strings"
 	"time"
 
@@ -16,27 +15,35 @@ import (
 	"github.com/stiffis/UniCLI/internal/ui/styles"
 )
 
-// Column represents a kanban column
+// Column is the index of a kanban column in the board configuration
 type Column int
 
-const (
-	ColumnTodo Column = iota
-	ColumnInProgress
-	ColumnDone
-)
-
 // TaskScreen is the tasks view
 type TaskScreen struct {
 	db             *database.DB
 	tasks          []models.Task
-	activeColumn   Column         // Which column has focus
-	cursors        map[Column]int // Cursor position for each column
-	selectedTaskID string         // ID of selected task (empty if none)
+	courses        []models.Course
+	columns        []models.BoardColumn
+	activeColumn   Column                         // Which column has focus
+	cursors        map[Column]int                 // Cursor position for each column
+	sortModes      map[Column]models.TaskSortMode // Sort mode of each column, priority if unset
+	selectedTaskID string                         // ID of selected task (empty if none)
 	width          int
 	height         int
 	loading        bool
 	err            error
 	feedbackMsg    string
+	jumpTaskID     string        // Task to select once tasks are loaded
+	archiveAfter   time.Duration // Completed tasks older than this are archived on load, 0 keeps them
+
+	// Filter state
+	filter          models.TaskFilter
+	filterName      string // Name of the smart list the filter came from
+	isFiltering     bool
+	filterInput     components.Input
+	filterErr       string
+	isNamingFilter  bool
+	filterNameInput components.Input
 
 	// Form state
 	showForm          bool
@@ -44,28 +51,55 @@ type TaskScreen struct {
 	showDetails       bool
 	taskForm          components.TaskForm
 
+	// Template state
+	showTemplates   bool
+	templateManager *components.TemplateManager
+
 	// Move mode state
 	moveMode     bool
 	targetColumn Column
 
+	// Priority matrix state
+	showMatrix    bool
+	matrix        models.MatrixThresholds
+	quadrant      models.Quadrant         // Which quadrant has focus
+	matrixCursors map[models.Quadrant]int // Cursor position for each quadrant
+
+	// Selection mode state, bulk actions apply to the marked tasks
+	selecting  bool
+	marked     map[string]bool
+	bulkPrompt bulkPrompt
+	bulkInput  components.Input
+	bulkErr    string
+
+	// Time tracking state
+	runningEntry   *models.TimeEntry // Running timer, nil if none
+	showTimeReport bool
+	timeReport     timeReportLoadedMsg
+
 	// Details view state
 	subtaskCursor             int
 	isCreatingSubtask         bool
+	isRenamingSubtask         bool
 	subtaskInput              components.Input
 	isConfirmingDeleteSubtask bool
 }
 
-// NewTaskScreen creates a new task screen
-func NewTaskScreen(db *database.DB) *TaskScreen {
+// NewTaskScreen creates a new task screen with the given board columns.
+// Tasks completed longer than archiveAfter ago are archived whenever the
+// board is loaded, and matrix places open tasks in the priority matrix.
+func NewTaskScreen(db *database.DB, columns []models.BoardColumn, archiveAfter time.Duration, matrix models.MatrixThresholds) *TaskScreen {
 	return &TaskScreen{
-		db:           db,
-		tasks:        []models.Task{},
-		activeColumn: ColumnTodo,
-		cursors: map[Column]int{
-			ColumnTodo:       0,
-			ColumnInProgress: 0,
-			ColumnDone:       0,
-		},
+		db:                        db,
+		columns:                   columns,
+		archiveAfter:              archiveAfter,
+		matrix:                    matrix,
+		matrixCursors:             make(map[models.Quadrant]int),
+		tasks:                     []models.Task{},
+		activeColumn:              0,
+		cursors:                   make(map[Column]int),
+		sortModes:                 make(map[Column]models.TaskSortMode),
+		marked:                    make(map[string]bool),
 		selectedTaskID:            "",
 		loading:                   true,
 		showForm:                  false,
@@ -79,12 +113,20 @@ func NewTaskScreen(db *database.DB) *TaskScreen {
 
 // Init initializes the task screen
 func (s *TaskScreen) Init() tea.Cmd {
-	return s.loadTasks()
+	return tea.Batch(s.loadTasks(), s.loadSortModes())
 }
 
 func (s *TaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	// The template manager gets the keys and the templates it loads
+	if s.showTemplates {
+		switch msg.(type) {
+		case tea.KeyMsg, components.TemplatesMsg:
+			return s.updateTemplateManager(msg)
+		}
+	}
+
 	// Top-level message handling
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -95,57 +137,74 @@ func (s *TaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Command-related messages
 	case tasksLoadedMsg:
 		s.tasks = msg.tasks
+		s.courses = msg.courses
+		s.runningEntry = msg.running
 		s.loading = false
 		s.err = msg.err
 
-		// Sort tasks with custom logic
-		priorityMap := map[models.TaskPriority]int{
-			models.TaskPriorityUrgent: 4,
-			models.TaskPriorityHigh:   3,
-			models.TaskPriorityMedium: 2,
-			models.TaskPriorityLow:    1,
+		// Adjust cursors if needed
+		for i := range s.columns {
+			col := Column(i)
+			tasks := s.getTasksForColumn(col)
+			if s.cursors[col] >= len(tasks) && len(tasks) > 0 {
+				s.cursors[col] = len(tasks) - 1
+			}
 		}
-		sort.Slice(s.tasks, func(i, j int) bool {
-			taskA := s.tasks[i]
-			taskB := s.tasks[j]
-
-			// Rule 1: Overdue status
-			if isAOverdue := taskA.IsOverdue(); isAOverdue != taskB.IsOverdue() {
-				return isAOverdue
+		s.clampMatrixCursors()
+		// Tasks that left the board are no longer marked
+		for id := range s.marked {
+			if s.getTaskByID(id) == nil {
+				delete(s.marked, id)
 			}
+		}
+		if s.jumpTaskID != "" {
+			s.revealTask(s.jumpTaskID)
+			s.jumpTaskID = ""
+		}
+		if msg.archived > 0 {
+			return s, s.showFeedback(fmt.Sprintf("Archived %d tasks completed over %d days ago", msg.archived, int(s.archiveAfter.Hours()/24)), styles.Info)
+		}
+		return s, nil
 
-			// Rule 2: Priority
-			priorityA := priorityMap[taskA.Priority]
-			priorityB := priorityMap[taskB.Priority]
-			if priorityA != priorityB {
-				return priorityA > priorityB
+	case sortModesLoadedMsg:
+		if msg.err != nil {
+			return s, s.showFeedback(fmt.Sprintf("Could not load sort modes: %v", msg.err), styles.Danger)
+		}
+		for i, column := range s.columns {
+			if mode, ok := msg.modes[column.Name]; ok {
+				s.sortModes[Column(i)] = mode
 			}
+		}
+		return s, nil
 
-			// Rule 3: Due Date
-			if taskA.DueDate != nil && taskB.DueDate == nil {
-				return true
-			}
-			if taskA.DueDate == nil && taskB.DueDate != nil {
-				return false
-			}
-			if taskA.DueDate != nil && taskB.DueDate != nil {
-				if !taskA.DueDate.Equal(*taskB.DueDate) {
-					return taskA.DueDate.Before(*taskB.DueDate)
-				}
-			}
+	case sortModeSavedMsg:
+		if msg.err != nil {
+			return s, s.showFeedback(fmt.Sprintf("Could not save sort mode: %v", msg.err), styles.Danger)
+		}
+		return s, nil
 
-			// Rule 4: Creation Date
-			return taskA.CreatedAt.Before(taskB.CreatedAt)
-		})
-		// Adjust cursors if needed
-		for col := ColumnTodo; col <= ColumnDone; col++ {
-			tasks := s.getTasksForColumn(col)
-			if s.cursors[col] >= len(tasks) && len(tasks) > 0 {
-				s.cursors[col] = len(tasks) - 1
-			}
+	case tasksReorderedMsg:
+		if msg.err != nil {
+			return s, tea.Batch(s.loadTasks(), s.showFeedback(fmt.Sprintf("Could not reorder tasks: %v", msg.err), styles.Danger))
 		}
 		return s, nil
 
+	case JumpToMsg:
+		// Close any overlay and reveal the task once reloaded
+		s.showForm = false
+		s.showTemplates = false
+		s.showDeleteConfirm = false
+		s.showDetails = false
+		s.moveMode = false
+		s.selecting = false
+		s.marked = make(map[string]bool)
+		s.bulkPrompt = bulkPromptNone
+		s.isCreatingSubtask = false
+		s.isRenamingSubtask = false
+		s.isConfirmingDeleteSubtask = false
+		s.jumpTaskID = msg.ID
+		return s, s.loadTasks()
+
 	case tasksExportedMsg:
 		if msg.err != nil {
 			s.feedbackMsg = lipgloss.NewStyle().Foreground(styles.Danger).Render(fmt.Sprintf("Export failed: %v", msg.err))
@@ -158,16 +217,116 @@ func (s *TaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		s.feedbackMsg = ""
 		return s, nil
 
-	case subtaskToggledMsg, subtaskCreatedMsg, subtaskDeletedMsg:
+	case timerToggledMsg:
+		switch {
+		case msg.err != nil:
+			return s, s.showFeedback(fmt.Sprintf("Timer failed: %v", msg.err), styles.Danger)
+		case msg.started:
+			return s, tea.Batch(s.loadTasks(), s.showFeedback(fmt.Sprintf("⏱ Tracking time on \"%s\"", msg.title), styles.Info))
+		}
+		return s, tea.Batch(s.loadTasks(), s.showFeedback(
+			fmt.Sprintf("⏱ Stopped \"%s\" after %s", msg.title, models.FormatMinutes(msg.entry.DurationSeconds/60)), styles.Success))
+
+	case timeReportLoadedMsg:
+		s.timeReport = msg
+		return s, nil
+
+	case PomodoroLoggedMsg:
+		return s, s.loadTasks()
+
+	case ApplyFilterMsg:
+		filter, err := models.ParseTaskFilter(msg.Query, time.Now())
+		if err != nil {
+			return s, s.showFeedback(fmt.Sprintf("Invalid smart list %q: %v", msg.Name, err), styles.Danger)
+		}
+		s.showForm = false
+		s.showDeleteConfirm = false
+		s.showDetails = false
+		s.moveMode = false
+		s.isFiltering = false
+		s.isNamingFilter = false
+		s.selectedTaskID = ""
+		s.filter = filter
+		s.filterName = msg.Name
+		return s, s.loadTasks()
+
+	case filterSavedMsg:
+		if msg.err != nil {
+			return s, s.showFeedback(fmt.Sprintf("Could not save smart list: %v", msg.err), styles.Danger)
+		}
+		s.filterName = msg.name
+		return s, tea.Batch(
+			s.showFeedback(fmt.Sprintf("Saved smart list \"%s\"", msg.name), styles.Success),
+			func() tea.Msg { return SavedFiltersChangedMsg{} },
+		)
+
+	case subtaskToggledMsg, subtaskCreatedMsg, subtaskDeletedMsg, subtaskRenamedMsg, subtaskMovedMsg:
 		// The message types would need an `error` field for this to be useful
 		return s, s.loadTasks()
 
-	case taskCreatedMsg, taskUpdatedMsg, taskMovedMsg, taskDeletedMsg:
+	case taskMovedMsg:
+		s.showDetails = false
+		s.selectedTaskID = ""
+		switch {
+		case msg.err != nil:
+			return s, tea.Batch(s.loadTasks(), s.showFeedback(fmt.Sprintf("Could not move task: %v", msg.err), styles.Danger))
+		case len(msg.blockers) > 0:
+			return s, tea.Batch(s.loadTasks(), s.showFeedback(
+				fmt.Sprintf("⊘ \"%s\" is blocked by %s, complete it first", msg.task.Title, dependencyTitles(msg.blockers)), styles.Warning))
+		case msg.full != nil:
+			return s, tea.Batch(s.loadTasks(), s.showFeedback(
+				fmt.Sprintf("%s is at its limit of %d tasks, finish one first", msg.full.Name, msg.full.WIPLimit), styles.Warning))
+		case msg.next != nil && msg.next.DueDate != nil:
+			return s, tea.Batch(s.loadTasks(), s.showFeedback(
+				fmt.Sprintf("↻ Next \"%s\" due %s", msg.next.Title, msg.next.DueDate.Format("Mon, 02 Jan")), styles.Success))
+		}
+		return s, s.loadTasks()
+
+	case taskCreatedMsg, taskUpdatedMsg:
+		s.showDetails = false
+		s.selectedTaskID = ""
+		if err := taskSaveError(msg); err != nil {
+			return s, tea.Batch(s.loadTasks(), s.showFeedback(fmt.Sprintf("Could not save task: %v", err), styles.Danger))
+		}
+		return s, s.loadTasks()
+
+	case taskFromTemplateMsg:
+		if msg.err != nil {
+			return s, s.showFeedback(fmt.Sprintf("Could not create task from template: %v", msg.err), styles.Danger)
+		}
+		s.jumpTaskID = msg.task.ID
+		return s, tea.Batch(s.loadTasks(), s.showFeedback(
+			fmt.Sprintf("Created \"%s\" from %s with %d subtasks", msg.task.Title, msg.template, len(msg.task.Subtasks)), styles.Success))
+
+	case taskDeletedMsg:
 		// These actions originate from outside the details view, so reset to kanban
 		s.showDetails = false
 		s.selectedTaskID = ""
 		return s, s.loadTasks()
 
+	case bulkAppliedMsg:
+		switch {
+		case msg.err != nil:
+			return s, tea.Batch(s.loadTasks(), s.showFeedback(fmt.Sprintf("Bulk action failed: %v", msg.err), styles.Danger))
+		case msg.warning != "":
+			return s, tea.Batch(s.loadTasks(), s.showFeedback(capitalize(msg.warning), styles.Warning))
+		}
+		return s, tea.Batch(s.loadTasks(), s.showFeedback(capitalize(msg.summary), styles.Success))
+
+	case taskArchivedMsg:
+		s.selectedTaskID = ""
+		if msg.err != nil {
+			return s, tea.Batch(s.loadTasks(), s.showFeedback(fmt.Sprintf("Could not archive task: %v", msg.err), styles.Danger))
+		}
+		return s, tea.Batch(s.loadTasks(), s.showFeedback(fmt.Sprintf("Archived \"%s\"", msg.title), styles.Success))
+
+	case components.EditorFinishedMsg:
+		if s.showForm {
+			s.taskForm, cmd = s.taskForm.Update(msg)
+			return s, cmd
+		}
+		return s, nil
+
 	// Key presses are handled last
 	case tea.KeyMsg:
 		// If a modal/overlay is active, it gets priority
@@ -191,6 +350,9 @@ func (s *TaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch msg.String() {
 			case "y", "Y":
 				s.showDeleteConfirm = false
+				if s.selecting {
+					return s, s.bulkDelete()
+				}
 				if s.selectedTaskID != "" {
 					return s, s.deleteTask(s.selectedTaskID)
 				}
@@ -203,15 +365,14 @@ func (s *TaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if s.moveMode {
 			switch msg.String() {
 			case "left", "h":
-				if s.targetColumn > ColumnTodo {
-					s.targetColumn--
-				}
+				s.targetColumn = s.getPreviousColumn(s.targetColumn)
 			case "right", "l":
-				if s.targetColumn < ColumnDone {
-					s.targetColumn++
-				}
+				s.targetColumn = s.getNextColumn(s.targetColumn)
 			case "enter":
 				s.moveMode = false
+				if s.selecting {
+					return s, s.bulkMove(s.targetColumn)
+				}
 				if s.selectedTaskID != "" {
 					return s, s.moveTaskToColumn(s.selectedTaskID, s.targetColumn)
 				}
@@ -221,6 +382,14 @@ func (s *TaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return s, nil
 		}
 
+		if s.showTimeReport {
+			switch msg.String() {
+			case "E", "esc", "q":
+				s.showTimeReport = false
+			}
+			return s, nil
+		}
+
 		if s.showDetails {
 			if s.isConfirmingDeleteSubtask {
 				switch msg.String() {
@@ -248,6 +417,21 @@ func (s *TaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return s, cmd
 			}
 
+			if s.isRenamingSubtask {
+				cmd = s.subtaskInput.Update(msg)
+				switch msg.String() {
+				case "enter":
+					title := strings.TrimSpace(s.subtaskInput.Value())
+					if title != "" {
+						s.isRenamingSubtask = false
+						return s, s.renameSubtask(title)
+					}
+				case "esc":
+					s.isRenamingSubtask = false
+				}
+				return s, cmd
+			}
+
 			switch msg.String() {
 			case "enter", "q":
 				s.showDetails = false
@@ -266,10 +450,28 @@ func (s *TaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if task != nil && s.subtaskCursor < len(task.Subtasks) {
 					return s, s.toggleSubtask()
 				}
+			case "T":
+				if task := s.getTaskByID(s.selectedTaskID); task != nil {
+					return s, s.toggleTimer(task)
+				}
+			case "P":
+				if task := s.getTaskByID(s.selectedTaskID); task != nil {
+					return s, s.startPomodoro(task)
+				}
 			case "t":
 				s.isCreatingSubtask = true
 				s.subtaskInput = components.NewInput("", "New subtask title...")
 				return s, s.subtaskInput.Focus()
+			case "r":
+				task := s.getTaskByID(s.selectedTaskID)
+				if task != nil && s.subtaskCursor < len(task.Subtasks) {
+					s.isRenamingSubtask = true
+					s.subtaskInput = components.NewInput("", "Subtask title...")
+					s.subtaskInput.SetValue(task.Subtasks[s.subtaskCursor].Title)
+					return s, s.subtaskInput.Focus()
+				}
+			case "K", "J", "tab", "shift+tab":
+				return s, s.rearrangeSubtask(msg.String())
 			case "d", "delete":
 				task := s.getTaskByID(s.selectedTaskID)
 				if task != nil && len(task.Subtasks) > 0 {
@@ -279,12 +481,82 @@ func (s *TaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return s, nil
 		}
 
+		if s.isNamingFilter {
+			switch msg.String() {
+			case "enter":
+				name := strings.TrimSpace(s.filterNameInput.Value())
+				if name == "" {
+					return s, nil
+				}
+				s.isNamingFilter = false
+				return s, s.saveFilter(name, s.filter.Query)
+			case "esc":
+				s.isNamingFilter = false
+				return s, nil
+			}
+			cmd = s.filterNameInput.Update(msg)
+			return s, cmd
+		}
+
+		if s.isFiltering {
+			switch msg.String() {
+			case "enter":
+				filter, err := models.ParseTaskFilter(s.filterInput.Value(), time.Now())
+				if err != nil {
+					s.filterErr = err.Error()
+					return s, nil
+				}
+				s.isFiltering = false
+				if filter.Query != s.filter.Query {
+					s.filterName = ""
+				}
+				s.filter = filter
+				s.selectedTaskID = ""
+				return s, s.loadTasks()
+			case "esc":
+				s.isFiltering = false
+				return s, nil
+			}
+			s.filterErr = ""
+			cmd = s.filterInput.Update(msg)
+			return s, cmd
+		}
+
+		if s.bulkPrompt != bulkPromptNone {
+			switch msg.String() {
+			case "enter":
+				return s, s.submitBulkPrompt()
+			case "esc":
+				s.bulkPrompt = bulkPromptNone
+				return s, nil
+			}
+			s.bulkErr = ""
+			cmd = s.bulkInput.Update(msg)
+			return s, cmd
+		}
+
+		if s.selecting {
+			if cmd, handled := s.handleSelectionKey(msg.String()); handled {
+				return s, cmd
+			}
+		}
+
+		if s.showMatrix {
+			if cmd, handled := s.handleMatrixKey(msg.String()); handled {
+				return s, cmd
+			}
+		}
+
 		// If no modal is active, handle main kanban view keys
 		switch msg.String() {
 		case "tab":
-			s.activeColumn = (s.activeColumn + 1) % 3
+			s.activeColumn = (s.activeColumn + 1) % Column(len(s.columns))
 		case "shift+tab":
-			s.activeColumn = (s.activeColumn + 2) % 3
+			s.activeColumn = (s.activeColumn + Column(len(s.columns)) - 1) % Column(len(s.columns))
+		case "left", "h":
+			s.activeColumn = s.getPreviousColumn(s.activeColumn)
+		case "right", "l":
+			s.activeColumn = s.getNextColumn(s.activeColumn)
 		case "j", "down":
 			tasks := s.getTasksForColumn(s.activeColumn)
 			if s.cursors[s.activeColumn] < len(tasks)-1 {
@@ -294,6 +566,14 @@ func (s *TaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if s.cursors[s.activeColumn] > 0 {
 				s.cursors[s.activeColumn]--
 			}
+		case "M":
+			s.showMatrix = !s.showMatrix
+		case "o":
+			return s, s.cycleSortMode()
+		case "J":
+			return s, s.moveTaskInColumn(1)
+		case "K":
+			return s, s.moveTaskInColumn(-1)
 		case "g":
 			s.cursors[s.activeColumn] = 0
 		case "G":
@@ -318,6 +598,12 @@ func (s *TaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					s.selectedTaskID = task.ID
 				}
 			}
+		case "v":
+			if task := s.currentTask(); task != nil {
+				s.selecting = true
+				s.selectedTaskID = ""
+				s.marked[task.ID] = true
+			}
 		case "m":
 			if s.selectedTaskID != "" {
 				s.moveMode = true
@@ -327,14 +613,52 @@ func (s *TaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if s.selectedTaskID != "" {
 				s.showDeleteConfirm = true
 			}
+		case "a":
+			if task := s.currentTask(); task != nil {
+				return s, s.archiveTask(*task)
+			}
 		case "r":
 			return s, s.loadTasks()
+		case "f":
+			s.isFiltering = true
+			s.filterErr = ""
+			s.filterInput = components.NewInput("Filter:", `e.g. priority:high tag:math due:<7d -status:done`)
+			s.filterInput.SetValue(s.filter.Query)
+			return s, s.filterInput.Focus()
+		case "F":
+			if !s.filter.IsEmpty() {
+				s.filter = models.TaskFilter{}
+				s.filterName = ""
+				return s, s.loadTasks()
+			}
+		case "S":
+			if !s.filter.IsEmpty() {
+				s.isNamingFilter = true
+				s.filterNameInput = components.NewInput("Smart list name:", "e.g. This week's exams")
+				s.filterNameInput.SetValue(s.filterName)
+				return s, s.filterNameInput.Focus()
+			}
 		case "x":
 			return s, s.exportTasks()
+		case "T":
+			if task := s.currentTask(); task != nil {
+				return s, s.toggleTimer(task)
+			}
+		case "P":
+			if task := s.currentTask(); task != nil {
+				return s, s.startPomodoro(task)
+			}
+		case "E":
+			s.showTimeReport = true
+			return s, s.loadTimeReport()
 		case "n":
 			s.showForm = true
-			s.taskForm = components.NewTaskForm(nil)
+			s.taskForm = components.NewTaskForm(nil, s.tasks, s.courses)
 			return s, nil
+		case "N":
+			s.showTemplates = true
+			s.templateManager = components.NewTemplateManager(s.db)
+			return s, s.templateManager.Init()
 		case "e":
 			if s.selectedTaskID != "" {
 				var taskToEdit *models.Task
@@ -346,7 +670,7 @@ func (s *TaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				if taskToEdit != nil {
 					s.showForm = true
-					s.taskForm = components.NewTaskForm(taskToEdit)
+					s.taskForm = components.NewTaskForm(taskToEdit, s.tasks, s.courses)
 				}
 			}
 		}
@@ -375,8 +699,12 @@ func (s *TaskScreen) View() string {
 
 	// Decide which main view to render
 	var mainView string
-	if s.showDetails {
+	if s.showTimeReport {
+		mainView = s.renderTimeReport()
+	} else if s.showDetails {
 		mainView = s.renderDetailsView()
+	} else if s.showMatrix {
+		mainView = s.renderMatrix()
 	} else {
 		mainView = s.renderKanban()
 	}
@@ -386,6 +714,10 @@ func (s *TaskScreen) View() string {
 		return s.overlayForm(mainView)
 	}
 
+	if s.showTemplates {
+		return lipgloss.Place(s.width, s.height, lipgloss.Center, lipgloss.Center, s.templateManager.View())
+	}
+
 	// If delete confirmation is shown, overlay it
 	if s.showDeleteConfirm {
 		return s.renderDeleteConfirmDialog(mainView)
@@ -405,9 +737,12 @@ func (s *TaskScreen) renderSubtaskDeleteConfirmDialog(baseView string) string {
 	if task == nil || s.subtaskCursor >= len(task.Subtasks) {
 		return baseView // Should not happen
 	}
-	subtaskTitle := task.Subtasks[s.subtaskCursor].Title
+	subtask := task.Subtasks[s.subtaskCursor]
 
-	question := fmt.Sprintf("Delete subtask \"%s\"?", subtaskTitle)
+	question := fmt.Sprintf("Delete subtask \"%s\"?", subtask.Title)
+	if nested := len(task.SubtaskBranch(subtask.ID)) - 1; nested > 0 {
+		question = fmt.Sprintf("Delete subtask \"%s\" and its %d nested subtasks?", subtask.Title, nested)
+	}
 
 	dialog := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -446,6 +781,9 @@ func (s *TaskScreen) renderDeleteConfirmDialog(baseView string) string {
 	}
 
 	question := fmt.Sprintf("Delete task \"%s\"?", taskTitle)
+	if s.selecting {
+		question = fmt.Sprintf("Delete %d marked tasks?", len(s.marked))
+	}
 
 	dialog := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -455,7 +793,7 @@ func (s *TaskScreen) renderDeleteConfirmDialog(baseView string) string {
 			lipgloss.Center,
 			styles.Title.Render(question),
 			"",
-			styles.Dimmed.Render("This action cannot be undone."),
+			styles.Dimmed.Render("It can be restored from the trash."),
 			"",
 			lipgloss.JoinHorizontal(
 				lipgloss.Top,
@@ -474,6 +812,36 @@ func (s *TaskScreen) renderDeleteConfirmDialog(baseView string) string {
 	)
 }
 
+// revealTask moves the cursor to a task and opens its details
+func (s *TaskScreen) revealTask(id string) {
+	for c := range s.columns {
+		col := Column(c)
+		for i, task := range s.getTasksForColumn(col) {
+			if task.ID == id {
+				s.activeColumn = col
+				s.cursors[col] = i
+				s.selectedTaskID = id
+				s.subtaskCursor = 0
+				s.showDetails = true
+				return
+			}
+		}
+	}
+}
+
+// courseByID returns the loaded course with the given ID
+func (s *TaskScreen) courseByID(id string) *models.Course {
+	if id == "" {
+		return nil
+	}
+	for i := range s.courses {
+		if s.courses[i].ID == id {
+			return &s.courses[i]
+		}
+	}
+	return nil
+}
+
 // getTaskByID finds a task by its ID
 func (s *TaskScreen) getTaskByID(id string) *models.Task {
 	for i := range s.tasks {
@@ -542,7 +910,7 @@ func (s *TaskScreen) renderDetailsView() string {
 	b.WriteString(labelStyle.Render("Description"))
 	b.WriteString("\n")
 	if task.Description != "" {
-		b.WriteString(descStyle.Render(task.Description))
+		b.WriteString(descStyle.Render(components.RenderMarkdown(task.Description, s.width-14)))
 	} else {
 		b.WriteString(descStyle.Render(styles.Dimmed.Render("No description.")))
 	}
@@ -559,6 +927,16 @@ func (s *TaskScreen) renderDetailsView() string {
 	b.WriteString(details)
 	b.WriteString("\n")
 
+	// Course
+	if course := s.courseByID(task.CourseID); course != nil {
+		name := course.Name
+		if course.Code != "" {
+			name = course.Code + " - " + course.Name
+		}
+		b.WriteString(labelStyle.Render("Course") + ": " + name)
+		b.WriteString("\n")
+	}
+
 	// Due Date
 	if task.DueDate != nil {
 		dueStr := task.DueDate.Format("Mon, 02 Jan 2006")
@@ -569,6 +947,47 @@ func (s *TaskScreen) renderDetailsView() string {
 		b.WriteString("\n")
 	}
 
+	// Time tracking
+	if tracked := task.TrackedTime(s.runningEntry); tracked > 0 || task.EstimatedMinutes > 0 {
+		timeStr := models.FormatMinutes(int(tracked.Minutes())) + " tracked"
+		if task.EstimatedMinutes > 0 {
+			percentage := int(tracked.Minutes()) * 100 / task.EstimatedMinutes
+			timeStr += fmt.Sprintf(" of %s estimated (%d%%)", models.FormatMinutes(task.EstimatedMinutes), percentage)
+		}
+		if s.runningEntry != nil && s.runningEntry.TaskID == task.ID {
+			timeStr += lipgloss.NewStyle().Foreground(styles.Info).
+				Render(" ⏱ running since " + s.runningEntry.StartedAt.Format("15:04"))
+		}
+		b.WriteString(labelStyle.Render("Time") + ": " + timeStr)
+		b.WriteString("\n")
+	}
+
+	// Pomodoros
+	if task.Pomodoros > 0 {
+		b.WriteString(labelStyle.Render("Pomodoros") + ": " + fmt.Sprintf("🍅 %d completed", task.Pomodoros))
+		b.WriteString("\n")
+	}
+
+	// Recurrence
+	if recurrence, err := models.ParseTaskRecurrence(task.RecurrenceRule); err == nil && !recurrence.IsEmpty() {
+		b.WriteString(labelStyle.Render("Repeats") + ": " + recurrence.Describe())
+		b.WriteString("\n")
+	}
+
+	// Prerequisites
+	if len(task.Dependencies) > 0 {
+		var deps []string
+		for _, dep := range task.Dependencies {
+			if dep.IsDone() {
+				deps = append(deps, lipgloss.NewStyle().Foreground(styles.Muted).Strikethrough(true).Render(dep.Title))
+			} else {
+				deps = append(deps, lipgloss.NewStyle().Foreground(styles.Danger).Render(dep.Title))
+			}
+		}
+		b.WriteString(labelStyle.Render("Blocked by") + ": " + strings.Join(deps, ", "))
+		b.WriteString("\n")
+	}
+
 	// Tags
 	if len(task.Tags) > 0 {
 		var tagStrings []string
@@ -604,7 +1023,13 @@ func (s *TaskScreen) renderDetailsView() string {
 				}
 			}
 
-			line := fmt.Sprintf("  %s %s", checkbox, st.Title)
+			indent := strings.Repeat("    ", st.Depth)
+			if s.isRenamingSubtask && i == s.subtaskCursor {
+				b.WriteString(fmt.Sprintf("  %s%s", indent, checkbox) + s.subtaskInput.ViewInline() + "\n")
+				continue
+			}
+
+			line := fmt.Sprintf("  %s%s %s", indent, checkbox, st.Title)
 			b.WriteString(lineStyle.Render(line) + "\n")
 		}
 	}
@@ -635,27 +1060,21 @@ func (s *TaskScreen) renderDetailsView() string {
 
 // renderKanban renders the kanban board
 func (s *TaskScreen) renderKanban() string {
-	todoTasks := s.getTasksForColumn(ColumnTodo)
-	inProgressTasks := s.getTasksForColumn(ColumnInProgress)
-	doneTasks := s.getTasksForColumn(ColumnDone)
-
-	// Calculate column width (divide available width by 3, minus borders and spacing)
-	columnWidth := ((s.width - 10) / 3) + 3
+	// Calculate column width (divide available width by the columns, minus borders and spacing)
+	n := len(s.columns)
+	columnWidth := ((s.width - 10) / n) + 3
 	if columnWidth < 23 {
 		columnWidth = 23
 	}
 
-	todoColumn := s.renderColumn("To Do", todoTasks, ColumnTodo, columnWidth)
-	inProgressColumn := s.renderColumn("In Progress", inProgressTasks, ColumnInProgress, columnWidth)
-	doneColumn := s.renderColumn("Done", doneTasks, ColumnDone, columnWidth)
+	var columns []string
+	for i := range s.columns {
+		col := Column(i)
+		columns = append(columns, s.renderColumn(s.getTasksForColumn(col), col, columnWidth))
+	}
 
 	// Combine columns horizontally
-	kanbanBoard := lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		todoColumn,
-		inProgressColumn,
-		doneColumn,
-	)
+	kanbanBoard := lipgloss.JoinHorizontal(lipgloss.Top, columns...)
 
 	shortcuts := s.renderShortcuts()
 
@@ -689,9 +1108,14 @@ func (s *TaskScreen) overlayForm(baseView string) string {
 }
 
 // renderColumn renders a single kanban column
-func (s *TaskScreen) renderColumn(title string, tasks []models.Task, column Column, width int) string {
-	// Column header with count
-	headerText := fmt.Sprintf("%s (%d)", title, len(tasks))
+func (s *TaskScreen) renderColumn(tasks []models.Task, column Column, width int) string {
+	config := s.columns[column]
+
+	// Column header with count, against the WIP limit if there is one
+	headerText := fmt.Sprintf("%s (%d)", config.Name, len(tasks))
+	if config.WIPLimit > 0 {
+		headerText = fmt.Sprintf("%s (%d/%d)", config.Name, len(tasks), config.WIPLimit)
+	}
 
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -714,11 +1138,19 @@ func (s *TaskScreen) renderColumn(title string, tasks []models.Task, column Colu
 		headerStyle = headerStyle.Foreground(styles.Secondary)
 	}
 
-	header := headerStyle.Render(headerText)
+	// A column over its WIP limit is flagged, one at the limit takes no more
+	switch {
+	case config.WIPLimit > 0 && len(tasks) > config.WIPLimit:
+		headerStyle = headerStyle.Foreground(styles.Danger)
+	case config.IsFull(len(tasks)):
+		headerStyle = headerStyle.Foreground(styles.Warning)
+	}
+
+	header := headerStyle.Render(headerText + styles.Dimmed.Render(" ↕ "+s.sortMode(column).Label()))
 
 	var taskLines []string
 	for i, task := range tasks {
-		isSelected := task.ID == s.selectedTaskID
+		isSelected := task.ID == s.selectedTaskID || s.marked[task.ID]
 		isCursor := i == s.cursors[column] && s.activeColumn == column
 		taskLine := s.renderKanbanTask(task, isSelected, isCursor)
 		taskLines = append(taskLines, taskLine)
@@ -768,11 +1200,24 @@ func (s *TaskScreen) renderKanbanTask(task models.Task, isSelected bool, isCurso
 
 	// Task title
 	titleStyle := lipgloss.NewStyle()
-	if task.Status == models.TaskStatusCompleted {
+	if task.Status == models.TaskStatusCompleted || task.Status == models.TaskStatusCancelled {
 		titleStyle = titleStyle.Strikethrough(true).Foreground(styles.Muted)
 	}
 
 	title := titleStyle.Render(task.Title)
+	if task.Status == models.TaskStatusCancelled {
+		title = lipgloss.NewStyle().Foreground(styles.Danger).Render("✗ ") + title
+	}
+
+	// Course badge
+	var courseBadge string
+	if course := s.courseByID(task.CourseID); course != nil {
+		color := styles.Secondary
+		if course.Color != "" {
+			color = lipgloss.Color(course.Color)
+		}
+		courseBadge = lipgloss.NewStyle().Foreground(color).Bold(true).Render(courseLabel(course)) + " "
+	}
 
 	// Subtask progress indicator
 	var progressIndicator string
@@ -799,12 +1244,41 @@ func (s *TaskScreen) renderKanbanTask(task models.Task, isSelected bool, isCurso
 		}
 	}
 
-	taskText := fmt.Sprintf("%s %s%s%s", priorityIndicator, title, progressIndicator, dueInfo)
+	// Running timer indicator
+	var timerInfo string
+	if s.runningEntry != nil && s.runningEntry.TaskID == task.ID {
+		timerInfo = lipgloss.NewStyle().Foreground(styles.Info).
+			Render(" ⏱ " + models.FormatMinutes(int(s.runningEntry.Elapsed().Minutes())))
+	}
+
+	// Blocked indicator
+	var blockedInfo string
+	if blockers := task.BlockedBy(); len(blockers) > 0 && task.Status != models.TaskStatusCompleted {
+		text := " ⊘ " + blockers[0].Title
+		if len(blockers) > 1 {
+			text += fmt.Sprintf(" +%d", len(blockers)-1)
+		}
+		blockedInfo = lipgloss.NewStyle().Foreground(styles.Danger).Render(text)
+	}
+
+	// Recurrence indicator
+	var repeatInfo string
+	if task.IsRecurring() {
+		repeatInfo = lipgloss.NewStyle().Foreground(styles.Info).Render(" ↻")
+	}
+
+	taskText := fmt.Sprintf("%s %s%s%s%s%s%s%s", priorityIndicator, courseBadge, title, progressIndicator, dueInfo, timerInfo, repeatInfo, blockedInfo)
 
 	// Apply selection/cursor styles
 	taskStyle := lipgloss.NewStyle().Padding(0, 1)
 
-	if isSelected {
+	if isSelected && isCursor {
+		// Selected task under the cursor
+		taskStyle = taskStyle.
+			Background(styles.Primary).
+			Foreground(styles.Background).
+			Bold(true)
+	} else if isSelected {
 		// Selected task - highlighted background
 		taskStyle = taskStyle.
 			Background(styles.Secondary).
@@ -842,6 +1316,26 @@ func (s *TaskScreen) renderShortcuts() string {
 		return s.feedbackMsg
 	}
 
+	if s.isNamingFilter {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			s.filterNameInput.ViewInline(),
+			styles.Dimmed.Render("Save "+s.filter.Query+" as a smart list  •  enter save  esc cancel"),
+		)
+	}
+
+	if s.selecting && !s.moveMode {
+		return s.renderSelectionShortcuts()
+	}
+
+	if s.isFiltering {
+		status := styles.Dimmed.Render("fields: priority tag category course status due  •  -field:value negates  •  enter apply  esc cancel")
+		if s.filterErr != "" {
+			status = lipgloss.NewStyle().Foreground(styles.Danger).Render("⚠ " + s.filterErr)
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, s.filterInput.ViewInline(), status)
+	}
+
 	var shortcuts []string
 
 	if s.moveMode {
@@ -850,12 +1344,21 @@ func (s *TaskScreen) renderShortcuts() string {
 			styles.Shortcut.Render("enter") + styles.ShortcutText.Render(" confirm"),
 			styles.Shortcut.Render("esc") + styles.ShortcutText.Render(" cancel"),
 		}
+		if s.showMatrix {
+			// The columns are not on screen
+			return lipgloss.JoinVertical(lipgloss.Left, s.renderMoveTargets(), strings.Join(shortcuts, "  "))
+		}
 	} else if s.showDetails {
 		shortcuts = []string{
 			styles.Shortcut.Render("enter") + styles.ShortcutText.Render(" close"),
 			styles.Shortcut.Render("j/k") + styles.ShortcutText.Render(" nav"),
 			styles.Shortcut.Render("space") + styles.ShortcutText.Render(" toggle"),
 			styles.Shortcut.Render("t") + styles.ShortcutText.Render(" new subtask"),
+			styles.Shortcut.Render("r") + styles.ShortcutText.Render(" rename"),
+			styles.Shortcut.Render("J/K") + styles.ShortcutText.Render(" move"),
+			styles.Shortcut.Render("tab/S-tab") + styles.ShortcutText.Render(" indent/outdent"),
+			styles.Shortcut.Render("T") + styles.ShortcutText.Render(" timer"),
+			styles.Shortcut.Render("P") + styles.ShortcutText.Render(" pomodoro"),
 			styles.Shortcut.Render("d") + styles.ShortcutText.Render(" delete"),
 		}
 	} else if s.selectedTaskID != "" {
@@ -863,18 +1366,41 @@ func (s *TaskScreen) renderShortcuts() string {
 			styles.Shortcut.Render("space") + styles.ShortcutText.Render(" deselect"),
 			styles.Shortcut.Render("m") + styles.ShortcutText.Render(" move"),
 			styles.Shortcut.Render("del") + styles.ShortcutText.Render(" delete"),
+			styles.Shortcut.Render("a") + styles.ShortcutText.Render(" archive"),
 			styles.Shortcut.Render("enter") + styles.ShortcutText.Render(" details"),
 			styles.Shortcut.Render("e") + styles.ShortcutText.Render(" edit"),
+			styles.Shortcut.Render("T") + styles.ShortcutText.Render(" timer"),
+			styles.Shortcut.Render("P") + styles.ShortcutText.Render(" pomodoro"),
 		}
 	} else {
+		next := styles.Shortcut.Render("tab") + styles.ShortcutText.Render(" next column")
+		view := styles.Shortcut.Render("M") + styles.ShortcutText.Render(" matrix")
+		if s.showMatrix {
+			next = styles.Shortcut.Render("tab") + styles.ShortcutText.Render(" next quadrant")
+			view = styles.Shortcut.Render("M") + styles.ShortcutText.Render(" board")
+		}
 		shortcuts = []string{
 			styles.Shortcut.Render("space") + styles.ShortcutText.Render(" select"),
-			styles.Shortcut.Render("tab") + styles.ShortcutText.Render(" next column"),
+			styles.Shortcut.Render("v") + styles.ShortcutText.Render(" multi-select"),
+			next,
+			view,
 			styles.Shortcut.Render("enter") + styles.ShortcutText.Render(" details"),
 			styles.Shortcut.Render("j/k") + styles.ShortcutText.Render(" navigate"),
 			styles.Shortcut.Render("n") + styles.ShortcutText.Render(" new"),
+			styles.Shortcut.Render("N") + styles.ShortcutText.Render(" from template"),
+			styles.Shortcut.Render("a") + styles.ShortcutText.Render(" archive"),
+			styles.Shortcut.Render("f") + styles.ShortcutText.Render(" filter"),
 			styles.Shortcut.Render("r") + styles.ShortcutText.Render(" refresh"),
 			styles.Shortcut.Render("x") + styles.ShortcutText.Render(" export"),
+			styles.Shortcut.Render("T") + styles.ShortcutText.Render(" timer"),
+			styles.Shortcut.Render("P") + styles.ShortcutText.Render(" pomodoro"),
+			styles.Shortcut.Render("E") + styles.ShortcutText.Render(" estimates"),
+		}
+		if !s.filter.IsEmpty() {
+			shortcuts = append(shortcuts,
+				styles.Shortcut.Render("F")+styles.ShortcutText.Render(" clear filter"),
+				styles.Shortcut.Render("S")+styles.ShortcutText.Render(" save as smart list"),
+			)
 		}
 	}
 
@@ -883,6 +1409,17 @@ func (s *TaskScreen) renderShortcuts() string {
 	// Total count
 	totalText := fmt.Sprintf("Total: %d tasks", len(s.tasks))
 	totalLine := styles.Dimmed.Render(totalText)
+	if !s.filter.IsEmpty() {
+		filterText := " " + s.filter.Query
+		if s.filterName != "" {
+			filterText = " " + s.filterName + ": " + s.filter.Query
+		}
+		totalLine = lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			styles.Dimmed.Render(fmt.Sprintf("Showing %d matching tasks  ", len(s.tasks))),
+			lipgloss.NewStyle().Foreground(styles.AutumnYellow).Render(filterText),
+		)
+	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, shortcutLine, totalLine)
 }
@@ -891,48 +1428,28 @@ func (s *TaskScreen) renderShortcuts() string {
 func (s *TaskScreen) getTasksForColumn(column Column) []models.Task {
 	var tasks []models.Task
 	for _, task := range s.tasks {
-		switch column {
-		case ColumnTodo:
-			if task.Status == models.TaskStatusPending {
-				tasks = append(tasks, task)
-			}
-		case ColumnInProgress:
-			if task.Status == models.TaskStatusInProgress {
-				tasks = append(tasks, task)
-			}
-		case ColumnDone:
-			if task.Status == models.TaskStatusCompleted {
-				tasks = append(tasks, task)
-			}
+		if models.BoardColumnOf(s.columns, task.Status) == int(column) {
+			tasks = append(tasks, task)
 		}
 	}
+	models.SortTasks(tasks, s.sortMode(column))
 	return tasks
 }
 
-// getPreviousColumn returns the previous column
-func (s *TaskScreen) getPreviousColumn() Column {
-	switch s.activeColumn {
-	case ColumnTodo:
-		return ColumnTodo // Can't go before Todo
-	case ColumnInProgress:
-		return ColumnTodo
-	case ColumnDone:
-		return ColumnInProgress
+// getPreviousColumn returns the column left of a column
+func (s *TaskScreen) getPreviousColumn(column Column) Column {
+	if column > 0 {
+		return column - 1
 	}
-	return ColumnTodo
+	return column // Can't go before the first column
 }
 
-// getNextColumn returns the next column
-func (s *TaskScreen) getNextColumn() Column {
-	switch s.activeColumn {
-	case ColumnTodo:
-		return ColumnInProgress
-	case ColumnInProgress:
-		return ColumnDone
-	case ColumnDone:
-		return ColumnDone // Can't go after Done
+// getNextColumn returns the column right of a column
+func (s *TaskScreen) getNextColumn(column Column) Column {
+	if int(column) < len(s.columns)-1 {
+		return column + 1
 	}
-	return ColumnDone
+	return column // Can't go after the last column
 }
 
 // moveTaskToColumn moves a task to a different column (status)
@@ -943,20 +1460,47 @@ func (s *TaskScreen) moveTaskToColumn(taskID string, targetColumn Column) tea.Cm
 		if err != nil {
 			return taskMovedMsg{err: err}
 		}
+		before := *task
+		column := s.columns[targetColumn]
+		description := fmt.Sprintf("move task %q to %s", task.Title, column.Name)
 
-		switch targetColumn {
-		case ColumnTodo:
-			task.Status = models.TaskStatusPending
-			task.CompletedAt = nil
-		case ColumnInProgress:
-			task.Status = models.TaskStatusInProgress
-			task.CompletedAt = nil
-		case ColumnDone:
-			task.Status = models.TaskStatusCompleted
-			// CompletedAt will be set by the repository
+		if column.Holds(task.Status) {
+			return taskMovedMsg{} // Already in the column
 		}
 
+		if column.WIPLimit > 0 {
+			count, err := s.db.Tasks().CountByStatus(column.Statuses...)
+			if err != nil {
+				return taskMovedMsg{err: err}
+			}
+			if column.IsFull(count) {
+				return taskMovedMsg{task: task, full: &column}
+			}
+		}
+
+		if column.Status() == models.TaskStatusCompleted {
+			if blockers := task.BlockedBy(); len(blockers) > 0 {
+				return taskMovedMsg{task: task, blockers: blockers}
+			}
+			// Finishing a task stops its timer
+			if s.runningEntry != nil && s.runningEntry.TaskID == task.ID {
+				if _, err := s.db.TimeEntries().Stop(); err != nil {
+					return taskMovedMsg{err: err}
+				}
+			}
+			// Completing a recurring task schedules its next instance
+			next, err := s.db.Tasks().Complete(task)
+			if err == nil {
+				recordTaskChange(s.db, description, before, *task, next)
+			}
+			return taskMovedMsg{next: next, err: err}
+		}
+
+		task.Status = column.Status()
 		err = s.db.Tasks().Update(task)
+		if err == nil {
+			recordTaskChange(s.db, description, before, *task, nil)
+		}
 		return taskMovedMsg{err: err}
 	}
 }
@@ -984,7 +1528,22 @@ func (s *TaskScreen) createSubtask(title string) tea.Cmd {
 // deleteTask deletes a task by ID
 func (s *TaskScreen) deleteTask(taskID string) tea.Cmd {
 	return func() tea.Msg {
-		err := s.db.Tasks().Delete(taskID)
+		task, err := s.db.Tasks().FindByID(taskID)
+		if err != nil {
+			return taskDeletedMsg{err: err}
+		}
+		// Deleting a task stops its timer
+		if s.runningEntry != nil && s.runningEntry.TaskID == taskID {
+			if _, err := s.db.TimeEntries().Stop(); err != nil {
+				return taskDeletedMsg{err: err}
+			}
+		}
+		err = s.db.Tasks().Delete(taskID)
+		if err == nil {
+			recordDelete(s.db, models.SearchKindTask, task.ID, task.Title, func() error {
+				return s.db.Tasks().Delete(task.ID)
+			})
+		}
 		return taskDeletedMsg{err: err}
 	}
 }
@@ -995,12 +1554,78 @@ func (s *TaskScreen) deleteSubtask() tea.Cmd {
 		if task == nil || s.subtaskCursor >= len(task.Subtasks) {
 			return subtaskDeletedMsg{err: fmt.Errorf("subtask not found")}
 		}
-		subtaskID := task.Subtasks[s.subtaskCursor].ID
-		err := s.db.Tasks().DeleteSubtask(subtaskID)
+		branch := task.SubtaskBranch(task.Subtasks[s.subtaskCursor].ID)
+		err := s.db.Tasks().DeleteSubtask(branch[0].ID)
+		if err == nil {
+			recordSubtaskDelete(s.db, branch, task.Subtasks)
+		}
 		return subtaskDeletedMsg{err: err}
 	}
 }
 
+// renameSubtask changes the title of the subtask under the cursor
+func (s *TaskScreen) renameSubtask(title string) tea.Cmd {
+	task := s.getTaskByID(s.selectedTaskID)
+	if task == nil || s.subtaskCursor >= len(task.Subtasks) {
+		return nil
+	}
+	subtask := task.Subtasks[s.subtaskCursor]
+	if subtask.Title == title {
+		return nil
+	}
+
+	return func() tea.Msg {
+		err := s.db.Tasks().RenameSubtask(subtask.ID, title)
+		if err == nil {
+			recordSubtaskRename(s.db, subtask.ID, subtask.Title, title)
+		}
+		return subtaskRenamedMsg{err: err}
+	}
+}
+
+// rearrangeSubtask moves the subtask under the cursor up (K) or down (J)
+// among its siblings, or nests it under the subtask above (tab) or out of
+// its parent (shift+tab). The cursor stays on the subtask.
+func (s *TaskScreen) rearrangeSubtask(key string) tea.Cmd {
+	task := s.getTaskByID(s.selectedTaskID)
+	if task == nil || s.subtaskCursor >= len(task.Subtasks) {
+		return nil
+	}
+	subtask := task.Subtasks[s.subtaskCursor]
+	before := append([]models.Subtask{}, task.Subtasks...)
+
+	var changed bool
+	verb := "move"
+	switch key {
+	case "K":
+		changed = task.MoveSubtask(subtask.ID, -1)
+	case "J":
+		changed = task.MoveSubtask(subtask.ID, 1)
+	case "tab":
+		changed, verb = task.IndentSubtask(subtask.ID), "indent"
+	case "shift+tab":
+		changed, verb = task.OutdentSubtask(subtask.ID), "outdent"
+	}
+	if !changed {
+		return nil
+	}
+
+	for i, st := range task.Subtasks {
+		if st.ID == subtask.ID {
+			s.subtaskCursor = i
+		}
+	}
+	after := append([]models.Subtask{}, task.Subtasks...)
+
+	return func() tea.Msg {
+		err := s.db.Tasks().UpdateSubtaskLayout(after)
+		if err == nil {
+			recordSubtaskLayout(s.db, fmt.Sprintf("%s subtask %q", verb, subtask.Title), before, after)
+		}
+		return subtaskMovedMsg{err: err}
+	}
+}
+
 func (s *TaskScreen) toggleSubtask() tea.Cmd {
 	return func() tea.Msg {
 		task := s.getTaskByID(s.selectedTaskID)
@@ -1012,6 +1637,9 @@ func (s *TaskScreen) toggleSubtask() tea.Cmd {
 		subtask.IsCompleted = !subtask.IsCompleted
 
 		err := s.db.Tasks().UpdateSubtask(subtask)
+		if err == nil {
+			recordSubtaskToggle(s.db, *subtask)
+		}
 		return subtaskToggledMsg{err: err}
 	}
 }
@@ -1019,27 +1647,111 @@ func (s *TaskScreen) toggleSubtask() tea.Cmd {
 // updateTask updates an existing task
 func (s *TaskScreen) updateTask(task *models.Task) tea.Cmd {
 	return func() tea.Msg {
-		err := s.db.Tasks().Update(task)
+		before, err := s.db.Tasks().FindByID(task.ID)
+		if err != nil {
+			return taskUpdatedMsg{err: err}
+		}
+		err = s.db.Tasks().Update(task)
+		if err == nil {
+			recordTaskChange(s.db, fmt.Sprintf("edit task %q", task.Title), *before, *task, nil)
+		}
 		return taskUpdatedMsg{err: err}
 	}
 }
 
+// IsTaskFormActive returns true while the task form, the template manager, a
+// filter, bulk action or subtask input has focus
+func (s *TaskScreen) IsTaskFormActive() bool {
+	return s.showForm || s.showTemplates || s.isFiltering || s.isNamingFilter || s.bulkPrompt != bulkPromptNone || (s.showDetails && (s.isCreatingSubtask || s.isRenamingSubtask))
+}
+
 // loadTasks loads tasks from database
 func (s *TaskScreen) loadTasks() tea.Cmd {
 	return func() tea.Msg {
 		s.loading = true // Set loading to true before loading tasks
-		tasks, err := s.db.Tasks().FindAll()
+		var archived int64
+		if s.archiveAfter > 0 {
+			var err error
+			archived, err = s.db.Tasks().ArchiveCompletedBefore(time.Now().Add(-s.archiveAfter))
+			if err != nil {
+				return tasksLoadedMsg{tasks: []models.Task{}, err: err}
+			}
+		}
+
+		var tasks []models.Task
+		var err error
+		if s.filter.IsEmpty() {
+			tasks, err = s.db.Tasks().FindAll()
+		} else {
+			tasks, err = s.db.Tasks().FindByFilter(s.filter)
+		}
 		if err != nil {
 			return tasksLoadedMsg{tasks: []models.Task{}, err: err}
 		}
-		return tasksLoadedMsg{tasks: tasks, err: nil}
+		courses, err := s.db.Courses().GetAll()
+		if err != nil {
+			return tasksLoadedMsg{tasks: tasks, err: err}
+		}
+		running, err := s.db.TimeEntries().FindRunning()
+		if err != nil {
+			return tasksLoadedMsg{tasks: tasks, err: err}
+		}
+		return tasksLoadedMsg{tasks: tasks, courses: courses, running: running, archived: archived, err: nil}
+	}
+}
+
+// updateTemplateManager passes a message to the template manager and creates
+// a task from the template picked in it
+func (s *TaskScreen) updateTemplateManager(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	s.templateManager, cmd = s.templateManager.Update(msg)
+	if !s.templateManager.IsClosed() {
+		return s, cmd
+	}
+
+	s.showTemplates = false
+	if template := s.templateManager.Chosen(); template != nil {
+		return s, s.createFromTemplate(*template)
+	}
+	return s, cmd
+}
+
+// createFromTemplate creates a task with the checklist of a template
+func (s *TaskScreen) createFromTemplate(template models.TaskTemplate) tea.Cmd {
+	return func() tea.Msg {
+		task, err := template.Instantiate(time.Now())
+		if err != nil {
+			return taskFromTemplateMsg{template: template.Name, err: err}
+		}
+		err = s.db.Tasks().CreateWithSubtasks(task)
+		return taskFromTemplateMsg{task: task, template: template.Name, err: err}
+	}
+}
+
+// archiveTask moves a task off the board into the archive
+func (s *TaskScreen) archiveTask(task models.Task) tea.Cmd {
+	return func() tea.Msg {
+		// Archiving a task stops its timer
+		if s.runningEntry != nil && s.runningEntry.TaskID == task.ID {
+			if _, err := s.db.TimeEntries().Stop(); err != nil {
+				return taskArchivedMsg{title: task.Title, err: err}
+			}
+		}
+		err := s.db.Tasks().Archive(task.ID)
+		if err == nil {
+			recordArchive(s.db, task.ID, task.Title, true)
+		}
+		return taskArchivedMsg{title: task.Title, err: err}
 	}
 }
 
 // Messages
 type tasksLoadedMsg struct {
-	tasks []models.Task
-	err   error
+	tasks    []models.Task
+	courses  []models.Course
+	running  *models.TimeEntry
+	archived int64 // Completed tasks archived automatically by the load
+	err      error
 }
 
 type subtaskToggledMsg struct {
@@ -1054,12 +1766,24 @@ type subtaskDeletedMsg struct {
 	err error
 }
 
+type subtaskRenamedMsg struct {
+	err error
+}
+
+type subtaskMovedMsg struct {
+	err error
+}
+
 type taskCreatedMsg struct {
 	err error
 }
 
 type taskMovedMsg struct {
-	err error
+	task     *models.Task
+	next     *models.Task            // Next instance of a completed recurring task
+	blockers []models.TaskDependency // Incomplete prerequisites that kept the task from Done
+	full     *models.BoardColumn     // Column at its WIP limit that kept the task out
+	err      error
 }
 
 type taskDeletedMsg struct {
@@ -1070,6 +1794,17 @@ type taskUpdatedMsg struct {
 	err error
 }
 
+type taskArchivedMsg struct {
+	title string
+	err   error
+}
+
+type taskFromTemplateMsg struct {
+	task     *models.Task
+	template string
+	err      error
+}
+
 // exportTasks exports all tasks to a JSON file
 func (s *TaskScreen) exportTasks() tea.Cmd {
 	return func() tea.Msg {
@@ -1099,5 +1834,206 @@ type tasksExportedMsg struct {
 	err      error
 }
 
+// taskSaveError returns the error of a task created or updated message
+func taskSaveError(msg tea.Msg) error {
+	switch msg := msg.(type) {
+	case taskCreatedMsg:
+		return msg.err
+	case taskUpdatedMsg:
+		return msg.err
+	}
+	return nil
+}
+
+// dependencyTitles quotes and joins the titles of prerequisites
+func dependencyTitles(deps []models.TaskDependency) string {
+	titles := make([]string, len(deps))
+	for i, dep := range deps {
+		titles[i] = fmt.Sprintf("%q", dep.Title)
+	}
+	return strings.Join(titles, ", ")
+}
+
 // clearFeedbackMsg is sent to clear the feedback message after a delay
 type clearFeedbackMsg struct{}
+
+// ApplyFilterMsg applies a saved filter (smart list) to the kanban board
+type ApplyFilterMsg struct {
+	Name  string
+	Query string
+}
+
+// SavedFiltersChangedMsg is sent when a smart list was created or changed
+type SavedFiltersChangedMsg struct{}
+
+// StartPomodoroMsg asks the app to start a Pomodoro attached to a task
+type StartPomodoroMsg struct {
+	TaskID string
+	Title  string
+}
+
+// PomodoroLoggedMsg is sent when a completed Pomodoro was logged against a task
+type PomodoroLoggedMsg struct{}
+
+// filterSavedMsg is sent when the current filter was saved as a smart list
+type filterSavedMsg struct {
+	name string
+	err  error
+}
+
+// saveFilter saves a filter query as a smart list, replacing the query of an
+// existing smart list with the same name
+func (s *TaskScreen) saveFilter(name, query string) tea.Cmd {
+	return func() tea.Msg {
+		filters, err := s.db.SavedFilters().FindAll()
+		if err != nil {
+			return filterSavedMsg{err: err}
+		}
+		for _, existing := range filters {
+			if strings.EqualFold(existing.Name, name) {
+				existing.Query = query
+				return filterSavedMsg{name: existing.Name, err: s.db.SavedFilters().Update(&existing)}
+			}
+		}
+		return filterSavedMsg{name: name, err: s.db.SavedFilters().Create(models.NewSavedFilter(name, query))}
+	}
+}
+
+// currentTask returns the selected task, or the task under the cursor
+func (s *TaskScreen) currentTask() *models.Task {
+	if s.selectedTaskID != "" {
+		return s.getTaskByID(s.selectedTaskID)
+	}
+	tasks, cursor := s.cursorTasks()
+	if cursor < len(tasks) {
+		return s.getTaskByID(tasks[cursor].ID)
+	}
+	return nil
+}
+
+// startPomodoro starts a Pomodoro attached to a task
+func (s *TaskScreen) startPomodoro(task *models.Task) tea.Cmd {
+	msg := StartPomodoroMsg{TaskID: task.ID, Title: task.Title}
+	return tea.Batch(
+		func() tea.Msg { return msg },
+		s.showFeedback(fmt.Sprintf("🍅 Pomodoro started on \"%s\"", task.Title), styles.Info),
+	)
+}
+
+// timerToggledMsg is sent when the timer of a task was started or stopped
+type timerToggledMsg struct {
+	title   string
+	started bool
+	entry   *models.TimeEntry
+	err     error
+}
+
+// toggleTimer stops the timer of a task if it is running, otherwise starts it,
+// stopping the timer of any other task
+func (s *TaskScreen) toggleTimer(task *models.Task) tea.Cmd {
+	running := s.runningEntry != nil && s.runningEntry.TaskID == task.ID
+	title := task.Title
+	id := task.ID
+	return func() tea.Msg {
+		if running {
+			entry, err := s.db.TimeEntries().Stop()
+			if err == nil && entry == nil {
+				err = fmt.Errorf("no timer is running")
+			}
+			return timerToggledMsg{title: title, entry: entry, err: err}
+		}
+		entry, err := s.db.TimeEntries().Start(id)
+		return timerToggledMsg{title: title, started: true, entry: entry, err: err}
+	}
+}
+
+// timeReportLoadedMsg carries the estimate vs actual comparisons
+type timeReportLoadedMsg struct {
+	byCourse []models.TimeComparison
+	byTag    []models.TimeComparison
+	err      error
+}
+
+// loadTimeReport loads the estimate vs actual comparisons
+func (s *TaskScreen) loadTimeReport() tea.Cmd {
+	return func() tea.Msg {
+		byCourse, err := s.db.TimeEntries().CompareByCourse()
+		if err != nil {
+			return timeReportLoadedMsg{err: err}
+		}
+		byTag, err := s.db.TimeEntries().CompareByTag()
+		return timeReportLoadedMsg{byCourse: byCourse, byTag: byTag, err: err}
+	}
+}
+
+// renderTimeReport renders estimated against tracked time per course and per tag
+func (s *TaskScreen) renderTimeReport() string {
+	var b strings.Builder
+
+	b.WriteString(styles.Title.Render("⏱ Estimate vs actual"))
+	b.WriteString("\n")
+	b.WriteString(styles.Dimmed.Render("Tasks with an estimate or tracked time. Running timers count once stopped."))
+	b.WriteString("\n\n")
+
+	if s.timeReport.err != nil {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.Danger).Render("⚠ " + s.timeReport.err.Error()))
+	} else {
+		b.WriteString(s.renderTimeComparisons("By course", s.timeReport.byCourse))
+		b.WriteString("\n")
+		b.WriteString(s.renderTimeComparisons("By tag", s.timeReport.byTag))
+	}
+
+	content := styles.Panel.Copy().
+		BorderForeground(styles.Primary).
+		Width(s.width-2).
+		Height(s.height-8).
+		Padding(1, 2).
+		Render(b.String())
+
+	shortcuts := strings.Join([]string{
+		styles.Shortcut.Render("E") + styles.ShortcutText.Render(" close"),
+		styles.Shortcut.Render("esc") + styles.ShortcutText.Render(" back"),
+	}, "  ")
+
+	return lipgloss.JoinVertical(lipgloss.Left, content, "", shortcuts)
+}
+
+// renderTimeComparisons renders a table of time comparisons
+func (s *TaskScreen) renderTimeComparisons(title string, comparisons []models.TimeComparison) string {
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary)
+	nameWidth := max(10, min(30, s.width-50))
+
+	lines := []string{labelStyle.Render(title)}
+	if len(comparisons) == 0 {
+		lines = append(lines, styles.Dimmed.Render("  Nothing tracked yet"))
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	lines = append(lines, styles.Dimmed.Render(fmt.Sprintf("  %-*s %5s %10s %10s %7s", nameWidth, "", "tasks", "estimate", "actual", "ratio")))
+	for _, c := range comparisons {
+		ratio := "-"
+		color := styles.Foreground
+		if c.EstimatedMinutes > 0 {
+			ratio = fmt.Sprintf("%d%%", c.Ratio())
+			switch {
+			case c.Ratio() > 120:
+				color = styles.Danger
+			case c.Ratio() > 100:
+				color = styles.Warning
+			default:
+				color = styles.Success
+			}
+		}
+		line := fmt.Sprintf("  %-*s %5d %10s %10s %7s", nameWidth, truncate(c.Label, nameWidth), c.Tasks,
+			models.FormatMinutes(c.EstimatedMinutes), models.FormatMinutes(c.TrackedSeconds/60), ratio)
+		lines = append(lines, lipgloss.NewStyle().Foreground(color).Render(line))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// showFeedback shows a temporary message in the shortcuts bar
+func (s *TaskScreen) showFeedback(text string, color lipgloss.Color) tea.Cmd {
+	s.feedbackMsg = lipgloss.NewStyle().Foreground(color).Render(text)
+	return tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return clearFeedbackMsg{} })
+}
@@ -0,0 +1,407 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stiffis/UniCLI/internal/database"
+	"github.com/stiffis/UniCLI/internal/models"
+	"github.com/stiffis/UniCLI/internal/ui/styles"
+)
+
+// trashFilters are the kinds of items the trash list can be narrowed to, the
+// empty kind shows everything
+var trashFilters = []models.SearchKind{
+	"",
+	models.SearchKindTask,
+	models.SearchKindEvent,
+	models.SearchKindCourse,
+	models.SearchKindNote,
+}
+
+// TrashScreen lists deleted tasks, events, courses and notes, which can be
+// restored or purged
+type TrashScreen struct {
+	db            *database.DB
+	retention     time.Duration // Age at which items are purged, 0 keeps them
+	items         []models.TrashItem
+	filter        int // Index into trashFilters
+	selectedIndex int
+	width         int
+	height        int
+	loading       bool
+	err           error
+	feedbackMsg   string
+
+	showPurgeConfirm bool
+	showEmptyConfirm bool
+}
+
+// NewTrashScreen creates a new trash screen
+func NewTrashScreen(db *database.DB, retention time.Duration) *TrashScreen {
+	return &TrashScreen{
+		db:        db,
+		retention: retention,
+		loading:   true,
+	}
+}
+
+// Init loads the trash
+func (s *TrashScreen) Init() tea.Cmd {
+	return s.loadTrash()
+}
+
+func (s *TrashScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		s.height = msg.Height
+		return s, nil
+
+	case trashLoadedMsg:
+		s.loading = false
+		s.err = msg.err
+		if msg.err == nil {
+			s.items = msg.items
+		}
+		if n := len(s.visibleItems()); s.selectedIndex >= n {
+			s.selectedIndex = max(0, n-1)
+		}
+		return s, nil
+
+	case trashChangedMsg:
+		if msg.err != nil {
+			return s, s.showFeedback(fmt.Sprintf("Could not %s: %v", msg.text, msg.err), styles.Danger)
+		}
+		return s, tea.Batch(s.showFeedback(msg.text, styles.Success), s.loadTrash())
+
+	case clearFeedbackMsg:
+		s.feedbackMsg = ""
+		return s, nil
+
+	case tea.KeyMsg:
+		if s.showPurgeConfirm {
+			switch msg.String() {
+			case "y", "Y":
+				s.showPurgeConfirm = false
+				if item := s.selectedItem(); item != nil {
+					return s, s.purgeItem(*item)
+				}
+			case "n", "N", "esc":
+				s.showPurgeConfirm = false
+			}
+			return s, nil
+		}
+
+		if s.showEmptyConfirm {
+			switch msg.String() {
+			case "y", "Y":
+				s.showEmptyConfirm = false
+				return s, s.emptyTrash()
+			case "n", "N", "esc":
+				s.showEmptyConfirm = false
+			}
+			return s, nil
+		}
+
+		switch msg.String() {
+		case "j", "down":
+			if s.selectedIndex < len(s.visibleItems())-1 {
+				s.selectedIndex++
+			}
+		case "k", "up":
+			if s.selectedIndex > 0 {
+				s.selectedIndex--
+			}
+		case "g":
+			s.selectedIndex = 0
+		case "G":
+			s.selectedIndex = max(0, len(s.visibleItems())-1)
+		case "f", "tab":
+			s.filter = (s.filter + 1) % len(trashFilters)
+			s.selectedIndex = 0
+		case "r", "enter":
+			if item := s.selectedItem(); item != nil {
+				return s, s.restoreItem(*item)
+			}
+		case "d", "delete":
+			if s.selectedItem() != nil {
+				s.showPurgeConfirm = true
+			}
+		case "E":
+			if len(s.items) > 0 {
+				s.showEmptyConfirm = true
+			}
+		}
+	}
+
+	return s, nil
+}
+
+func (s *TrashScreen) View() string {
+	if s.width == 0 || s.height == 0 || s.loading {
+		return lipgloss.NewStyle().
+			Padding(2).
+			Foreground(styles.Info).
+			Render("Loading trash...")
+	}
+
+	if s.err != nil {
+		return lipgloss.NewStyle().
+			Padding(2).
+			Foreground(styles.Danger).
+			Render(fmt.Sprintf("Error: %v", s.err))
+	}
+
+	if s.showPurgeConfirm {
+		if item := s.selectedItem(); item != nil {
+			return s.renderConfirmDialog(fmt.Sprintf("Delete %s \"%s\" forever?", strings.ToLower(item.Kind.Label()), item.Title))
+		}
+	}
+	if s.showEmptyConfirm {
+		return s.renderConfirmDialog(fmt.Sprintf("Delete all %d items in the trash forever?", len(s.items)))
+	}
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Primary).
+		Padding(1, 0).
+		Render(" Trash " + styles.Dimmed.Render("· "+s.filterName()))
+
+	retention := "Deleted items are kept until the trash is emptied"
+	if s.retention > 0 {
+		retention = fmt.Sprintf("Deleted items are purged after %d days", int(s.retention.Hours()/24))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		styles.Dimmed.Render(retention),
+		"",
+		s.renderList(s.height-9),
+		"",
+		s.renderShortcuts(),
+	)
+}
+
+// renderList renders the visible items of the trash
+func (s *TrashScreen) renderList(height int) string {
+	items := s.visibleItems()
+	if len(items) == 0 {
+		return styles.Dimmed.Render("The trash is empty.")
+	}
+
+	kindWidth := 13
+	dateWidth := 24
+	titleWidth := max(10, s.width-kindWidth-dateWidth-6)
+
+	// Keep the selected item visible
+	visible := max(1, height)
+	start := 0
+	if s.selectedIndex >= visible {
+		start = s.selectedIndex - visible + 1
+	}
+
+	now := time.Now()
+	var lines []string
+	for i := start; i < len(items) && i < start+visible; i++ {
+		item := items[i]
+
+		title := item.Title
+		if item.Course != "" {
+			title += " (" + item.Course + ")"
+		}
+
+		deleted := "deleted " + item.DeletedAt.Format("Jan 02 15:04")
+		if expires := item.ExpiresAt(s.retention); !expires.IsZero() {
+			days := max(0, int(expires.Sub(now).Hours()/24))
+			deleted += fmt.Sprintf(" · %dd left", days)
+		}
+
+		kindStyle := lipgloss.NewStyle().Width(kindWidth).Foreground(trashKindColor(item.Kind))
+		titleStyle := lipgloss.NewStyle().Width(titleWidth)
+		dateStyle := lipgloss.NewStyle().Width(dateWidth).Foreground(styles.Muted)
+		lineStyle := lipgloss.NewStyle().Padding(0, 1)
+		if i == s.selectedIndex {
+			selected := lipgloss.NewStyle().Background(styles.Primary).Foreground(styles.Background).Bold(true)
+			kindStyle = kindStyle.Inherit(selected).Foreground(styles.Background)
+			titleStyle = titleStyle.Inherit(selected)
+			dateStyle = dateStyle.Inherit(selected).Foreground(styles.Background)
+			lineStyle = lineStyle.Inherit(selected)
+		}
+
+		line := kindStyle.Render(item.Kind.Label()) +
+			titleStyle.Render(truncate(title, titleWidth-1)) +
+			dateStyle.Render(deleted)
+		lines = append(lines, lineStyle.Render(line))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderConfirmDialog renders a confirmation dialog for a permanent deletion
+func (s *TrashScreen) renderConfirmDialog(question string) string {
+	dialog := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Danger).
+		Padding(1, 2).
+		Render(lipgloss.JoinVertical(
+			lipgloss.Center,
+			styles.Title.Render(question),
+			"",
+			styles.Dimmed.Render("This action cannot be undone."),
+			"",
+			lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				styles.Shortcut.Render("y")+styles.ShortcutText.Render(" delete"),
+				"  ",
+				styles.Shortcut.Render("n")+styles.ShortcutText.Render(" cancel"),
+			),
+		))
+
+	return lipgloss.Place(
+		s.width,
+		s.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
+
+// renderShortcuts renders keyboard shortcuts or a feedback message
+func (s *TrashScreen) renderShortcuts() string {
+	if s.feedbackMsg != "" {
+		return s.feedbackMsg
+	}
+
+	shortcuts := []string{
+		styles.Shortcut.Render("j/k") + styles.ShortcutText.Render(" navigate"),
+		styles.Shortcut.Render("r") + styles.ShortcutText.Render(" restore"),
+		styles.Shortcut.Render("d") + styles.ShortcutText.Render(" delete forever"),
+		styles.Shortcut.Render("E") + styles.ShortcutText.Render(" empty trash"),
+		styles.Shortcut.Render("f") + styles.ShortcutText.Render(" filter"),
+	}
+
+	return strings.Join(shortcuts, "  ")
+}
+
+// showFeedback shows a temporary feedback message in the shortcuts bar
+func (s *TrashScreen) showFeedback(text string, color lipgloss.Color) tea.Cmd {
+	s.feedbackMsg = lipgloss.NewStyle().Foreground(color).Render(text)
+	return tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return clearFeedbackMsg{} })
+}
+
+// filterName returns the description of the active kind filter
+func (s *TrashScreen) filterName() string {
+	switch trashFilters[s.filter] {
+	case "":
+		return "All items"
+	case models.SearchKindTask:
+		return "Tasks"
+	case models.SearchKindEvent:
+		return "Events"
+	case models.SearchKindCourse:
+		return "Courses"
+	default:
+		return "Notes"
+	}
+}
+
+// visibleItems returns the items matching the active filter. Course notes
+// are listed with notes.
+func (s *TrashScreen) visibleItems() []models.TrashItem {
+	kind := trashFilters[s.filter]
+	if kind == "" {
+		return s.items
+	}
+
+	var items []models.TrashItem
+	for _, item := range s.items {
+		itemKind := item.Kind
+		if itemKind == models.SearchKindCourseNote {
+			itemKind = models.SearchKindNote
+		}
+		if itemKind == kind {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// selectedItem returns the item under the cursor
+func (s *TrashScreen) selectedItem() *models.TrashItem {
+	items := s.visibleItems()
+	if s.selectedIndex < 0 || s.selectedIndex >= len(items) {
+		return nil
+	}
+	return &items[s.selectedIndex]
+}
+
+// trashKindColor returns the color of the kind label of an item
+func trashKindColor(kind models.SearchKind) lipgloss.Color {
+	switch kind {
+	case models.SearchKindTask:
+		return styles.Primary
+	case models.SearchKindEvent:
+		return styles.Info
+	case models.SearchKindCourse:
+		return styles.Secondary
+	default:
+		return styles.Success
+	}
+}
+
+// loadTrash loads the items in the trash
+func (s *TrashScreen) loadTrash() tea.Cmd {
+	return func() tea.Msg {
+		items, err := s.db.Trash().FindAll()
+		return trashLoadedMsg{items: items, err: err}
+	}
+}
+
+// restoreItem moves an item out of the trash
+func (s *TrashScreen) restoreItem(item models.TrashItem) tea.Cmd {
+	return func() tea.Msg {
+		if err := s.db.Trash().Restore(item.Kind, item.ID); err != nil {
+			return trashChangedMsg{text: "restore", err: err}
+		}
+		return trashChangedMsg{text: fmt.Sprintf("Restored %s \"%s\"", strings.ToLower(item.Kind.Label()), item.Title)}
+	}
+}
+
+// purgeItem permanently deletes an item
+func (s *TrashScreen) purgeItem(item models.TrashItem) tea.Cmd {
+	return func() tea.Msg {
+		if err := s.db.Trash().Purge(item.Kind, item.ID); err != nil {
+			return trashChangedMsg{text: "delete", err: err}
+		}
+		return trashChangedMsg{text: fmt.Sprintf("Deleted %s \"%s\" forever", strings.ToLower(item.Kind.Label()), item.Title)}
+	}
+}
+
+// emptyTrash permanently deletes every item in the trash
+func (s *TrashScreen) emptyTrash() tea.Cmd {
+	return func() tea.Msg {
+		count, err := s.db.Trash().Empty()
+		if err != nil {
+			return trashChangedMsg{text: "empty the trash", err: err}
+		}
+		return trashChangedMsg{text: fmt.Sprintf("Deleted %d items forever", count)}
+	}
+}
+
+// Messages
+type trashLoadedMsg struct {
+	items []models.TrashItem
+	err   error
+}
+
+// trashChangedMsg reports a restore or purge. text is the confirmation, or
+// the failed action if err is set.
+type trashChangedMsg struct {
+	text string
+	err  error
+}
@@ -114,6 +114,12 @@ func (w *WeekView) Update(msg tea.Msg) (*WeekView, tea.Cmd) {
 		w.width = msg.Width
 		w.height = msg.Height
 
+	case components.EditorFinishedMsg:
+		if w.showEventForm {
+			w.eventForm, cmd = w.eventForm.Update(msg)
+			return w, cmd
+		}
+
 	case tea.KeyMsg:
 		if w.showDeleteConfirm {
 			switch msg.String() {
@@ -295,7 +301,7 @@ func (w *WeekView) createEvent(event *models.Event) tea.Cmd {
 
 func (w *WeekView) updateEvent(event *models.Event) tea.Cmd {
 	return func() tea.Msg {
-		err := w.db.Events().Update(event)
+		err := updateEventRecorded(w.db, event)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -305,7 +311,7 @@ func (w *WeekView) updateEvent(event *models.Event) tea.Cmd {
 
 func (w *WeekView) deleteEvent(eventID string) tea.Cmd {
 	return func() tea.Msg {
-		err := w.db.Events().Delete(eventID)
+		err := deleteEventRecorded(w.db, eventID)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -355,7 +361,7 @@ func (w *WeekView) renderDeleteConfirmDialog(baseView string) string {
 			lipgloss.Center,
 			styles.Title.Render(question),
 			"",
-			styles.Dimmed.Render("This action cannot be undone."),
+			styles.Dimmed.Render("It can be restored from the trash."),
 			"",
 			lipgloss.JoinHorizontal(
 				lipgloss.Top,
@@ -0,0 +1,84 @@
+// Package undo records reversible changes so they can be undone and redone.
+package undo
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNothingToUndo is returned by Undo when no change was recorded
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// ErrNothingToRedo is returned by Redo when no change was undone
+var ErrNothingToRedo = errors.New("nothing to redo")
+
+// Action is a recorded change with the operations reverting and repeating it
+type Action struct {
+	Description string // What was done, e.g. `delete task "Essay"`
+	Undo        func() error
+	Redo        func() error
+}
+
+// Stack holds the recorded actions. It is safe for concurrent use since
+// actions are recorded from commands running outside the UI loop.
+type Stack struct {
+	mu     sync.Mutex
+	done   []Action
+	undone []Action
+	limit  int
+}
+
+// NewStack creates a stack keeping the last limit actions
+func NewStack(limit int) *Stack {
+	return &Stack{limit: limit}
+}
+
+// Push records an action that was just performed. Recording a new action
+// clears the actions that could be redone.
+func (s *Stack) Push(action Action) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.done = append(s.done, action)
+	if s.limit > 0 && len(s.done) > s.limit {
+		s.done = s.done[len(s.done)-s.limit:]
+	}
+	s.undone = nil
+}
+
+// Undo reverts the last action. An action that fails to revert is dropped,
+// since the data it refers to has most likely changed since.
+func (s *Stack) Undo() (Action, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.done) == 0 {
+		return Action{}, ErrNothingToUndo
+	}
+	action := s.done[len(s.done)-1]
+	s.done = s.done[:len(s.done)-1]
+
+	if err := action.Undo(); err != nil {
+		return action, err
+	}
+	s.undone = append(s.undone, action)
+	return action, nil
+}
+
+// Redo repeats the last undone action
+func (s *Stack) Redo() (Action, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.undone) == 0 {
+		return Action{}, ErrNothingToRedo
+	}
+	action := s.undone[len(s.undone)-1]
+	s.undone = s.undone[:len(s.undone)-1]
+
+	if err := action.Redo(); err != nil {
+		return action, err
+	}
+	s.done = append(s.done, action)
+	return action, nil
+}